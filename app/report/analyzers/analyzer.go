@@ -0,0 +1,18 @@
+package analyzers
+
+import (
+	"context"
+	"gdsc/baro/app/report/types"
+)
+
+// Analyzer runs posture analysis on a video.
+// The report service only depends on this interface, so the AI server can be
+// swapped for FakeAnalyzer in tests and local runs.
+type Analyzer interface {
+	Analyze(ctx context.Context, videoURL string) (types.ResponseAnalysis, error)
+}
+
+// StatusReporter is implemented by analyzers that can describe their AI server endpoints.
+type StatusReporter interface {
+	Status() []types.ResponseAnalyzerEndpoint
+}
@@ -0,0 +1,263 @@
+package analyzers
+
+import (
+	"context"
+	"errors"
+	"gdsc/baro/app/report/types"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNoAvailableEndpoint is returned when every AI server endpoint is unhealthy
+// or has an open circuit.
+var ErrNoAvailableEndpoint = errors.New("no ai server endpoint is available")
+
+type BalanceStrategy string
+
+const (
+	RoundRobin  BalanceStrategy = "round_robin"
+	LeastLoaded BalanceStrategy = "least_loaded"
+)
+
+const DefaultHealthInterval = 15 * time.Second
+
+// Endpoint is one AI server instance behind the BalancedAnalyzer.
+// HealthURL is polled by StartHealthChecks; an endpoint without one is always treated as healthy.
+type Endpoint struct {
+	URL       string
+	HealthURL string
+	Analyzer  Analyzer
+	Breaker   *CircuitBreaker
+
+	inFlight atomic.Int64
+
+	mu            sync.Mutex
+	healthy       bool
+	lastError     string
+	lastCheckedAt *time.Time
+}
+
+func NewEndpoint(url string, healthURL string, analyzer Analyzer, breaker *CircuitBreaker) *Endpoint {
+	return &Endpoint{
+		URL:       url,
+		HealthURL: healthURL,
+		Analyzer:  analyzer,
+		Breaker:   breaker,
+		healthy:   true,
+	}
+}
+
+func (endpoint *Endpoint) isHealthy() bool {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+
+	return endpoint.healthy
+}
+
+func (endpoint *Endpoint) setHealth(healthy bool, err error) {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+
+	now := time.Now()
+	endpoint.healthy = healthy
+	endpoint.lastCheckedAt = &now
+	if err != nil {
+		endpoint.lastError = err.Error()
+	}
+}
+
+func (endpoint *Endpoint) setLastError(err error) {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+
+	endpoint.lastError = err.Error()
+}
+
+// BalancedAnalyzer spreads analyses over several AI server endpoints.
+// Endpoints that fail their health check or whose circuit is open are skipped.
+type BalancedAnalyzer struct {
+	Endpoints      []*Endpoint
+	Strategy       BalanceStrategy
+	HealthInterval time.Duration
+	Client         *http.Client
+
+	next atomic.Uint64
+}
+
+func NewBalancedAnalyzer(endpoints []*Endpoint, strategy BalanceStrategy) *BalancedAnalyzer {
+	if strategy != LeastLoaded {
+		strategy = RoundRobin
+	}
+
+	return &BalancedAnalyzer{
+		Endpoints:      endpoints,
+		Strategy:       strategy,
+		HealthInterval: DefaultHealthInterval,
+		Client:         &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (analyzer *BalancedAnalyzer) Analyze(ctx context.Context, videoURL string) (types.ResponseAnalysis, error) {
+	var response types.ResponseAnalysis
+
+	err := analyzer.call(func(endpoint *Endpoint) error {
+		var err error
+		response, err = endpoint.Analyzer.Analyze(ctx, videoURL)
+		return err
+	})
+	if err != nil {
+		return types.ResponseAnalysis{}, err
+	}
+
+	return response, nil
+}
+
+func (analyzer *BalancedAnalyzer) Submit(ctx context.Context, request types.RequestAnalysisToAi) error {
+	return analyzer.call(func(endpoint *Endpoint) error {
+		submitter, ok := endpoint.Analyzer.(Submitter)
+		if !ok {
+			return ErrCallbackUnsupported
+		}
+		return submitter.Submit(ctx, request)
+	})
+}
+
+// call runs fn against the next available endpoint and reports the outcome to its circuit breaker.
+func (analyzer *BalancedAnalyzer) call(fn func(endpoint *Endpoint) error) error {
+	endpoint := analyzer.pick()
+	if endpoint == nil {
+		return ErrNoAvailableEndpoint
+	}
+
+	endpoint.inFlight.Add(1)
+	defer endpoint.inFlight.Add(-1)
+
+	err := fn(endpoint)
+	switch {
+	case err == nil:
+		endpoint.Breaker.Success()
+	case errors.Is(err, context.Canceled):
+		endpoint.Breaker.Release()
+	case IsRetryable(err):
+		endpoint.Breaker.Failure()
+		endpoint.setLastError(err)
+	default:
+		// The endpoint answered; the request itself was rejected.
+		endpoint.Breaker.Success()
+	}
+
+	return err
+}
+
+// pick returns the next endpoint to use and reserves it with its circuit breaker,
+// or nil if none is available.
+func (analyzer *BalancedAnalyzer) pick() *Endpoint {
+	if len(analyzer.Endpoints) == 0 {
+		return nil
+	}
+
+	start := int(analyzer.next.Add(1)-1) % len(analyzer.Endpoints)
+
+	candidates := make([]*Endpoint, 0, len(analyzer.Endpoints))
+	for i := range analyzer.Endpoints {
+		endpoint := analyzer.Endpoints[(start+i)%len(analyzer.Endpoints)]
+		if endpoint.isHealthy() {
+			candidates = append(candidates, endpoint)
+		}
+	}
+
+	if analyzer.Strategy == LeastLoaded {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].inFlight.Load() < candidates[j].inFlight.Load()
+		})
+	}
+
+	for _, endpoint := range candidates {
+		if endpoint.Breaker.Allow() {
+			return endpoint
+		}
+	}
+
+	return nil
+}
+
+// StartHealthChecks polls the HealthURL of every endpoint until ctx is cancelled.
+func (analyzer *BalancedAnalyzer) StartHealthChecks(ctx context.Context) {
+	analyzer.CheckHealth(ctx)
+
+	go func() {
+		ticker := time.NewTicker(analyzer.HealthInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				analyzer.CheckHealth(ctx)
+			}
+		}
+	}()
+}
+
+// CheckHealth runs one health check against every endpoint that has a HealthURL.
+func (analyzer *BalancedAnalyzer) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, endpoint := range analyzer.Endpoints {
+		if endpoint.HealthURL == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(endpoint *Endpoint) {
+			defer wg.Done()
+			endpoint.setHealth(analyzer.probe(ctx, endpoint.HealthURL))
+		}(endpoint)
+	}
+
+	wg.Wait()
+}
+
+func (analyzer *BalancedAnalyzer) probe(ctx context.Context, healthURL string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	response, err := analyzer.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return false, &StatusError{StatusCode: response.StatusCode}
+	}
+
+	return true, nil
+}
+
+// Status returns the current state of every endpoint for diagnostics.
+func (analyzer *BalancedAnalyzer) Status() []types.ResponseAnalyzerEndpoint {
+	status := make([]types.ResponseAnalyzerEndpoint, 0, len(analyzer.Endpoints))
+
+	for _, endpoint := range analyzer.Endpoints {
+		endpoint.mu.Lock()
+		status = append(status, types.ResponseAnalyzerEndpoint{
+			URL:           endpoint.URL,
+			Healthy:       endpoint.healthy,
+			CircuitState:  string(endpoint.Breaker.State()),
+			Failures:      endpoint.Breaker.Failures(),
+			InFlight:      endpoint.inFlight.Load(),
+			LastError:     endpoint.lastError,
+			LastCheckedAt: endpoint.lastCheckedAt,
+		})
+		endpoint.mu.Unlock()
+	}
+
+	return status
+}
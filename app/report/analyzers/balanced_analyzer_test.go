@@ -0,0 +1,179 @@
+package analyzers_test
+
+import (
+	"context"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/types"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingAnalyzer holds every call until release is closed.
+type blockingAnalyzer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingAnalyzer) Analyze(ctx context.Context, videoURL string) (types.ResponseAnalysis, error) {
+	a.started <- struct{}{}
+	<-a.release
+	return analyzers.GoodPostureFixture(), nil
+}
+
+func newTestEndpoint(url string, analyzer analyzers.Analyzer) *analyzers.Endpoint {
+	return analyzers.NewEndpoint(url, "", analyzer, analyzers.NewCircuitBreaker(2, time.Hour))
+}
+
+func TestBalancedAnalyzer_RoundRobin(t *testing.T) {
+	// Create two endpoints
+	first := analyzers.NewFakeAnalyzer()
+	second := analyzers.NewFakeAnalyzer()
+	analyzer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		newTestEndpoint("http://ai-1", first),
+		newTestEndpoint("http://ai-2", second),
+	}, analyzers.RoundRobin)
+
+	// Call the method under test
+	for i := 0; i < 4; i++ {
+		_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+		assert.NoError(t, err)
+	}
+
+	// Check the result
+	assert.Len(t, first.Calls(), 2)
+	assert.Len(t, second.Calls(), 2)
+}
+
+func TestBalancedAnalyzer_SkipsOpenCircuit(t *testing.T) {
+	// Create a failing endpoint and a good one
+	failing := analyzers.NewFakeAnalyzer()
+	failing.Err = &analyzers.StatusError{StatusCode: 503}
+	good := analyzers.NewFakeAnalyzer()
+	analyzer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		newTestEndpoint("http://ai-1", failing),
+		newTestEndpoint("http://ai-2", good),
+	}, analyzers.RoundRobin)
+
+	// Call the method under test
+	for i := 0; i < 6; i++ {
+		analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+	}
+
+	// Check the result: the failing endpoint gets no traffic once its circuit opens
+	assert.Len(t, failing.Calls(), 2)
+	assert.Len(t, good.Calls(), 4)
+
+	status := analyzer.Status()
+	assert.Equal(t, string(analyzers.CircuitOpen), status[0].CircuitState)
+	assert.Equal(t, "ai server responded with status 503", status[0].LastError)
+	assert.Equal(t, string(analyzers.CircuitClosed), status[1].CircuitState)
+}
+
+func TestBalancedAnalyzer_RejectedRequestKeepsCircuitClosed(t *testing.T) {
+	// Create an endpoint that rejects the video
+	rejecting := analyzers.NewFakeAnalyzer()
+	rejecting.Err = &analyzers.StatusError{StatusCode: 422}
+	analyzer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		newTestEndpoint("http://ai-1", rejecting),
+	}, analyzers.RoundRobin)
+
+	// Call the method under test
+	for i := 0; i < 3; i++ {
+		_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+		assert.Error(t, err)
+	}
+
+	// Check the result
+	assert.Equal(t, string(analyzers.CircuitClosed), analyzer.Status()[0].CircuitState)
+}
+
+func TestBalancedAnalyzer_NoAvailableEndpoint(t *testing.T) {
+	// Create a single failing endpoint
+	failing := analyzers.NewFakeAnalyzer()
+	failing.Err = &analyzers.StatusError{StatusCode: 500}
+	analyzer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		newTestEndpoint("http://ai-1", failing),
+	}, analyzers.RoundRobin)
+
+	// Open its circuit
+	analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+	analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Call the method under test
+	_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.ErrorIs(t, err, analyzers.ErrNoAvailableEndpoint)
+	assert.True(t, analyzers.IsRetryable(err))
+}
+
+func TestBalancedAnalyzer_LeastLoaded(t *testing.T) {
+	// Create a busy endpoint and an idle one
+	busy := &blockingAnalyzer{started: make(chan struct{}), release: make(chan struct{})}
+	idle := analyzers.NewFakeAnalyzer()
+	analyzer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		newTestEndpoint("http://ai-1", busy),
+		newTestEndpoint("http://ai-2", idle),
+	}, analyzers.LeastLoaded)
+
+	// Keep one request in flight on the busy endpoint
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		analyzer.Analyze(context.Background(), "https://example.com/slow.mp4")
+	}()
+	<-busy.started
+
+	// Call the method under test
+	for i := 0; i < 3; i++ {
+		_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+		assert.NoError(t, err)
+	}
+
+	// Check the result
+	assert.Len(t, idle.Calls(), 3)
+	assert.Equal(t, int64(1), analyzer.Status()[0].InFlight)
+
+	close(busy.release)
+	wg.Wait()
+}
+
+func TestBalancedAnalyzer_HealthCheck(t *testing.T) {
+	// Create one healthy and one unhealthy AI server
+	healthyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer healthyServer.Close()
+	unhealthyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthyServer.Close()
+
+	healthy := analyzers.NewFakeAnalyzer()
+	unhealthy := analyzers.NewFakeAnalyzer()
+	analyzer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		analyzers.NewEndpoint(unhealthyServer.URL, unhealthyServer.URL+"/health", unhealthy, analyzers.NewCircuitBreaker(2, time.Hour)),
+		analyzers.NewEndpoint(healthyServer.URL, healthyServer.URL+"/health", healthy, analyzers.NewCircuitBreaker(2, time.Hour)),
+	}, analyzers.RoundRobin)
+
+	// Call the method under test
+	analyzer.CheckHealth(context.Background())
+	for i := 0; i < 3; i++ {
+		_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+		assert.NoError(t, err)
+	}
+
+	// Check the result
+	assert.Empty(t, unhealthy.Calls())
+	assert.Len(t, healthy.Calls(), 3)
+
+	status := analyzer.Status()
+	assert.False(t, status[0].Healthy)
+	assert.NotNil(t, status[0].LastCheckedAt)
+	assert.Equal(t, "ai server responded with status 503", status[0].LastError)
+	assert.True(t, status[1].Healthy)
+}
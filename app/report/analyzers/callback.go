@@ -0,0 +1,68 @@
+package analyzers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"gdsc/baro/app/report/types"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Callbacks from the AI server carry the unix time they were sent and an HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the shared secret.
+const (
+	SignatureHeader          = "X-Baro-Signature"
+	TimestampHeader          = "X-Baro-Timestamp"
+	DefaultCallbackTolerance = 5 * time.Minute
+	// MaxCallbackSize bounds the body of a callback, which is read before its signature is checked.
+	// A result takes a few hundred bytes per analyzed frame, so this leaves room for tens of thousands of frames.
+	MaxCallbackSize = 8 << 20
+)
+
+var (
+	ErrInvalidSignature    = errors.New("invalid callback signature")
+	ErrCallbackUnsupported = errors.New("analyzer does not support callbacks")
+)
+
+// Submitter hands a video to the AI server without waiting for the result.
+// The AI server posts a types.RequestAnalysisCallback to request.CallbackURL when it is done.
+type Submitter interface {
+	Submit(ctx context.Context, request types.RequestAnalysisToAi) error
+}
+
+func SignCallback(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCallback checks the signature of a callback body and rejects callbacks
+// sent more than tolerance away from now, so a captured request cannot be replayed later.
+func VerifyCallback(secret string, timestamp string, signature string, body []byte, now time.Time, tolerance time.Duration) error {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return ErrInvalidSignature
+	}
+
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	skew := now.Sub(time.Unix(sentAt, 0))
+	if skew > tolerance || skew < -tolerance {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(SignCallback(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package analyzers_test
+
+import (
+	"context"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/types"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyCallback(t *testing.T) {
+	// Sign a callback body
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"job_id":1,"result":{"result":[0,1]}}`)
+	signature := analyzers.SignCallback("secret", timestamp, body)
+
+	// Call the method under test
+	err := analyzers.VerifyCallback("secret", timestamp, signature, body, now, time.Minute)
+
+	// Check the result
+	assert.NoError(t, err)
+}
+
+func TestVerifyCallback_Rejected(t *testing.T) {
+	// Sign a callback body
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"job_id":1,"result":{"result":[0,1]}}`)
+	signature := analyzers.SignCallback("secret", timestamp, body)
+
+	tests := map[string]func() error{
+		"wrong secret": func() error {
+			return analyzers.VerifyCallback("other", timestamp, signature, body, now, time.Minute)
+		},
+		"tampered body": func() error {
+			return analyzers.VerifyCallback("secret", timestamp, signature, []byte(`{"job_id":2}`), now, time.Minute)
+		},
+		"replayed": func() error {
+			return analyzers.VerifyCallback("secret", timestamp, signature, body, now.Add(10*time.Minute), time.Minute)
+		},
+		"missing signature": func() error {
+			return analyzers.VerifyCallback("secret", timestamp, "", body, now, time.Minute)
+		},
+		"no secret configured": func() error {
+			return analyzers.VerifyCallback("", timestamp, analyzers.SignCallback("", timestamp, body), body, now, time.Minute)
+		},
+	}
+
+	for name, verify := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, verify(), analyzers.ErrInvalidSignature)
+		})
+	}
+}
+
+func TestRetryAnalyzer_Submit(t *testing.T) {
+	// Wrap a balanced fake endpoint, as the server does
+	fake := analyzers.NewFakeAnalyzer()
+	balancer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		analyzers.NewEndpoint("http://ai-1", "", fake, analyzers.NewCircuitBreaker(2, time.Hour)),
+	}, analyzers.RoundRobin)
+	analyzer := analyzers.NewRetryAnalyzer(balancer, testRetryPolicy())
+	request := types.RequestAnalysisToAi{VideoURL: "https://example.com/video.mp4", JobID: 3, CallbackURL: "https://api.example.com/cb"}
+
+	// Call the method under test
+	err := analyzer.Submit(context.Background(), request)
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RequestAnalysisToAi{request}, fake.Submitted())
+}
+
+func TestRetryAnalyzer_Submit_Unsupported(t *testing.T) {
+	// Wrap an analyzer that cannot submit
+	analyzer := analyzers.NewRetryAnalyzer(&sequenceAnalyzer{}, testRetryPolicy())
+
+	// Call the method under test
+	err := analyzer.Submit(context.Background(), types.RequestAnalysisToAi{VideoURL: "https://example.com/video.mp4"})
+
+	// Check the result
+	assert.ErrorIs(t, err, analyzers.ErrCallbackUnsupported)
+}
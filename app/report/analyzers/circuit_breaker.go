@@ -0,0 +1,118 @@
+package analyzers
+
+import (
+	"sync"
+	"time"
+)
+
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+)
+
+// CircuitBreaker stops traffic to an endpoint after FailureThreshold consecutive failures.
+// Once OpenTimeout has passed, a single trial request is let through:
+// success closes the circuit again, failure keeps it open for another OpenTimeout.
+type CircuitBreaker struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = DefaultFailureThreshold
+	}
+	if openTimeout <= 0 {
+		openTimeout = DefaultOpenTimeout
+	}
+
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		OpenTimeout:      openTimeout,
+		state:            CircuitClosed,
+	}
+}
+
+// Allow reports whether a request may be sent now.
+// In the half-open state only one trial request is allowed until it is reported.
+func (breaker *CircuitBreaker) Allow() bool {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	if breaker.state == CircuitOpen && time.Since(breaker.openedAt) >= breaker.OpenTimeout {
+		breaker.state = CircuitHalfOpen
+		breaker.trial = false
+	}
+
+	switch breaker.state {
+	case CircuitOpen:
+		return false
+	case CircuitHalfOpen:
+		if breaker.trial {
+			return false
+		}
+		breaker.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (breaker *CircuitBreaker) Success() {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	breaker.state = CircuitClosed
+	breaker.failures = 0
+	breaker.trial = false
+}
+
+func (breaker *CircuitBreaker) Failure() {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	breaker.failures++
+	breaker.trial = false
+
+	if breaker.state == CircuitHalfOpen || breaker.failures >= breaker.FailureThreshold {
+		breaker.state = CircuitOpen
+		breaker.openedAt = time.Now()
+	}
+}
+
+// Release gives back a trial request that ended without telling anything about the endpoint,
+// e.g. because the caller cancelled it.
+func (breaker *CircuitBreaker) Release() {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	breaker.trial = false
+}
+
+func (breaker *CircuitBreaker) State() CircuitState {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	return breaker.state
+}
+
+func (breaker *CircuitBreaker) Failures() int {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	return breaker.failures
+}
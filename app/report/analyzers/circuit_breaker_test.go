@@ -0,0 +1,77 @@
+package analyzers_test
+
+import (
+	"gdsc/baro/app/report/analyzers"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	// Create a breaker that opens after 3 failures
+	breaker := analyzers.NewCircuitBreaker(3, time.Hour)
+
+	// Two failures keep the circuit closed
+	breaker.Failure()
+	breaker.Failure()
+	assert.Equal(t, analyzers.CircuitClosed, breaker.State())
+	assert.True(t, breaker.Allow())
+
+	// The third failure opens it
+	breaker.Failure()
+	assert.Equal(t, analyzers.CircuitOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	// Create a breaker that opens after 2 failures
+	breaker := analyzers.NewCircuitBreaker(2, time.Hour)
+
+	// A success in between resets the count
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+
+	// Check the result
+	assert.Equal(t, analyzers.CircuitClosed, breaker.State())
+	assert.Equal(t, 1, breaker.Failures())
+}
+
+func TestCircuitBreaker_HalfOpenTrial(t *testing.T) {
+	// Open the circuit with a short open timeout
+	breaker := analyzers.NewCircuitBreaker(1, 10*time.Millisecond)
+	breaker.Failure()
+	assert.False(t, breaker.Allow())
+
+	// After the timeout only a single trial is let through
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, breaker.Allow())
+	assert.Equal(t, analyzers.CircuitHalfOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+
+	// A failed trial opens the circuit again
+	breaker.Failure()
+	assert.Equal(t, analyzers.CircuitOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+
+	// A successful trial closes it
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, analyzers.CircuitClosed, breaker.State())
+	assert.True(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_ReleaseTrial(t *testing.T) {
+	// Open the circuit and take the trial
+	breaker := analyzers.NewCircuitBreaker(1, 10*time.Millisecond)
+	breaker.Failure()
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, breaker.Allow())
+
+	// Releasing the trial lets the next request try again
+	breaker.Release()
+	assert.True(t, breaker.Allow())
+	assert.Equal(t, analyzers.CircuitHalfOpen, breaker.State())
+}
@@ -0,0 +1,46 @@
+package analyzers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ErrInvalidResponse is returned when the AI server answers with a body
+// that is not a types.ResponseAnalysis.
+var ErrInvalidResponse = errors.New("failed to parse ai server response")
+
+// StatusError is returned when the AI server answers with a non-2xx status.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("ai server responded with status %d", e.StatusCode)
+}
+
+// IsRetryable reports whether a failed analysis may succeed if it is sent again:
+// 5xx and 429 responses, timeouts, network errors, unreadable bodies and
+// having no AI server endpoint available.
+// Other 4xx responses and cancellation by the caller are permanent.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 ||
+			statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode == http.StatusRequestTimeout
+	}
+
+	if errors.Is(err, ErrInvalidResponse) || errors.Is(err, ErrNoAvailableEndpoint) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,137 @@
+package analyzers
+
+import (
+	"context"
+	"encoding/json"
+	"gdsc/baro/app/report/types"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// FakeAnalyzer answers with fixed results instead of calling the AI server.
+// Responses are looked up by video URL and fall back to Default.
+type FakeAnalyzer struct {
+	Responses map[string]types.ResponseAnalysis
+	Default   types.ResponseAnalysis
+	Err       error
+
+	mu        sync.Mutex
+	calls     []string
+	submitted []types.RequestAnalysisToAi
+}
+
+func NewFakeAnalyzer() *FakeAnalyzer {
+	return &FakeAnalyzer{
+		Responses: map[string]types.ResponseAnalysis{},
+		Default:   GoodPostureFixture(),
+	}
+}
+
+func (analyzer *FakeAnalyzer) Analyze(ctx context.Context, videoURL string) (types.ResponseAnalysis, error) {
+	analyzer.mu.Lock()
+	analyzer.calls = append(analyzer.calls, videoURL)
+	analyzer.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return types.ResponseAnalysis{}, err
+	}
+
+	if analyzer.Err != nil {
+		return types.ResponseAnalysis{}, analyzer.Err
+	}
+
+	if response, ok := analyzer.Responses[videoURL]; ok {
+		return response, nil
+	}
+
+	return analyzer.Default, nil
+}
+
+// Calls returns the video URLs the fake was asked to analyze, in order.
+func (analyzer *FakeAnalyzer) Calls() []string {
+	analyzer.mu.Lock()
+	defer analyzer.mu.Unlock()
+
+	return append([]string(nil), analyzer.calls...)
+}
+
+// Submit records the request; tests deliver the result through the callback themselves.
+func (analyzer *FakeAnalyzer) Submit(ctx context.Context, request types.RequestAnalysisToAi) error {
+	analyzer.mu.Lock()
+	analyzer.submitted = append(analyzer.submitted, request)
+	analyzer.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return analyzer.Err
+}
+
+// Submitted returns the requests passed to Submit, in order.
+func (analyzer *FakeAnalyzer) Submitted() []types.RequestAnalysisToAi {
+	analyzer.mu.Lock()
+	defer analyzer.mu.Unlock()
+
+	return append([]types.RequestAnalysisToAi(nil), analyzer.submitted...)
+}
+
+// NewFakeServer serves the given analyzer over HTTP with the same contract as the AI server,
+// so HTTPAnalyzer can be pointed at it. The caller must Close the server.
+func NewFakeServer(analyzer Analyzer) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request types.RequestAnalysisToAi
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response, err := analyzer.Analyze(r.Context(), request.VideoURL)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+// GoodPostureFixture is a short session where every frame is normal.
+func GoodPostureFixture() types.ResponseAnalysis {
+	return types.ResponseAnalysis{
+		Result:       []int{1, 1, 1, 1, 1, 1},
+		HunchedRatio: 0,
+		NormalRatio:  100,
+		Scores:       []float64{99.9, 92.9, 96.3, 92.4, 99.1, 92.0},
+		LandmarksInfo: []types.LandmarkInfo{
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.61}, LeftEar: types.Landmark{X: 0.55, Y: 0.32}, VerticalDistanceCM: 14.5, Angle: 12.1},
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.61}, LeftEar: types.Landmark{X: 0.56, Y: 0.33}, VerticalDistanceCM: 14.2, Angle: 13.4},
+			{LeftShoulder: types.Landmark{X: 0.51, Y: 0.60}, LeftEar: types.Landmark{X: 0.55, Y: 0.32}, VerticalDistanceCM: 14.4, Angle: 12.8},
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.61}, LeftEar: types.Landmark{X: 0.57, Y: 0.33}, VerticalDistanceCM: 14.0, Angle: 14.2},
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.60}, LeftEar: types.Landmark{X: 0.55, Y: 0.31}, VerticalDistanceCM: 14.6, Angle: 11.9},
+			{LeftShoulder: types.Landmark{X: 0.53, Y: 0.61}, LeftEar: types.Landmark{X: 0.57, Y: 0.33}, VerticalDistanceCM: 14.1, Angle: 13.9},
+		},
+		StatusFrequencies: map[string]int{"Fine": 6},
+	}
+}
+
+// BadPostureFixture is a short session where every frame is hunched.
+func BadPostureFixture() types.ResponseAnalysis {
+	return types.ResponseAnalysis{
+		Result:       []int{0, 0, 0, 0, 0, 0},
+		HunchedRatio: 100,
+		NormalRatio:  0,
+		Scores:       []float64{99.9, 92.9, 92.3, 92.4, 99.5, 92.0},
+		LandmarksInfo: []types.LandmarkInfo{
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.61}, LeftEar: types.Landmark{X: 0.68, Y: 0.38}, VerticalDistanceCM: 11.2, Angle: 34.8},
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.61}, LeftEar: types.Landmark{X: 0.69, Y: 0.39}, VerticalDistanceCM: 10.9, Angle: 36.1},
+			{LeftShoulder: types.Landmark{X: 0.51, Y: 0.60}, LeftEar: types.Landmark{X: 0.70, Y: 0.40}, VerticalDistanceCM: 10.4, Angle: 38.5},
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.61}, LeftEar: types.Landmark{X: 0.71, Y: 0.41}, VerticalDistanceCM: 10.1, Angle: 40.2},
+			{LeftShoulder: types.Landmark{X: 0.52, Y: 0.60}, LeftEar: types.Landmark{X: 0.70, Y: 0.40}, VerticalDistanceCM: 10.5, Angle: 38.9},
+			{LeftShoulder: types.Landmark{X: 0.53, Y: 0.61}, LeftEar: types.Landmark{X: 0.71, Y: 0.41}, VerticalDistanceCM: 10.2, Angle: 39.7},
+		},
+		StatusFrequencies: map[string]int{"Danger": 2, "Serious": 3, "Very Serious": 1},
+	}
+}
@@ -0,0 +1,82 @@
+package analyzers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"gdsc/baro/app/report/types"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout bounds a single request to the AI server.
+// Analysis runs while the request is open, so it has to cover a whole video.
+const DefaultTimeout = 10 * time.Minute
+
+type HTTPAnalyzer struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewHTTPAnalyzer(url string, timeout time.Duration) *HTTPAnalyzer {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &HTTPAnalyzer{
+		URL:    url,
+		Client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (analyzer *HTTPAnalyzer) Analyze(ctx context.Context, videoURL string) (types.ResponseAnalysis, error) {
+	body, err := analyzer.post(ctx, types.RequestAnalysisToAi{VideoURL: videoURL})
+	if err != nil {
+		return types.ResponseAnalysis{}, err
+	}
+
+	var data types.ResponseAnalysis
+	if err := json.Unmarshal(body, &data); err != nil {
+		return types.ResponseAnalysis{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	return data, nil
+}
+
+// Submit sends the job with a callback URL; the AI server only acknowledges it here.
+func (analyzer *HTTPAnalyzer) Submit(ctx context.Context, request types.RequestAnalysisToAi) error {
+	_, err := analyzer.post(ctx, request)
+	return err
+}
+
+func (analyzer *HTTPAnalyzer) post(ctx context.Context, request types.RequestAnalysisToAi) ([]byte, error) {
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, analyzer.URL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := analyzer.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, &StatusError{StatusCode: response.StatusCode}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,137 @@
+package analyzers_test
+
+import (
+	"context"
+	"encoding/json"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPAnalyzer_Analyze(t *testing.T) {
+	// Serve the good posture fixture from a fake AI server
+	server := analyzers.NewFakeServer(analyzers.NewFakeAnalyzer())
+	defer server.Close()
+
+	// Create HTTPAnalyzer
+	analyzer := analyzers.NewHTTPAnalyzer(server.URL, time.Second)
+
+	// Call the method under test
+	response, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, analyzers.GoodPostureFixture(), response)
+}
+
+func TestHTTPAnalyzer_Analyze_InvalidURL(t *testing.T) {
+	// Create HTTPAnalyzer with an invalid URL
+	analyzer := analyzers.NewHTTPAnalyzer("", time.Second)
+
+	// Call the method under test
+	_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.Error(t, err)
+}
+
+func TestHTTPAnalyzer_Analyze_ServerError(t *testing.T) {
+	// Set up a server that always fails
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	// Create HTTPAnalyzer
+	analyzer := analyzers.NewHTTPAnalyzer(server.URL, time.Second)
+
+	// Call the method under test
+	_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.EqualError(t, err, "ai server responded with status 502")
+	assert.True(t, analyzers.IsRetryable(err))
+}
+
+func TestHTTPAnalyzer_Analyze_InvalidBody(t *testing.T) {
+	// Set up a server that answers with something that is not an analysis
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>maintenance</html>"))
+	}))
+	defer server.Close()
+
+	// Create HTTPAnalyzer
+	analyzer := analyzers.NewHTTPAnalyzer(server.URL, time.Second)
+
+	// Call the method under test
+	_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.ErrorIs(t, err, analyzers.ErrInvalidResponse)
+}
+
+func TestHTTPAnalyzer_Analyze_Timeout(t *testing.T) {
+	// Set up a server that is slower than the client timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	// Create HTTPAnalyzer
+	analyzer := analyzers.NewHTTPAnalyzer(server.URL, 50*time.Millisecond)
+
+	// Call the method under test
+	_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.Error(t, err)
+}
+
+func TestHTTPAnalyzer_Analyze_Cancelled(t *testing.T) {
+	// Serve the good posture fixture from a fake AI server
+	server := analyzers.NewFakeServer(analyzers.NewFakeAnalyzer())
+	defer server.Close()
+
+	// Create HTTPAnalyzer
+	analyzer := analyzers.NewHTTPAnalyzer(server.URL, time.Second)
+
+	// Cancel the request before it is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Call the method under test
+	_, err := analyzer.Analyze(ctx, "https://example.com/video.mp4")
+
+	// Check the result
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestHTTPAnalyzer_Submit(t *testing.T) {
+	// Set up a server that accepts the job and records the request
+	var received types.RequestAnalysisToAi
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	// Create HTTPAnalyzer
+	analyzer := analyzers.NewHTTPAnalyzer(server.URL, time.Second)
+	request := types.RequestAnalysisToAi{
+		VideoURL:    "https://example.com/video.mp4",
+		JobID:       7,
+		CallbackURL: "https://api.example.com/internal/analysis/callback",
+	}
+
+	// Call the method under test
+	err := analyzer.Submit(context.Background(), request)
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, request, received)
+}
@@ -0,0 +1,129 @@
+package analyzers
+
+import (
+	"gdsc/baro/app/report/types"
+	"math"
+)
+
+// Neck angle bounds of the posture statuses, in degrees from vertical.
+// A frame is hunched once its neck angle reaches FineMaxNeckAngle.
+const (
+	FineMaxNeckAngle    = 25.0
+	DangerMaxNeckAngle  = 37.0
+	SeriousMaxNeckAngle = 40.0
+
+	// DefaultFrameHeightCM is how many centimeters the height of the camera frame covers at the user's distance.
+	DefaultFrameHeightCM = 50.0
+)
+
+// confidencePoint gives the confidence of a frame whose neck angle is distance degrees from FineMaxNeckAngle.
+type confidencePoint struct {
+	distance   float64
+	confidence float64
+}
+
+// The AI server's confidences are scored with the tiers of the scoring profile, so the confidence of a
+// frame is placed on the tier bounds of the default profile, interpolated linearly between these points.
+// The further an upright frame is from FineMaxNeckAngle the more it weighs: 0.9 within 5 degrees, then
+// 0.94, 0.97 and 1.0 from 15 degrees on. A hunched frame weighs less the more it bends, in step with
+// NeckStatus: 0.35 and then 0.3 in the Danger band, 0.23 when Serious and 0.18 when Very Serious.
+var (
+	uprightConfidence = []confidencePoint{{0, 93}, {5, 96}, {10, 99}, {15, 99.8}, {FineMaxNeckAngle, 100}}
+	hunchedConfidence = []confidencePoint{
+		{0, 90},
+		{6, 95},
+		{DangerMaxNeckAngle - FineMaxNeckAngle, 98},
+		{SeriousMaxNeckAngle - FineMaxNeckAngle, 99.5},
+		{SeriousMaxNeckAngle - FineMaxNeckAngle + 15, 100},
+	}
+)
+
+// KeypointAnalyzer scores landmarks estimated on the device the way the AI server scores a video,
+// so the app can skip the upload. Coordinates are normalized to the frame, with y growing downwards.
+type KeypointAnalyzer struct {
+	FrameHeightCM float64
+}
+
+func NewKeypointAnalyzer() *KeypointAnalyzer {
+	return &KeypointAnalyzer{
+		FrameHeightCM: DefaultFrameHeightCM,
+	}
+}
+
+// Analyze returns the response the AI server would have sent for these frames.
+func (analyzer *KeypointAnalyzer) Analyze(frames []types.KeypointFrame) types.ResponseAnalysis {
+	response := types.ResponseAnalysis{
+		Result:            make([]int, 0, len(frames)),
+		Scores:            make([]float64, 0, len(frames)),
+		LandmarksInfo:     make([]types.LandmarkInfo, 0, len(frames)),
+		StatusFrequencies: map[string]int{},
+	}
+
+	normal := 0
+	for _, frame := range frames {
+		class, confidence, landmarks := analyzer.AnalyzeFrame(frame)
+		normal += class
+
+		response.Result = append(response.Result, class)
+		response.Scores = append(response.Scores, confidence)
+		response.LandmarksInfo = append(response.LandmarksInfo, landmarks)
+		response.StatusFrequencies[NeckStatus(landmarks.Angle)]++
+	}
+
+	if len(frames) > 0 {
+		response.NormalRatio = float64(normal) / float64(len(frames)) * 100
+		response.HunchedRatio = 100 - response.NormalRatio
+	}
+
+	return response
+}
+
+// AnalyzeFrame classifies one frame: 1 when upright, 0 when hunched, with a confidence between 90 and 100
+// from uprightConfidence or hunchedConfidence.
+func (analyzer *KeypointAnalyzer) AnalyzeFrame(frame types.KeypointFrame) (int, float64, types.LandmarkInfo) {
+	angle := NeckAngle(frame.LeftShoulder, frame.LeftEar)
+
+	class, points := 0, hunchedConfidence
+	if angle < FineMaxNeckAngle {
+		class, points = 1, uprightConfidence
+	}
+
+	return class, interpolateConfidence(points, math.Abs(angle-FineMaxNeckAngle)), types.LandmarkInfo{
+		LeftShoulder:       frame.LeftShoulder,
+		LeftEar:            frame.LeftEar,
+		VerticalDistanceCM: (frame.LeftShoulder.Y - frame.LeftEar.Y) * analyzer.FrameHeightCM,
+		Angle:              angle,
+	}
+}
+
+// interpolateConfidence returns the confidence at distance between the points around it, or that of the
+// last point beyond it.
+func interpolateConfidence(points []confidencePoint, distance float64) float64 {
+	for i := 1; i < len(points); i++ {
+		if distance <= points[i].distance {
+			from, to := points[i-1], points[i]
+			return from.confidence + (to.confidence-from.confidence)*(distance-from.distance)/(to.distance-from.distance)
+		}
+	}
+
+	return points[len(points)-1].confidence
+}
+
+// NeckAngle returns the angle in degrees between vertical and the line from the shoulder to the ear.
+func NeckAngle(shoulder types.Landmark, ear types.Landmark) float64 {
+	return math.Atan2(math.Abs(ear.X-shoulder.X), shoulder.Y-ear.Y) * 180 / math.Pi
+}
+
+// NeckStatus returns the status frequency key of a neck angle.
+func NeckStatus(angle float64) string {
+	switch {
+	case angle < FineMaxNeckAngle:
+		return "Fine"
+	case angle < DangerMaxNeckAngle:
+		return "Danger"
+	case angle < SeriousMaxNeckAngle:
+		return "Serious"
+	default:
+		return "Very Serious"
+	}
+}
@@ -0,0 +1,59 @@
+package analyzers_test
+
+import (
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func keypointFrames(fixture types.ResponseAnalysis) []types.KeypointFrame {
+	frames := make([]types.KeypointFrame, 0, len(fixture.LandmarksInfo))
+	for _, landmarks := range fixture.LandmarksInfo {
+		frames = append(frames, types.KeypointFrame{LeftShoulder: landmarks.LeftShoulder, LeftEar: landmarks.LeftEar})
+	}
+	return frames
+}
+
+func TestNeckAngle(t *testing.T) {
+	// Upright, leaning 45 degrees either way
+	assert.InDelta(t, 0, analyzers.NeckAngle(types.Landmark{X: 0.5, Y: 0.6}, types.Landmark{X: 0.5, Y: 0.3}), 1e-9)
+	assert.InDelta(t, 45, analyzers.NeckAngle(types.Landmark{X: 0.5, Y: 0.6}, types.Landmark{X: 0.7, Y: 0.4}), 1e-9)
+	assert.InDelta(t, 45, analyzers.NeckAngle(types.Landmark{X: 0.5, Y: 0.6}, types.Landmark{X: 0.3, Y: 0.4}), 1e-9)
+}
+
+func TestKeypointAnalyzer_Analyze(t *testing.T) {
+	// Create KeypointAnalyzer
+	analyzer := analyzers.NewKeypointAnalyzer()
+
+	// Call the method under test with the landmarks of the fixtures
+	good := analyzer.Analyze(keypointFrames(analyzers.GoodPostureFixture()))
+	bad := analyzer.Analyze(keypointFrames(analyzers.BadPostureFixture()))
+
+	// Check the results: upright frames are normal, hunched ones are not
+	assert.Equal(t, []int{1, 1, 1, 1, 1, 1}, good.Result)
+	assert.Equal(t, 100.0, good.NormalRatio)
+	assert.Equal(t, map[string]int{"Fine": 6}, good.StatusFrequencies)
+	assert.InDelta(t, 14.5, good.LandmarksInfo[0].VerticalDistanceCM, 1e-9)
+
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 0}, bad.Result)
+	assert.Equal(t, 0.0, bad.NormalRatio)
+	assert.Equal(t, 100.0, bad.HunchedRatio)
+	assert.InDelta(t, 34.8, bad.LandmarksInfo[0].Angle, 0.1)
+
+	// Check the results: every confidence is a percentage, higher the further from the threshold
+	for _, score := range append(good.Scores, bad.Scores...) {
+		assert.GreaterOrEqual(t, score, 90.0)
+		assert.LessOrEqual(t, score, 100.0)
+	}
+	assert.GreaterOrEqual(t, good.Scores[0], 99.8)
+	assert.Less(t, bad.Scores[0], bad.Scores[3])
+}
+
+func TestNeckStatus(t *testing.T) {
+	assert.Equal(t, "Fine", analyzers.NeckStatus(10))
+	assert.Equal(t, "Danger", analyzers.NeckStatus(analyzers.FineMaxNeckAngle))
+	assert.Equal(t, "Serious", analyzers.NeckStatus(38))
+	assert.Equal(t, "Very Serious", analyzers.NeckStatus(45))
+}
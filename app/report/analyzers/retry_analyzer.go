@@ -0,0 +1,143 @@
+package analyzers
+
+import (
+	"context"
+	"fmt"
+	"gdsc/baro/app/report/types"
+	"math/rand"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Jitter is the fraction (0 to 1) of each delay that is randomized,
+	// so that retries from several workers do not hit the AI server at the same moment.
+	Jitter float64
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   2 * time.Second,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// Delay returns how long to wait before the given retry (1 for the first retry).
+func (policy RetryPolicy) Delay(retry int) time.Duration {
+	delay := policy.backoff(retry)
+
+	if policy.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * policy.Jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// MaxDuration returns the longest a call retried with the policy can take when every attempt runs
+// for timeout.
+func (policy RetryPolicy) MaxDuration(timeout time.Duration) time.Duration {
+	attempts := policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	total := time.Duration(attempts) * timeout
+	for retry := 1; retry < attempts; retry++ {
+		total += policy.backoff(retry)
+	}
+
+	return total
+}
+
+// backoff returns the delay before the given retry without jitter.
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	delay := policy.BaseDelay << (retry - 1)
+	if delay > policy.MaxDelay || delay <= 0 {
+		delay = policy.MaxDelay
+	}
+
+	return delay
+}
+
+// RetryAnalyzer retries retryable failures of the wrapped analyzer with exponential backoff.
+type RetryAnalyzer struct {
+	Analyzer Analyzer
+	Policy   RetryPolicy
+}
+
+func NewRetryAnalyzer(analyzer Analyzer, policy RetryPolicy) *RetryAnalyzer {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	return &RetryAnalyzer{
+		Analyzer: analyzer,
+		Policy:   policy,
+	}
+}
+
+func (analyzer *RetryAnalyzer) Analyze(ctx context.Context, videoURL string) (types.ResponseAnalysis, error) {
+	var response types.ResponseAnalysis
+
+	err := analyzer.retry(ctx, func() error {
+		var err error
+		response, err = analyzer.Analyzer.Analyze(ctx, videoURL)
+		return err
+	})
+	if err != nil {
+		return types.ResponseAnalysis{}, err
+	}
+
+	return response, nil
+}
+
+func (analyzer *RetryAnalyzer) Submit(ctx context.Context, request types.RequestAnalysisToAi) error {
+	submitter, ok := analyzer.Analyzer.(Submitter)
+	if !ok {
+		return ErrCallbackUnsupported
+	}
+
+	return analyzer.retry(ctx, func() error {
+		return submitter.Submit(ctx, request)
+	})
+}
+
+func (analyzer *RetryAnalyzer) retry(ctx context.Context, call func() error) error {
+	var err error
+
+	for attempt := 1; attempt <= analyzer.Policy.MaxAttempts; attempt++ {
+		err = call()
+		if err == nil {
+			return nil
+		}
+
+		if !IsRetryable(err) {
+			return err
+		}
+
+		if attempt == analyzer.Policy.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(analyzer.Policy.Delay(attempt)):
+		}
+	}
+
+	return fmt.Errorf("analysis failed after %d attempts: %w", analyzer.Policy.MaxAttempts, err)
+}
+
+// Status reports the endpoints of the wrapped analyzer, if it has any.
+func (analyzer *RetryAnalyzer) Status() []types.ResponseAnalyzerEndpoint {
+	if reporter, ok := analyzer.Analyzer.(StatusReporter); ok {
+		return reporter.Status()
+	}
+
+	return []types.ResponseAnalyzerEndpoint{}
+}
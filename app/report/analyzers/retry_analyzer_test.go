@@ -0,0 +1,130 @@
+package analyzers_test
+
+import (
+	"context"
+	"errors"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceAnalyzer returns the given errors in order, then the good posture fixture.
+type sequenceAnalyzer struct {
+	errs  []error
+	calls int
+}
+
+func (a *sequenceAnalyzer) Analyze(ctx context.Context, videoURL string) (types.ResponseAnalysis, error) {
+	a.calls++
+	if a.calls <= len(a.errs) {
+		return types.ResponseAnalysis{}, a.errs[a.calls-1]
+	}
+	return analyzers.GoodPostureFixture(), nil
+}
+
+func testRetryPolicy() analyzers.RetryPolicy {
+	return analyzers.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Jitter:      0.5,
+	}
+}
+
+func TestRetryAnalyzer_RecoversFromTransientErrors(t *testing.T) {
+	// Fail twice with retryable errors, then succeed
+	inner := &sequenceAnalyzer{errs: []error{
+		&analyzers.StatusError{StatusCode: 503},
+		analyzers.ErrInvalidResponse,
+	}}
+	analyzer := analyzers.NewRetryAnalyzer(inner, testRetryPolicy())
+
+	// Call the method under test
+	response, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, analyzers.GoodPostureFixture(), response)
+	assert.Equal(t, 3, inner.calls)
+}
+
+func TestRetryAnalyzer_GivesUp(t *testing.T) {
+	// Always fail with a retryable error
+	inner := &sequenceAnalyzer{errs: []error{
+		&analyzers.StatusError{StatusCode: 500},
+		&analyzers.StatusError{StatusCode: 500},
+		&analyzers.StatusError{StatusCode: 500},
+	}}
+	analyzer := analyzers.NewRetryAnalyzer(inner, testRetryPolicy())
+
+	// Call the method under test
+	_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.EqualError(t, err, "analysis failed after 3 attempts: ai server responded with status 500")
+	assert.Equal(t, 3, inner.calls)
+}
+
+func TestRetryAnalyzer_DoesNotRetryClientErrors(t *testing.T) {
+	// Fail with a permanent error
+	inner := &sequenceAnalyzer{errs: []error{&analyzers.StatusError{StatusCode: 422}}}
+	analyzer := analyzers.NewRetryAnalyzer(inner, testRetryPolicy())
+
+	// Call the method under test
+	_, err := analyzer.Analyze(context.Background(), "https://example.com/video.mp4")
+
+	// Check the result
+	assert.EqualError(t, err, "ai server responded with status 422")
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestRetryAnalyzer_StopsWhenCancelled(t *testing.T) {
+	// Fail with a retryable error and a long backoff
+	inner := &sequenceAnalyzer{errs: []error{&analyzers.StatusError{StatusCode: 502}, errors.New("unreachable")}}
+	policy := testRetryPolicy()
+	policy.BaseDelay = time.Hour
+	policy.MaxDelay = time.Hour
+	analyzer := analyzers.NewRetryAnalyzer(inner, policy)
+
+	// Cancel while the analyzer is waiting to retry
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Call the method under test
+	_, err := analyzer.Analyze(ctx, "https://example.com/video.mp4")
+
+	// Check the result
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	// Without jitter the delay doubles up to the maximum
+	policy := analyzers.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.Delay(1))
+	assert.Equal(t, 2*time.Second, policy.Delay(2))
+	assert.Equal(t, 4*time.Second, policy.Delay(3))
+	assert.Equal(t, 5*time.Second, policy.Delay(4))
+
+	// With jitter the delay stays within the jitter fraction
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		delay := policy.Delay(2)
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, 2*time.Second)
+	}
+}
+
+func TestRetryPolicy_MaxDuration(t *testing.T) {
+	policy := analyzers.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Second, Jitter: 0.5}
+
+	// Check the results: every attempt runs for the timeout and the retries wait 1s and 2s in between
+	assert.Equal(t, 3*time.Minute+3*time.Second, policy.MaxDuration(time.Minute))
+
+	// A policy without retries runs once
+	assert.Equal(t, time.Minute, analyzers.RetryPolicy{}.MaxDuration(time.Minute))
+}
@@ -1,9 +1,13 @@
 package controllers
 
 import (
+	"errors"
+	"gdsc/baro/app/report/analyzers"
 	"gdsc/baro/app/report/services"
 	"gdsc/baro/app/report/types"
 	"gdsc/baro/global"
+	"math"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -21,12 +25,13 @@ func NewReportController(reportService services.ReportServiceInterface) *ReportC
 
 // @Tags Reports
 // @Summary 자세 추정 요청
-// @Description 자세를 추정합니다. (동영상 URL을 입력받아 자세를 추정합니다.)
+// @Description 자세 추정 작업을 등록합니다. (동영상 URL을 입력받아 작업을 등록하고, 작업 상태는 /analysis/jobs/{id}로 조회합니다. 허용되지 않은 scheme, host나 내부 주소를 가리키는 URL은 400을 반환합니다. type은 /analysis/types에 등록된 분석 유형이어야 하며, 이전 버전의 type(예: Study)은 대응하는 분석 유형으로 처리됩니다.)
 // @Accept  json
 // @Produce  json
 // @Param   video_url    body    types.RequestAnalysis   true    "URL, 알림 횟수 등"
 // @Success 200 {object} global.Response
 // @Failure 400 {object} global.Response
+// @Failure 429 {object} global.Response
 // @Security Bearer
 // @Router /analysis [post]
 func (controller *ReportController) Analysis(c *gin.Context) {
@@ -48,6 +53,105 @@ func (controller *ReportController) Analysis(c *gin.Context) {
 	}
 
 	response, err := controller.ReportService.Analysis(c, input)
+	var queueFullErr *services.QueueFullError
+	if errors.As(err, &queueFullErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(queueFullErr.RetryAfter.Seconds()))))
+		c.JSON(429, global.Response{
+			Status:  429,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 키포인트 자세 분석
+// @Description 앱에서 추정한 프레임별 어깨, 귀 좌표로 서버에서 바로 점수를 계산해 보고서를 저장합니다. 동영상을 업로드하지 않으며 AI 서버를 호출하지 않습니다. (좌표는 프레임 크기 기준 0~1, 최대 36000 프레임)
+// @Accept  json
+// @Produce  json
+// @Param   frames    body    types.RequestKeypointAnalysis   true    "프레임별 좌표, 알림 횟수 등"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Security Bearer
+// @Router /analysis/keypoints [post]
+func (controller *ReportController) AnalyzeKeypoints(c *gin.Context) {
+	var input types.RequestKeypointAnalysis
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := controller.ReportService.AnalyzeKeypoints(c, input)
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 자세 추정 작업 상태 조회
+// @Description 자세 추정 작업의 상태(queued, running, succeeded, failed)를 조회합니다. 완료되면 보고서 id가 함께 반환됩니다. 없거나 다른 사용자의 작업은 404를 반환합니다.
+// @Accept  json
+// @Produce  json
+// @Param   id    path    int   true    "자세 추정 작업 id"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 404 {object} global.Response
+// @Security Bearer
+// @Router /analysis/jobs/{id} [get]
+func (controller *ReportController) GetAnalysisJob(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: "Invalid ID",
+		})
+		return
+	}
+
+	response, err := controller.ReportService.FindAnalysisJob(c, uint(id))
+	if errors.Is(err, services.ErrAnalysisJobNotFound) {
+		c.JSON(404, global.Response{
+			Status:  404,
+			Message: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(400, global.Response{
 			Status:  400,
@@ -91,27 +195,201 @@ func (controller *ReportController) GetAnalysis(c *gin.Context) {
 
 // @Tags Reports
 // @Summary 자세 추정 결과 id로 조회
-// @Description 보고서 id로 자세 추정 결과를 조회합니다. (요약으로 먼저 보고서 id 조회하고 사용자가 그걸 누르면 이걸 사용하기)
+// @Description 보고서 id로 자세 추정 결과를 조회합니다. (요약으로 먼저 보고서 id 조회하고 사용자가 그걸 누르면 이걸 사용하기) 로그인한 사용자의 보고서만 조회할 수 있으며, 없거나 다른 사용자의 보고서는 404를 반환합니다.
 // @Accept  json
 // @Produce  json
-// @Param   id    path    int   true    "자세 추정 결과 id"
+// @Param   id    path    string   true    "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)"
 // @Success 200 {object} global.Response
 // @Failure 400 {object} global.Response
+// @Failure 404 {object} global.Response
 // @Security Bearer
 // @Router /analysis/{id} [get]
 func (controller *ReportController) GetAnalysisById(c *gin.Context) {
-	idStr := c.Param("id")
+	id := c.Param("id")
 
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	response, err := controller.ReportService.FindById(c, id)
+	if errors.Is(err, services.ErrReportNotFound) {
+		c.JSON(404, global.Response{
+			Status:  404,
+			Message: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(400, global.Response{
 			Status:  400,
-			Message: "Invalid ID",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 자세 추정 결과 조회 (v2)
+// @Description 로그인한 사용자의 자세 추정 결과를 조회합니다. v1과 달리 predict, neck_angles, distances는 배열, score와 normal_ratio는 숫자, status_frequencies는 상태 이름을 키로 하는 객체로 반환합니다.
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Security Bearer
+// @Router /v2/analysis [get]
+func (controller *ReportController) GetAnalysisV2(c *gin.Context) {
+	response, err := controller.ReportService.FindReportsV2(c)
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
 		})
 		return
 	}
 
-	response, err := controller.ReportService.FindById(c, uint(id))
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 자세 추정 결과 id로 조회 (v2)
+// @Description 보고서 id로 로그인한 사용자의 자세 추정 결과를 조회합니다. 값의 형식은 /v2/analysis와 같습니다. 없거나 다른 사용자의 보고서는 404를 반환합니다.
+// @Accept  json
+// @Produce  json
+// @Param   id    path    string   true    "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 404 {object} global.Response
+// @Security Bearer
+// @Router /v2/analysis/{id} [get]
+func (controller *ReportController) GetAnalysisByIdV2(c *gin.Context) {
+	id := c.Param("id")
+
+	response, err := controller.ReportService.FindReportV2(c, id)
+	if errors.Is(err, services.ErrReportNotFound) {
+		c.JSON(404, global.Response{
+			Status:  404,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 자세 추정 결과 시계열 조회
+// @Description 보고서의 프레임별 목 각도(angle), 거리(distance), 자세 분류(class)를 시간순으로 조회합니다. 차트에 필요한 만큼만 points개 이하로 줄여서 반환합니다. lttb는 모양을 살리는 프레임을 고르고, bucket은 구간별 평균과 최소, 최대를 반환합니다. (method 기본값: class는 bucket, 나머지는 lttb)
+// @Accept  json
+// @Produce  json
+// @Param   id    path    string   true    "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)"
+// @Param   metric  query    string   true    "angle, distance, class 중 하나"
+// @Param   points  query    int   false    "최대 점 개수 (3~2000, 기본값 200)"
+// @Param   method  query    string   false    "lttb 또는 bucket"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 404 {object} global.Response
+// @Security Bearer
+// @Router /analysis/{id}/timeseries [get]
+func (controller *ReportController) GetAnalysisTimeseries(c *gin.Context) {
+	id := c.Param("id")
+
+	var input types.RequestTimeseries
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := controller.ReportService.FindTimeseries(c, id, input)
+	if errors.Is(err, services.ErrReportNotFound) {
+		c.JSON(404, global.Response{
+			Status:  404,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 자세 알림 기록 (v2)
+// @Description 보고서가 측정한 동안 발생한 자세 알림을 기록합니다. 알림 종류, 심각도(low, medium, high), 발생 시각, 자세를 바로잡기까지 걸린 시간(ms)을 받으며, 알림 타임라인과 지표가 포함된 보고서를 반환합니다. (한 번에 최대 1000개)
+// @Accept  json
+// @Produce  json
+// @Param   id    path    string   true    "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)"
+// @Param   events    body    types.RequestAlertEvents   true    "알림 목록"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 404 {object} global.Response
+// @Security Bearer
+// @Router /v2/analysis/{id}/alerts [post]
+func (controller *ReportController) RecordAlertEvents(c *gin.Context) {
+	id := c.Param("id")
+
+	var input types.RequestAlertEvents
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := controller.ReportService.RecordAlertEvents(c, id, input)
+	if errors.Is(err, services.ErrReportNotFound) {
+		c.JSON(404, global.Response{
+			Status:  404,
+			Message: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(400, global.Response{
 			Status:  400,
@@ -183,14 +461,15 @@ func (controller *ReportController) GetAnalysisRankAtAgeAndGender(c *gin.Context
 }
 
 // @Tags Reports
-// @Summary 자세 추정 결과 전체 조회 (테스트용)
-// @Description 자세 추정 결과를 조회합니다.
+// @Summary 자세 추정 결과 전체 조회 (관리자)
+// @Description 모든 사용자의 자세 추정 결과를 조회합니다. 관리자 권한이 없으면 403을 반환합니다.
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} global.Response
 // @Failure 400 {object} global.Response
+// @Failure 403 {object} global.Response
 // @Security Bearer
-// @Router /analysis/all [get]
+// @Router /admin/analysis/all [get]
 func (controller *ReportController) GetAnalyzes(c *gin.Context) {
 	response, err := controller.ReportService.FindAll()
 	if err != nil {
@@ -207,3 +486,210 @@ func (controller *ReportController) GetAnalyzes(c *gin.Context) {
 		Data:    response,
 	})
 }
+
+// @Tags Reports
+// @Summary 실패한 자세 추정 요청 목록 조회 (운영자용)
+// @Description 재시도 후에도 실패하여 dead-letter로 옮겨진 자세 추정 요청 목록을 조회합니다.
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/analysis/dead-letters [get]
+func (controller *ReportController) GetDeadLetters(c *gin.Context) {
+	response, err := controller.ReportService.FindDeadLetters()
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 실패한 자세 추정 요청 재처리 (운영자용)
+// @Description dead-letter에 있는 자세 추정 요청을 새 작업으로 다시 등록합니다.
+// @Accept  json
+// @Produce  json
+// @Param   id    path    int   true    "dead-letter id"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/analysis/dead-letters/{id}/redrive [post]
+func (controller *ReportController) RedriveDeadLetter(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: "Invalid ID",
+		})
+		return
+	}
+
+	response, err := controller.ReportService.RedriveDeadLetter(uint(id))
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary AI 서버 엔드포인트 상태 조회 (운영자용)
+// @Description AI 서버 엔드포인트별 헬스 체크 결과와 서킷 브레이커 상태를 조회합니다.
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/analysis/endpoints [get]
+func (controller *ReportController) GetAnalyzerEndpoints(c *gin.Context) {
+	response := controller.ReportService.FindAnalyzerEndpoints()
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 자세 추정 작업 큐 상태 조회 (운영자용)
+// @Description 대기 중인 작업 수, 실행 중인 작업 수, 이 서버에서 처리 중인 작업 수를 조회합니다.
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/analysis/queue [get]
+func (controller *ReportController) GetAnalysisQueue(c *gin.Context) {
+	response, err := controller.ReportService.FindAnalysisQueue()
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary AI 서버 응답 거부 통계 조회 (운영자용)
+// @Description 검증에 실패해 점수를 매기지 않은 AI 서버 응답 수를 사유별로 조회합니다. 서버가 시작된 이후의 수치입니다.
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/analysis/rejections [get]
+func (controller *ReportController) GetAnalysisRejections(c *gin.Context) {
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    controller.ReportService.FindAnalysisRejections(),
+	})
+}
+
+// @Tags Reports
+// @Summary 분석 유형 목록 조회
+// @Description 분석 요청의 type으로 보낼 수 있는 분석 유형과 각 유형이 허용하는 요청 방식(video, keypoints, session), 최대 분석 시간(초, 0이면 제한 없음), 채점 프로필을 조회합니다.
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} global.Response
+// @Security Bearer
+// @Router /analysis/types [get]
+func (controller *ReportController) GetAnalysisTypes(c *gin.Context) {
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    controller.ReportService.FindAnalysisTypes(),
+	})
+}
+
+// @Tags Reports
+// @Summary AI 서버 분석 결과 콜백 (내부용)
+// @Description AI 서버가 분석 결과를 전달합니다. X-Baro-Timestamp, X-Baro-Signature 헤더의 HMAC 서명을 검증한 뒤 대기 중인 작업을 완료합니다. 요청받은 job_id와 attempt를 그대로 돌려보내야 하며, 재시도로 대체된 이전 시도의 콜백은 409로 거부됩니다. 본문은 8MiB까지 받습니다.
+// @Accept  json
+// @Produce  json
+// @Param   X-Baro-Timestamp    header    string   true    "전송 시각 (unix time)"
+// @Param   X-Baro-Signature    header    string   true    "sha256=<HMAC-SHA256(timestamp.body)>"
+// @Param   callback    body    types.RequestAnalysisCallback   true    "작업 ID, 시도 번호, 분석 결과 또는 오류"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 401 {object} global.Response
+// @Failure 409 {object} global.Response
+// @Failure 413 {object} global.Response
+// @Router /internal/analysis/callback [post]
+func (controller *ReportController) AnalysisCallback(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, analyzers.MaxCallbackSize)
+	body, err := c.GetRawData()
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(413, global.Response{
+			Status:  413,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	err = controller.ReportService.HandleCallback(c, body, c.GetHeader(analyzers.TimestampHeader), c.GetHeader(analyzers.SignatureHeader))
+	if errors.Is(err, analyzers.ErrInvalidSignature) {
+		c.JSON(401, global.Response{
+			Status:  401,
+			Message: err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrJobNotPending) {
+		c.JSON(409, global.Response{
+			Status:  409,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+	})
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"errors"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	"gdsc/baro/global"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ScoringController struct {
+	ScoringService services.ScoringServiceInterface
+}
+
+func NewScoringController(scoringService services.ScoringServiceInterface) *ScoringController {
+	return &ScoringController{
+		ScoringService: scoringService,
+	}
+}
+
+// @Tags Scoring
+// @Summary 점수 산정 프로필 목록 조회 (운영자용)
+// @Description 저장된 점수 산정 프로필과 현재 활성화된 프로필을 조회합니다.
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/scoring-profiles [get]
+func (controller *ScoringController) GetScoringProfiles(c *gin.Context) {
+	response, err := controller.ScoringService.FindScoringProfiles()
+	if err != nil {
+		respondScoringError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Scoring
+// @Summary 점수 산정 프로필 추가 (운영자용)
+// @Description 새 버전의 점수 산정 프로필을 저장합니다. 이미 있는 버전은 덮어쓸 수 없으며, 저장한 프로필은 활성화해야 적용됩니다.
+// @Accept  json
+// @Produce  json
+// @Param   profile    body    types.RequestScoringProfile   true    "버전, 설명, 점수 구간"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 409 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/scoring-profiles [post]
+func (controller *ScoringController) CreateScoringProfile(c *gin.Context) {
+	var input types.RequestScoringProfile
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondScoringError(c, err)
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		respondScoringError(c, err)
+		return
+	}
+
+	response, err := controller.ScoringService.CreateScoringProfile(input)
+	if err != nil {
+		respondScoringError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Scoring
+// @Summary 점수 산정 프로필 활성화 (운영자용)
+// @Description 이후 생성되는 보고서에 적용할 점수 산정 프로필을 활성화합니다. 이미 생성된 보고서의 점수는 바뀌지 않습니다.
+// @Accept  json
+// @Produce  json
+// @Param   version    path    string   true    "프로필 버전"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 404 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Security Bearer
+// @Router /admin/scoring-profiles/{version}/activate [post]
+func (controller *ScoringController) ActivateScoringProfile(c *gin.Context) {
+	response, err := controller.ScoringService.ActivateScoringProfile(c.Param("version"))
+	if err != nil {
+		respondScoringError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+func respondScoringError(c *gin.Context, err error) {
+	status := 400
+
+	switch {
+	case errors.Is(err, services.ErrScoringProfileExists):
+		status = 409
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = 404
+	}
+
+	c.JSON(status, global.Response{
+		Status:  status,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,280 @@
+package controllers
+
+import (
+	"errors"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	"gdsc/baro/global"
+	"gdsc/baro/global/storage"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UploadController struct {
+	UploadService services.UploadServiceInterface
+}
+
+func NewUploadController(uploadService services.UploadServiceInterface) *UploadController {
+	return &UploadController{
+		UploadService: uploadService,
+	}
+}
+
+// @Tags Reports
+// @Summary 동영상 업로드 후 자세 추정 요청
+// @Description 동영상을 직접 업로드하고 자세 추정 작업을 등록합니다. (multipart/form-data, video 파일은 다른 필드보다 뒤에 보내야 합니다.)
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param   alert_count    formData    int   false    "알림 횟수"
+// @Param   analysis_time    formData    int   false    "측정 시간 (초)"
+// @Param   type    formData    string   true    "측정 유형"
+// @Param   video    formData    file   true    "동영상 파일"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 413 {object} global.Response
+// @Failure 415 {object} global.Response
+// @Failure 429 {object} global.Response
+// @Security Bearer
+// @Router /analysis/upload [post]
+func (controller *UploadController) UploadAnalysis(c *gin.Context) {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	// The video is streamed straight to the blob store instead of being buffered,
+	// so the form fields have to arrive before it.
+	fields := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			respondUploadError(c, errors.New("video is required"))
+			return
+		}
+		if err != nil {
+			respondUploadError(c, err)
+			return
+		}
+
+		if part.FormName() != "video" {
+			value, err := io.ReadAll(io.LimitReader(part, 1024))
+			if err != nil {
+				respondUploadError(c, err)
+				return
+			}
+			fields[part.FormName()] = string(value)
+			continue
+		}
+
+		alertCount, _ := strconv.Atoi(fields["alert_count"])
+		analysisTime, _ := strconv.Atoi(fields["analysis_time"])
+		input := types.RequestUploadAnalysis{
+			AlertCount:   alertCount,
+			AnalysisTime: analysisTime,
+			Type:         fields["type"],
+		}
+		if err := input.Validate(); err != nil {
+			respondUploadError(c, err)
+			return
+		}
+
+		response, err := controller.UploadService.UploadAnalysis(c, part, part.Header.Get("Content-Type"), input)
+		if err != nil {
+			respondUploadError(c, err)
+			return
+		}
+
+		c.JSON(200, global.Response{
+			Status:  200,
+			Message: "success",
+			Data:    response,
+		})
+		return
+	}
+}
+
+// @Tags Reports
+// @Summary 이어 올리기 업로드 세션 생성
+// @Description 큰 동영상을 나눠서 올리기 위한 업로드 세션을 만듭니다. 마지막 조각이 올라오면 자세 추정 작업이 등록됩니다.
+// @Accept  json
+// @Produce  json
+// @Param   session    body    types.RequestUploadSession   true    "파일 크기, Content-Type, 알림 횟수 등"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 413 {object} global.Response
+// @Failure 415 {object} global.Response
+// @Security Bearer
+// @Router /analysis/uploads [post]
+func (controller *UploadController) CreateUploadSession(c *gin.Context) {
+	var input types.RequestUploadSession
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	response, err := controller.UploadService.CreateUploadSession(c, input)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 업로드 세션 조회
+// @Description 지금까지 받은 바이트 수를 조회합니다. 끊긴 업로드는 received부터 다시 올리면 됩니다.
+// @Accept  json
+// @Produce  json
+// @Param   id    path    string   true    "업로드 세션 ID"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Security Bearer
+// @Router /analysis/uploads/{id} [get]
+func (controller *UploadController) GetUploadSession(c *gin.Context) {
+	response, err := controller.UploadService.FindUploadSession(c, c.Param("id"))
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 동영상 조각 업로드
+// @Description Upload-Offset 헤더의 위치부터 요청 본문을 이어 붙입니다. 오프셋이 받은 바이트 수와 다르면 409를 반환합니다.
+// @Accept  application/octet-stream
+// @Produce  json
+// @Param   id    path    string   true    "업로드 세션 ID"
+// @Param   Upload-Offset    header    int   true    "이 조각의 시작 위치"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 409 {object} global.Response
+// @Failure 413 {object} global.Response
+// @Failure 429 {object} global.Response
+// @Security Bearer
+// @Router /analysis/uploads/{id} [patch]
+func (controller *UploadController) UploadChunk(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil {
+		respondUploadError(c, errors.New("invalid Upload-Offset header"))
+		return
+	}
+
+	response, err := controller.UploadService.UploadChunk(c, c.Param("id"), offset, c.Request.Body)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 동영상 업로드 URL 발급
+// @Description 짧은 시간 동안만 유효한 동영상 업로드 URL을 발급합니다. upload_url에 method와 headers로 동영상을 올린 뒤, video_url로 자세 추정을 요청합니다.
+// @Accept  json
+// @Produce  json
+// @Param   upload    body    types.RequestUploadURL   true    "Content-Type, 파일 크기"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 413 {object} global.Response
+// @Failure 415 {object} global.Response
+// @Security Bearer
+// @Router /analysis/upload-url [post]
+func (controller *UploadController) IssueUploadURL(c *gin.Context) {
+	var input types.RequestUploadURL
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	response, err := controller.UploadService.IssueUploadURL(c, input)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// @Tags Reports
+// @Summary 서명된 URL로 동영상 업로드
+// @Description /analysis/upload-url에서 발급받은 URL로 동영상을 올립니다. 토큰이 만료되었거나 크기, Content-Type이 다르면 거부합니다. URL은 한 번만 사용할 수 있으며, 이미 업로드된 URL은 409를 반환합니다.
+// @Accept  application/octet-stream
+// @Produce  json
+// @Param   token    path    string   true    "업로드 토큰"
+// @Success 200 {object} global.Response
+// @Failure 403 {object} global.Response
+// @Failure 409 {object} global.Response
+// @Failure 413 {object} global.Response
+// @Failure 415 {object} global.Response
+// @Router /uploads/{token} [put]
+func (controller *UploadController) ReceiveSignedUpload(c *gin.Context) {
+	response, err := controller.UploadService.ReceiveSignedUpload(c, c.Param("token"), c.GetHeader("Content-Type"), c.Request.Body)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+func respondUploadError(c *gin.Context, err error) {
+	status := 400
+
+	var queueFullErr *services.QueueFullError
+	switch {
+	case errors.As(err, &queueFullErr):
+		status = 429
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(queueFullErr.RetryAfter.Seconds()))))
+	case errors.Is(err, services.ErrUploadTooLarge):
+		status = 413
+	case errors.Is(err, services.ErrUnsupportedContentType):
+		status = 415
+	case errors.Is(err, services.ErrUploadOffsetMismatch), errors.Is(err, services.ErrUploadCompleted), errors.Is(err, services.ErrUploadTokenUsed):
+		status = 409
+	case errors.Is(err, storage.ErrInvalidUploadToken), errors.Is(err, storage.ErrUploadTokenExpired):
+		status = 403
+	}
+
+	c.JSON(status, global.Response{
+		Status:  status,
+		Message: err.Error(),
+	})
+}
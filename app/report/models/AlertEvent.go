@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// AlertTypeHunched is the alert posture sessions raise; apps may record other types of their own.
+const AlertTypeHunched = "hunched"
+
+const (
+	AlertSeverityLow    = "low"
+	AlertSeverityMedium = "medium"
+	AlertSeverityHigh   = "high"
+)
+
+// AlertEvent is one posture alert raised during the session a report covers.
+// CorrectedAfterMs is how long the user took to sit up again, nil if they never did.
+type AlertEvent struct {
+	ID               uint      `gorm:"primaryKey"`
+	ReportID         uint      `gorm:"index:idx_alert_events_report_id_occurred_at,priority:1"`
+	Type             string    `gorm:"size:32"`
+	Severity         string    `gorm:"size:16"`
+	OccurredAt       time.Time `gorm:"index:idx_alert_events_report_id_occurred_at,priority:2"`
+	CorrectedAfterMs *int64
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type AnalysisDeadLetter struct {
+	ID           uint `gorm:"primaryKey"`
+	JobID        uint `gorm:"index"`
+	UserID       uint `gorm:"index"`
+	VideoURL     string
+	AlertCount   int
+	AnalysisTime int
+	Type         string
+	LastError    string
+	RedriveJobID *uint
+	RedrivenAt   *time.Time
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
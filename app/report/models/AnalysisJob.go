@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	// JobStatusSubmitted means the AI server accepted the job and will post the result to the callback endpoint.
+	JobStatusSubmitted = "submitted"
+)
+
+type AnalysisJob struct {
+	ID           uint `gorm:"primaryKey"`
+	UserID       uint `gorm:"index"`
+	VideoURL     string
+	AlertCount   int
+	AnalysisTime int
+	Type         string
+	Status       string `gorm:"index"`
+	Attempts     int
+	ReportID     *uint
+	// ReportPublicID is the public ID of the report, set with ReportID.
+	ReportPublicID string `gorm:"size:36"`
+	ErrorMessage   string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
@@ -2,8 +2,12 @@ package models
 
 import "time"
 
+// Report keeps the legacy formatted strings for API compatibility next to numeric columns for queries.
+// The numeric columns are NULL for reports that have not been backfilled yet.
 type Report struct {
-	ID                uint `gorm:"primaryKey"`
+	ID uint `gorm:"primaryKey"`
+	// PublicID identifies the report to clients. Unlike ID it is random, so it reveals nothing about other reports.
+	PublicID          string `gorm:"size:36;uniqueIndex"`
 	UserID            uint
 	AlertCount        int
 	AnalysisTime      int
@@ -14,5 +18,18 @@ type Report struct {
 	NeckAngles        string
 	Distances         string
 	StatusFrequencies string
-	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	ScoreValue        *float64 `gorm:"index"`
+	NormalRatioValue  *float64
+	FineCount         *int
+	DangerCount       *int
+	SeriousCount      *int
+	VerySeriousCount  *int
+	// ScoringProfileVersion is the ScoringProfile Score was calculated with.
+	ScoringProfileVersion string `gorm:"size:32"`
+	// OriginalScore and OriginalScoringProfileVersion keep the score from before the first rescore.
+	OriginalScore                 *float64
+	OriginalScoringProfileVersion string        `gorm:"size:32"`
+	Frames                        []ReportFrame `gorm:"constraint:OnDelete:CASCADE"`
+	Alerts                        []AlertEvent  `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt                     time.Time     `gorm:"autoCreateTime"`
 }
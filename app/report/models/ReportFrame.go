@@ -0,0 +1,17 @@
+package models
+
+// ReportFrame is one analyzed frame of a report.
+// Confidence and landmarks are not known for reports backfilled from the legacy strings.
+type ReportFrame struct {
+	ID            uint `gorm:"primaryKey"`
+	ReportID      uint `gorm:"index:idx_report_frames_report_id_frame_index,priority:1"`
+	FrameIndex    int  `gorm:"index:idx_report_frames_report_id_frame_index,priority:2"`
+	Class         *int
+	Confidence    *float64
+	Angle         *float64
+	Distance      *float64
+	LeftShoulderX *float64
+	LeftShoulderY *float64
+	LeftEarX      *float64
+	LeftEarY      *float64
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// DefaultScoringProfileVersion is the profile reports were scored with before profiles existed.
+const DefaultScoringProfileVersion = "v1"
+
+// ScoringProfile is one immutable version of the scoring rules. Rules holds a types.ScoringRules as JSON.
+// At most one profile is active; new reports are scored with it.
+type ScoringProfile struct {
+	ID          uint   `gorm:"primaryKey"`
+	Version     string `gorm:"size:32;uniqueIndex"`
+	Description string
+	Rules       string `gorm:"type:text"`
+	Active      bool   `gorm:"index"`
+	ActivatedAt *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
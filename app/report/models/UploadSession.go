@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	UploadStatusPending   = "pending"
+	UploadStatusCompleted = "completed"
+)
+
+// UploadSession tracks a resumable video upload.
+// Each received chunk is stored as its own blob (PartKeys, one per line) until Received reaches Size,
+// then the parts are joined into ObjectKey and an analysis job is queued for it.
+type UploadSession struct {
+	ID           string `gorm:"primaryKey;size:36"`
+	UserID       uint   `gorm:"index"`
+	Size         int64
+	Received     int64
+	ContentType  string
+	ObjectKey    string
+	PartKeys     string `gorm:"type:text"`
+	AlertCount   int
+	AnalysisTime int
+	Type         string
+	Status       string
+	JobID        *uint
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// UsedUploadToken records the object key of an upload URL that a video was sent to, so the URL
+// cannot be used again before it expires. Rows past ExpiresAt are no longer needed.
+type UsedUploadToken struct {
+	ObjectKey string    `gorm:"primaryKey;size:255"`
+	ExpiresAt time.Time `gorm:"index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,588 @@
+package pb
+
+import (
+	"context"
+	"errors"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"gdsc/baro/app/user/repositories"
+	"gdsc/baro/global/auth"
+	"gdsc/baro/global/urlpolicy"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	reportpb "gdsc/baro/protos/report"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ReportPbApp struct {
+	ReportService  services.ReportServiceInterface
+	UploadService  services.UploadServiceInterface
+	UserRepository repositories.UserRepositoryInterface
+	reportpb.UnimplementedReportServiceServer
+}
+
+func NewReportPbApp(reportService services.ReportServiceInterface, uploadService services.UploadServiceInterface, userRepository repositories.UserRepositoryInterface) *ReportPbApp {
+	return &ReportPbApp{
+		ReportService:  reportService,
+		UploadService:  uploadService,
+		UserRepository: userRepository,
+	}
+}
+
+func (app *ReportPbApp) GetAnalysisTypes(c context.Context, req *reportpb.RequestAnalysisTypes) (*reportpb.ResponseAnalysisTypes, error) {
+	analysisTypes := app.ReportService.FindAnalysisTypes()
+
+	response := &reportpb.ResponseAnalysisTypes{Types: make([]*reportpb.AnalysisType, 0, len(analysisTypes))}
+	for _, analysisType := range analysisTypes {
+		response.Types = append(response.Types, &reportpb.AnalysisType{
+			Name:            analysisType.Name,
+			Description:     analysisType.Description,
+			Sources:         analysisType.Sources,
+			MaxAnalysisTime: int32(analysisType.MaxAnalysisTime),
+			ScoringProfile:  analysisType.ScoringProfile,
+		})
+	}
+
+	return response, nil
+}
+
+func (app *ReportPbApp) Analysis(c context.Context, req *reportpb.RequestAnalysis) (*reportpb.ResponseAnalysisJob, error) {
+	userID := c.Value(auth.UserIDKey).(string)
+
+	input := types.RequestAnalysis{
+		VideoURL:     req.VideoUrl,
+		AlertCount:   int(req.AlertCount),
+		AnalysisTime: int(req.AnalysisTime),
+		Type:         req.Type,
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := app.ReportService.SubmitAnalysis(&user, input)
+	if err != nil {
+		return nil, toPbSubmitError(c, err)
+	}
+
+	return toPbAnalysisJob(job), nil
+}
+
+// UploadAnalysis receives the video info followed by its chunks and queues an analysis of the stored video.
+// Chunks are piped straight into the blob store, so the video is never held in memory as a whole.
+func (app *ReportPbApp) UploadAnalysis(stream reportpb.ReportService_UploadAnalysisServer) error {
+	c := stream.Context()
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the upload info")
+	}
+
+	input := types.RequestUploadAnalysis{
+		AlertCount:   int(info.AlertCount),
+		AnalysisTime: int(info.AnalysisTime),
+		Type:         info.Type,
+	}
+
+	if err := input.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				writer.Close()
+				return
+			}
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+			if _, err := writer.Write(req.GetChunk()); err != nil {
+				return
+			}
+		}
+	}()
+
+	job, err := app.UploadService.UploadAnalysisForUser(c, &user, reader, info.ContentType, input)
+	reader.CloseWithError(err)
+	switch {
+	case errors.Is(err, services.ErrUploadTooLarge), errors.Is(err, services.ErrUnsupportedContentType):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return toPbSubmitError(c, err)
+	}
+
+	return stream.SendAndClose(toPbAnalysisJob(job))
+}
+
+// AnalyzeKeypoints scores the landmarks streamed after the info message once the client closes the stream.
+func (app *ReportPbApp) AnalyzeKeypoints(stream reportpb.ReportService_AnalyzeKeypointsServer) error {
+	c := stream.Context()
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the analysis info")
+	}
+
+	input := types.RequestKeypointAnalysis{
+		AlertCount:   int(info.AlertCount),
+		AnalysisTime: int(info.AnalysisTime),
+		Type:         info.Type,
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, frame := range req.GetFrames().GetFrames() {
+			input.Frames = append(input.Frames, types.KeypointFrame{
+				LeftShoulder: types.Landmark{X: frame.GetLeftShoulder().GetX(), Y: frame.GetLeftShoulder().GetY()},
+				LeftEar:      types.Landmark{X: frame.GetLeftEar().GetX(), Y: frame.GetLeftEar().GetY()},
+			})
+		}
+		if len(input.Frames) > types.MaxKeypointFrames {
+			return status.Errorf(codes.InvalidArgument, "frames must not exceed %d", types.MaxKeypointFrames)
+		}
+	}
+
+	if err := input.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	report, err := app.ReportService.AnalyzeKeypointsForUser(user, input)
+	if isAnalysisTypeError(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(toPbReport(report))
+}
+
+// PostureSession follows a live session: frames and states come in, alerts, running scores and break
+// suggestions go out. When the app closes its side the report is saved and sent as the last message.
+// If the stream breaks or a message is invalid instead, the report of what came before is still saved.
+func (app *ReportPbApp) PostureSession(stream reportpb.ReportService_PostureSessionServer) error {
+	c := stream.Context()
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	start := first.GetStart()
+	if start == nil || start.Type == "" {
+		return status.Error(codes.InvalidArgument, "the first message must start the session with a type")
+	}
+
+	session, err := app.ReportService.StartPostureSession(start.Type)
+	if isAnalysisTypeError(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			app.saveInterruptedPostureSession(user, session)
+			return err
+		}
+
+		events, err := observePostureSession(session, req)
+		if err != nil {
+			app.saveInterruptedPostureSession(user, session)
+			return err
+		}
+
+		for _, event := range events {
+			if err := stream.Send(&reportpb.ResponsePostureSession{Data: &reportpb.ResponsePostureSession_Event{Event: toPbPostureEvent(event)}}); err != nil {
+				app.saveInterruptedPostureSession(user, session)
+				return err
+			}
+		}
+	}
+
+	report, err := app.ReportService.FinishPostureSession(user, session)
+	if errors.Is(err, services.ErrPostureSessionEmpty) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&reportpb.ResponsePostureSession{Data: &reportpb.ResponsePostureSession_Report{Report: toPbReport(report)}})
+}
+
+// observePostureSession passes a frame or state to the session. An invalid message is an InvalidArgument
+// error and leaves the session as it was.
+func observePostureSession(session *services.PostureSession, req *reportpb.RequestPostureSession) ([]services.PostureEvent, error) {
+	var events []services.PostureEvent
+	var err error
+	switch data := req.Data.(type) {
+	case *reportpb.RequestPostureSession_Frame:
+		frame := types.KeypointFrame{
+			LeftShoulder: types.Landmark{X: data.Frame.GetKeypoints().GetLeftShoulder().GetX(), Y: data.Frame.GetKeypoints().GetLeftShoulder().GetY()},
+			LeftEar:      types.Landmark{X: data.Frame.GetKeypoints().GetLeftEar().GetX(), Y: data.Frame.GetKeypoints().GetLeftEar().GetY()},
+		}
+		if err := frame.Validate(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "frame "+err.Error())
+		}
+		events, err = session.ObserveFrame(time.Duration(data.Frame.ElapsedMs)*time.Millisecond, frame)
+	case *reportpb.RequestPostureSession_State:
+		if data.State.Confidence < 0 || data.State.Confidence > 100 {
+			return nil, status.Error(codes.InvalidArgument, "state confidence must be between 0 and 100")
+		}
+		events, err = session.ObserveState(time.Duration(data.State.ElapsedMs)*time.Millisecond, data.State.Hunched, data.State.Confidence)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "every message after the first must carry a frame or a state")
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return events, nil
+}
+
+// saveInterruptedPostureSession saves the report of a session that ended without the app closing its
+// side. Nothing is saved for a session without observations.
+func (app *ReportPbApp) saveInterruptedPostureSession(user usermodel.User, session *services.PostureSession) {
+	if _, err := app.ReportService.FinishPostureSession(user, session); err != nil && !errors.Is(err, services.ErrPostureSessionEmpty) {
+		log.Printf("failed to save interrupted posture session of user %d: %v", user.ID, err)
+	}
+}
+
+func toPbPostureEvent(event services.PostureEvent) *reportpb.PostureEvent {
+	return &reportpb.PostureEvent{
+		Kind:         event.Kind,
+		ElapsedMs:    event.Elapsed.Milliseconds(),
+		Score:        event.Score,
+		NormalRatio:  event.NormalRatio,
+		HunchedForMs: event.HunchedFor.Milliseconds(),
+		Severity:     event.Severity,
+	}
+}
+
+// toPbSubmitError maps a full analysis queue to RESOURCE_EXHAUSTED with a retry-after header.
+func toPbSubmitError(c context.Context, err error) error {
+	var queueFullErr *services.QueueFullError
+	if errors.As(err, &queueFullErr) {
+		retryAfter := strconv.Itoa(int(math.Ceil(queueFullErr.RetryAfter.Seconds())))
+		grpc.SetHeader(c, metadata.Pairs("retry-after", retryAfter))
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, urlpolicy.ErrNotAllowed) || errors.Is(err, services.ErrVideoURLNotIssued) || errors.Is(err, services.ErrVideoNotUploaded) || isAnalysisTypeError(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return err
+}
+
+// reportID returns the public ID of a report when the client sent one, and its numeric ID otherwise.
+func reportID(id uint64, publicID string) string {
+	if publicID != "" {
+		return publicID
+	}
+
+	return strconv.FormatUint(id, 10)
+}
+
+// toPbReportError maps a report or analysis job that is missing or owned by another user to NOT_FOUND.
+func toPbReportError(err error) error {
+	if errors.Is(err, services.ErrReportNotFound) || errors.Is(err, services.ErrAnalysisJobNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
+
+// isAnalysisTypeError reports whether err rejects the analysis type of a request.
+func isAnalysisTypeError(err error) bool {
+	return errors.Is(err, services.ErrUnknownAnalysisType) || errors.Is(err, services.ErrAnalysisSourceNotAllowed) || errors.Is(err, services.ErrAnalysisTooLong)
+}
+
+func (app *ReportPbApp) GetAnalysisJob(c context.Context, req *reportpb.RequestAnalysisJob) (*reportpb.ResponseAnalysisJob, error) {
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := app.ReportService.FindAnalysisJobByUserID(user.ID, uint(req.Id))
+	if err != nil {
+		return nil, toPbReportError(err)
+	}
+
+	return toPbAnalysisJob(job), nil
+}
+
+func toPbAnalysisJob(job types.ResponseAnalysisJob) *reportpb.ResponseAnalysisJob {
+	response := &reportpb.ResponseAnalysisJob{
+		Id:           uint64(job.ID),
+		Status:       job.Status,
+		ErrorMessage: job.ErrorMessage,
+		CreatedAt:    timestamppb.New(job.CreatedAt),
+		UpdatedAt:    timestamppb.New(job.UpdatedAt),
+	}
+
+	if job.ReportID != nil {
+		response.ReportPublicId = job.ReportPublicID
+	}
+
+	return response
+}
+
+func (app *ReportPbApp) GetReports(c context.Context, req *reportpb.RequestReports) (*reportpb.ResponseReports, error) {
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := app.ReportService.FindReportsV2ByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &reportpb.ResponseReports{Reports: make([]*reportpb.ResponseReport, 0, len(reports))}
+	for _, report := range reports {
+		response.Reports = append(response.Reports, toPbReport(report))
+	}
+
+	return response, nil
+}
+
+// GetAllReports is only reachable with auth.PermissionReadAllReports, checked by auth.UnaryPermissionInterceptor.
+func (app *ReportPbApp) GetAllReports(c context.Context, req *reportpb.RequestAllReports) (*reportpb.ResponseReports, error) {
+	reports, err := app.ReportService.FindAllV2()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &reportpb.ResponseReports{Reports: make([]*reportpb.ResponseReport, 0, len(reports))}
+	for _, report := range reports {
+		response.Reports = append(response.Reports, toPbReport(report))
+	}
+
+	return response, nil
+}
+
+func (app *ReportPbApp) GetReport(c context.Context, req *reportpb.RequestReport) (*reportpb.ResponseReport, error) {
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := app.ReportService.FindReportV2ByUserID(user.ID, reportID(req.Id, req.PublicId))
+	if err != nil {
+		return nil, toPbReportError(err)
+	}
+
+	return toPbReport(report), nil
+}
+
+func (app *ReportPbApp) GetTimeseries(c context.Context, req *reportpb.RequestTimeseries) (*reportpb.ResponseTimeseries, error) {
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := types.RequestTimeseries{
+		Metric: req.Metric,
+		Points: int(req.Points),
+		Method: req.Method,
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	timeseries, err := app.ReportService.FindTimeseriesByUserID(user.ID, reportID(req.ReportId, req.ReportPublicId), input)
+	if err != nil {
+		return nil, toPbReportError(err)
+	}
+
+	response := &reportpb.ResponseTimeseries{
+		ReportPublicId: timeseries.ReportPublicID,
+		Metric:         timeseries.Metric,
+		Method:         timeseries.Method,
+		TotalFrames:    int32(timeseries.TotalFrames),
+		Points:         make([]*reportpb.TimeseriesPoint, 0, len(timeseries.Points)),
+	}
+	for _, point := range timeseries.Points {
+		response.Points = append(response.Points, &reportpb.TimeseriesPoint{
+			T:     point.T,
+			Frame: int32(point.Frame),
+			Value: point.Value,
+			Min:   point.Min,
+			Max:   point.Max,
+		})
+	}
+
+	return response, nil
+}
+
+func (app *ReportPbApp) RecordAlertEvents(c context.Context, req *reportpb.RequestAlertEvents) (*reportpb.ResponseReport, error) {
+	input := types.RequestAlertEvents{Events: make([]types.RequestAlertEvent, 0, len(req.Events))}
+	for i, event := range req.Events {
+		// A missing timestamp would otherwise be read as the Unix epoch
+		if event.GetOccurredAt() == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "event %d has no occurred_at", i)
+		}
+		if err := event.GetOccurredAt().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "event %d: %v", i, err)
+		}
+
+		input.Events = append(input.Events, types.RequestAlertEvent{
+			Type:             event.Type,
+			Severity:         event.Severity,
+			OccurredAt:       event.GetOccurredAt().AsTime(),
+			CorrectedAfterMs: event.CorrectedAfterMs,
+		})
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID := c.Value(auth.UserIDKey).(string)
+
+	user, err := app.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := app.ReportService.RecordAlertEventsForUser(user.ID, reportID(req.ReportId, req.ReportPublicId), input)
+	if err != nil {
+		return nil, toPbReportError(err)
+	}
+
+	return toPbReport(report), nil
+}
+
+func toPbReport(report types.ResponseReportV2) *reportpb.ResponseReport {
+	response := &reportpb.ResponseReport{
+		PublicId:          report.PublicID,
+		UserId:            uint64(report.UserID),
+		AlertCount:        int32(report.AlertCount),
+		AnalysisTime:      int32(report.AnalysisTime),
+		Type:              report.Type,
+		Predict:           make([]int32, 0, len(report.Predict)),
+		Score:             report.Score,
+		NormalRatio:       report.NormalRatio,
+		NeckAngles:        report.NeckAngles,
+		Distances:         report.Distances,
+		StatusFrequencies: make(map[string]int32, len(report.StatusFrequencies)),
+		CreatedAt:         timestamppb.New(report.CreatedAt),
+		ScoringProfile:    report.ScoringProfile,
+	}
+
+	for _, predict := range report.Predict {
+		response.Predict = append(response.Predict, int32(predict))
+	}
+	for name, count := range report.StatusFrequencies {
+		response.StatusFrequencies[name] = int32(count)
+	}
+	response.AlertMetrics = &reportpb.AlertMetrics{
+		Count:               int32(report.AlertMetrics.Count),
+		Corrected:           int32(report.AlertMetrics.Corrected),
+		MeanTimeToCorrectMs: report.AlertMetrics.MeanTimeToCorrectMs,
+		AlertsPerHour:       report.AlertMetrics.AlertsPerHour,
+	}
+	for _, alert := range report.AlertTimeline {
+		response.AlertTimeline = append(response.AlertTimeline, &reportpb.AlertEvent{
+			Id:               uint64(alert.ID),
+			Type:             alert.Type,
+			Severity:         alert.Severity,
+			OccurredAt:       timestamppb.New(alert.OccurredAt),
+			CorrectedAfterMs: alert.CorrectedAfterMs,
+		})
+	}
+	if report.Breakdown != nil {
+		response.Breakdown = &reportpb.ScoreBreakdown{
+			TotalFrames:   int32(report.Breakdown.TotalFrames),
+			Bands:         toPbScoreBands(report.Breakdown.Bands),
+			TopDeductions: toPbScoreBands(report.Breakdown.TopDeductions),
+		}
+	}
+
+	return response
+}
+
+func toPbScoreBands(bands []types.ScoreBand) []*reportpb.ScoreBand {
+	response := make([]*reportpb.ScoreBand, 0, len(bands))
+	for _, band := range bands {
+		response = append(response, &reportpb.ScoreBand{
+			Class:         band.Class,
+			MinConfidence: band.MinConfidence,
+			Weight:        band.Weight,
+			Frames:        int32(band.Frames),
+			Points:        band.Points,
+			Deduction:     band.Deduction,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,141 @@
+package pb_test
+
+import (
+	"context"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/pb"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"gdsc/baro/app/user/repositories"
+	"gdsc/baro/global/auth"
+	"io"
+	"testing"
+	"time"
+
+	reportpb "gdsc/baro/protos/report"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAnalysis_InvalidRequest(t *testing.T) {
+	// Create ReportPbApp; an invalid request is rejected before any dependency is used
+	app := pb.NewReportPbApp(nil, nil, nil)
+	ctx := context.WithValue(context.Background(), auth.UserIDKey, "1")
+
+	// Call the method under test without a video URL
+	_, err := app.Analysis(ctx, &reportpb.RequestAnalysis{AlertCount: 3, AnalysisTime: 1800})
+
+	// Check the results
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRecordAlertEvents_InvalidOccurredAt(t *testing.T) {
+	tests := map[string]*timestamppb.Timestamp{
+		"missing": nil,
+		"invalid": {Seconds: 1700000000, Nanos: -1},
+	}
+
+	// Create ReportPbApp; an invalid request is rejected before any dependency is used
+	app := pb.NewReportPbApp(nil, nil, nil)
+	ctx := context.WithValue(context.Background(), auth.UserIDKey, "1")
+
+	for name, occurredAt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Call the method under test
+			_, err := app.RecordAlertEvents(ctx, &reportpb.RequestAlertEvents{
+				ReportPublicId: "r-10",
+				Events: []*reportpb.AlertEvent{
+					{Type: "neck", Severity: "high", OccurredAt: timestamppb.New(time.Now())},
+					{Type: "neck", Severity: "high", OccurredAt: occurredAt},
+				},
+			})
+
+			// Check the results
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+// fakeReportService runs posture sessions and records the ones that are finished.
+type fakeReportService struct {
+	services.ReportServiceInterface
+	finished []*services.PostureSession
+}
+
+func (f *fakeReportService) StartPostureSession(sessionType string) (*services.PostureSession, error) {
+	return services.NewPostureSession(sessionType, services.DefaultScoringRules(), analyzers.NewKeypointAnalyzer()), nil
+}
+
+func (f *fakeReportService) FinishPostureSession(user usermodel.User, session *services.PostureSession) (types.ResponseReportV2, error) {
+	if _, err := session.Analysis(); err != nil {
+		return types.ResponseReportV2{}, err
+	}
+	f.finished = append(f.finished, session)
+	return types.ResponseReportV2{PublicID: "r-10"}, nil
+}
+
+type fakeUserRepository struct {
+	repositories.UserRepositoryInterface
+}
+
+func (f *fakeUserRepository) FindByID(id string) (usermodel.User, error) {
+	return usermodel.User{ID: 1}, nil
+}
+
+// fakePostureStream replays requests and records what the server sends.
+type fakePostureStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*reportpb.RequestPostureSession
+	sent     []*reportpb.ResponsePostureSession
+}
+
+func (f *fakePostureStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakePostureStream) Recv() (*reportpb.RequestPostureSession, error) {
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *fakePostureStream) Send(res *reportpb.ResponsePostureSession) error {
+	f.sent = append(f.sent, res)
+	return nil
+}
+
+func TestPostureSession_InvalidMessageSavesSession(t *testing.T) {
+	// Create ReportPbApp
+	reportService := &fakeReportService{}
+	app := pb.NewReportPbApp(reportService, nil, &fakeUserRepository{})
+
+	// Set up a session whose third message has an out of range confidence
+	stream := &fakePostureStream{
+		ctx: context.WithValue(context.Background(), auth.UserIDKey, "1"),
+		requests: []*reportpb.RequestPostureSession{
+			{Data: &reportpb.RequestPostureSession_Start{Start: &reportpb.PostureSessionStart{Type: services.AnalysisTypeSideSitting}}},
+			{Data: &reportpb.RequestPostureSession_State{State: &reportpb.PostureState{ElapsedMs: 0, Hunched: false, Confidence: 99}}},
+			{Data: &reportpb.RequestPostureSession_State{State: &reportpb.PostureState{ElapsedMs: 1000, Hunched: true, Confidence: 150}}},
+		},
+	}
+
+	// Call the method under test
+	err := app.PostureSession(stream)
+
+	// Check the results: the message is rejected, but the state observed before it is saved
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Len(t, reportService.finished, 1)
+
+	response, err := reportService.finished[0].Analysis()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, response.Result)
+}
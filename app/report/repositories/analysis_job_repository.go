@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"errors"
+	"gdsc/baro/app/report/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnalysisJobRepositoryInterface interface {
+	Create(job *models.AnalysisJob) (models.AnalysisJob, error)
+	FindById(id uint) (models.AnalysisJob, error)
+	FindByIdAndUserID(id uint, userID uint) (models.AnalysisJob, error)
+	ClaimNext() (*models.AnalysisJob, error)
+	Update(job *models.AnalysisJob) (models.AnalysisJob, error)
+	RequeueStale(startedBefore time.Time, maxAttempts int) (int64, error)
+	FindStartedBefore(status string, startedBefore time.Time) ([]models.AnalysisJob, error)
+	CountByStatus(status string) (int64, error)
+	Transition(id uint, from string, to string) (bool, error)
+	TransitionAttempt(id uint, attempt int, from string, to string) (bool, error)
+	CreateDeadLetter(deadLetter *models.AnalysisDeadLetter) (models.AnalysisDeadLetter, error)
+	FindDeadLetters() ([]models.AnalysisDeadLetter, error)
+	FindDeadLetterById(id uint) (models.AnalysisDeadLetter, error)
+	RedriveDeadLetter(deadLetter *models.AnalysisDeadLetter, job *models.AnalysisJob) (bool, error)
+}
+
+type AnalysisJobRepository struct {
+	DB *gorm.DB
+}
+
+func NewAnalysisJobRepository(db *gorm.DB) *AnalysisJobRepository {
+	return &AnalysisJobRepository{
+		DB: db,
+	}
+}
+
+func (repo *AnalysisJobRepository) Create(job *models.AnalysisJob) (models.AnalysisJob, error) {
+	if err := repo.DB.Create(job).Error; err != nil {
+		return models.AnalysisJob{}, err
+	}
+	return *job, nil
+}
+
+func (repo *AnalysisJobRepository) FindById(id uint) (models.AnalysisJob, error) {
+	var job models.AnalysisJob
+	result := repo.DB.Where("id = ?", id).First(&job)
+	return job, result.Error
+}
+
+func (repo *AnalysisJobRepository) FindByIdAndUserID(id uint, userID uint) (models.AnalysisJob, error) {
+	var job models.AnalysisJob
+	result := repo.DB.Where("id = ? AND user_id = ?", id, userID).First(&job)
+	return job, result.Error
+}
+
+// ClaimNext marks the oldest queued job as running and returns it.
+// It returns nil without an error when there is nothing to run.
+// SKIP LOCKED lets several server instances poll the same table.
+func (repo *AnalysisJobRepository) ClaimNext() (*models.AnalysisJob, error) {
+	var job models.AnalysisJob
+
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.JobStatusQueued).
+			Order("id").
+			Limit(1).
+			Find(&job)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		now := time.Now()
+		job.Status = models.JobStatusRunning
+		job.Attempts++
+		job.StartedAt = &now
+
+		return tx.Save(&job).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (repo *AnalysisJobRepository) Update(job *models.AnalysisJob) (models.AnalysisJob, error) {
+	if err := repo.DB.Save(job).Error; err != nil {
+		return models.AnalysisJob{}, err
+	}
+	return *job, nil
+}
+
+// RequeueStale puts running jobs that were started before the given time back in the queue, as long
+// as they have fewer than maxAttempts attempts. These are jobs whose worker went away (e.g. the process
+// restarted) before finishing them; those out of attempts stay running for the service to fail.
+func (repo *AnalysisJobRepository) RequeueStale(startedBefore time.Time, maxAttempts int) (int64, error) {
+	result := repo.DB.Model(&models.AnalysisJob{}).
+		Where("status = ? AND started_at < ? AND attempts < ?", models.JobStatusRunning, startedBefore, maxAttempts).
+		Update("status", models.JobStatusQueued)
+	return result.RowsAffected, result.Error
+}
+
+// FindStartedBefore returns the jobs in status that were started before the given time. A job is
+// submitted to the AI server right after it is claimed, so for submitted jobs it is when they were submitted.
+func (repo *AnalysisJobRepository) FindStartedBefore(status string, startedBefore time.Time) ([]models.AnalysisJob, error) {
+	var jobs []models.AnalysisJob
+	result := repo.DB.Where("status = ? AND started_at < ?", status, startedBefore).
+		Order("id").
+		Find(&jobs)
+	return jobs, result.Error
+}
+
+func (repo *AnalysisJobRepository) CountByStatus(status string) (int64, error) {
+	var count int64
+	err := repo.DB.Model(&models.AnalysisJob{}).Where("status = ?", status).Count(&count).Error
+	return count, err
+}
+
+// Transition moves a job from one status to another only if it is still in the from status.
+// It reports whether this caller made the change, so concurrent callers cannot both handle the job.
+func (repo *AnalysisJobRepository) Transition(id uint, from string, to string) (bool, error) {
+	result := repo.DB.Model(&models.AnalysisJob{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
+// TransitionAttempt is Transition for one attempt of a job: once the job was requeued and claimed
+// again, the worker of the earlier attempt can no longer change it.
+func (repo *AnalysisJobRepository) TransitionAttempt(id uint, attempt int, from string, to string) (bool, error) {
+	result := repo.DB.Model(&models.AnalysisJob{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, from, attempt).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (repo *AnalysisJobRepository) CreateDeadLetter(deadLetter *models.AnalysisDeadLetter) (models.AnalysisDeadLetter, error) {
+	if err := repo.DB.Create(deadLetter).Error; err != nil {
+		return models.AnalysisDeadLetter{}, err
+	}
+	return *deadLetter, nil
+}
+
+func (repo *AnalysisJobRepository) FindDeadLetters() ([]models.AnalysisDeadLetter, error) {
+	var deadLetters []models.AnalysisDeadLetter
+	result := repo.DB.Order("id desc").Find(&deadLetters)
+	return deadLetters, result.Error
+}
+
+func (repo *AnalysisJobRepository) FindDeadLetterById(id uint) (models.AnalysisDeadLetter, error) {
+	var deadLetter models.AnalysisDeadLetter
+	result := repo.DB.Where("id = ?", id).First(&deadLetter)
+	return deadLetter, result.Error
+}
+
+// RedriveDeadLetter creates job for the dead letter and records it on the dead letter in one transaction.
+// The dead letter is claimed by setting redriven_at only while it is unset, so when another request
+// redrove it first this reports false and no job is created.
+func (repo *AnalysisJobRepository) RedriveDeadLetter(deadLetter *models.AnalysisDeadLetter, job *models.AnalysisJob) (bool, error) {
+	now := time.Now()
+	redriven := false
+
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AnalysisDeadLetter{}).
+			Where("id = ? AND redriven_at IS NULL", deadLetter.ID).
+			Update("redriven_at", now)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.AnalysisDeadLetter{}).Where("id = ?", deadLetter.ID).Update("redrive_job_id", job.ID).Error; err != nil {
+			return err
+		}
+
+		redriven = true
+		return nil
+	})
+	if err != nil || !redriven {
+		return false, err
+	}
+
+	deadLetter.RedrivenAt = &now
+	deadLetter.RedriveJobID = &job.ID
+	return true, nil
+}
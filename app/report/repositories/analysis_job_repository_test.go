@@ -0,0 +1,437 @@
+package repositories_test
+
+import (
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/repositories"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newAnalysisJobRepository(t *testing.T) (*repositories.AnalysisJobRepository, sqlmock.Sqlmock, func()) {
+	// Create mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB: %v", err)
+	}
+
+	// Set up expectations for the mock DB (ex: SELECT VERSION())
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION"}).
+			AddRow("8.0.0"))
+
+	// Create gorm.DB
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error creating gorm.DB: %v", err)
+	}
+
+	return repositories.NewAnalysisJobRepository(gormDB), mock, func() { db.Close() }
+}
+
+func TestAnalysisJobRepository_Create(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Create sample job for the test
+	job := models.AnalysisJob{
+		UserID:       1,
+		VideoURL:     "https://example.com/video.mp4",
+		AlertCount:   3,
+		AnalysisTime: 1800,
+		Type:         "Study",
+		Status:       models.JobStatusQueued,
+	}
+
+	// Set up expectations for the mock DB to insert the sample job
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `analysis_jobs`").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	createdJob, err := analysisJobRepository.Create(&job)
+	if err != nil {
+		t.Fatalf("Error creating job: %v", err)
+	}
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, uint(1), createdJob.ID)
+	assert.Equal(t, job.UserID, createdJob.UserID)
+	assert.Equal(t, models.JobStatusQueued, createdJob.Status)
+}
+
+func TestAnalysisJobRepository_FindByIdAndUserID(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to return the job
+	mock.ExpectQuery("SELECT \\* FROM `analysis_jobs` WHERE id = \\? AND user_id = \\? ORDER BY `analysis_jobs`.`id` LIMIT 1").
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).
+			AddRow(2, 1, models.JobStatusRunning))
+
+	// Call the method under test
+	job, err := analysisJobRepository.FindByIdAndUserID(2, 1)
+	if err != nil {
+		t.Fatalf("Error finding job: %v", err)
+	}
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, uint(2), job.ID)
+	assert.Equal(t, uint(1), job.UserID)
+	assert.Equal(t, models.JobStatusRunning, job.Status)
+}
+
+func TestAnalysisJobRepository_FindByIdAndUserID_Error(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to return no rows (another user's job)
+	mock.ExpectQuery("SELECT \\* FROM `analysis_jobs` WHERE id = \\? AND user_id = \\?").
+		WithArgs(2, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}))
+
+	// Call the method under test
+	_, err := analysisJobRepository.FindByIdAndUserID(2, 9)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestAnalysisJobRepository_ClaimNext(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to lock and claim the oldest queued job
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `analysis_jobs` WHERE status = \\? ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED").
+		WithArgs(models.JobStatusQueued).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "attempts"}).
+			AddRow(5, 1, models.JobStatusQueued, 0))
+	mock.ExpectExec("UPDATE `analysis_jobs`").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	job, err := analysisJobRepository.ClaimNext()
+	if err != nil {
+		t.Fatalf("Error claiming job: %v", err)
+	}
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.NotNil(t, job)
+	assert.Equal(t, uint(5), job.ID)
+	assert.Equal(t, models.JobStatusRunning, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.NotNil(t, job.StartedAt)
+}
+
+func TestAnalysisJobRepository_ClaimNext_Empty(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to find nothing to claim
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `analysis_jobs` WHERE status = \\?").
+		WithArgs(models.JobStatusQueued).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "attempts"}))
+	mock.ExpectRollback()
+
+	// Call the method under test
+	job, err := analysisJobRepository.ClaimNext()
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestAnalysisJobRepository_RequeueStale(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	startedBefore := time.Now().Add(-30 * time.Minute)
+
+	// Set up expectations for the mock DB to requeue two stale jobs with attempts left
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `analysis_jobs` SET `status`=\\?,`updated_at`=\\? WHERE status = \\? AND started_at < \\? AND attempts < \\?").
+		WithArgs(models.JobStatusQueued, sqlmock.AnyArg(), models.JobStatusRunning, startedBefore, 3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	requeued, err := analysisJobRepository.RequeueStale(startedBefore, 3)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), requeued)
+}
+
+func TestAnalysisJobRepository_FindStartedBefore(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	submittedBefore := time.Now().Add(-10 * time.Minute)
+
+	// Set up expectations for the mock DB to return one overdue job
+	mock.ExpectQuery("SELECT \\* FROM `analysis_jobs` WHERE status = \\? AND started_at < \\? ORDER BY id").
+		WithArgs(models.JobStatusSubmitted, submittedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "attempts"}).AddRow(4, 1, models.JobStatusSubmitted, 1))
+
+	// Call the method under test
+	jobs, err := analysisJobRepository.FindStartedBefore(models.JobStatusSubmitted, submittedBefore)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, uint(4), jobs[0].ID)
+}
+
+func TestAnalysisJobRepository_CountByStatus(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to count queued jobs
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `analysis_jobs` WHERE status = \\?").
+		WithArgs(models.JobStatusQueued).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	// Call the method under test
+	count, err := analysisJobRepository.CountByStatus(models.JobStatusQueued)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+}
+
+func TestAnalysisJobRepository_Transition(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB: the first update wins, the second finds nothing to change
+	for _, rowsAffected := range []int64{1, 0} {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `analysis_jobs` SET `status`=\\?,`updated_at`=\\? WHERE id = \\? AND status = \\?").
+			WithArgs(models.JobStatusRunning, sqlmock.AnyArg(), 1, models.JobStatusSubmitted).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		mock.ExpectCommit()
+	}
+
+	// Call the method under test
+	first, err := analysisJobRepository.Transition(1, models.JobStatusSubmitted, models.JobStatusRunning)
+	assert.NoError(t, err)
+	second, err := analysisJobRepository.Transition(1, models.JobStatusSubmitted, models.JobStatusRunning)
+	assert.NoError(t, err)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.True(t, first)
+	assert.False(t, second)
+}
+
+func TestAnalysisJobRepository_TransitionAttempt(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB: the current attempt wins, an earlier attempt finds nothing to change
+	for _, rowsAffected := range []int64{1, 0} {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `analysis_jobs` SET `status`=\\?,`updated_at`=\\? WHERE id = \\? AND status = \\? AND attempts = \\?").
+			WithArgs(models.JobStatusSucceeded, sqlmock.AnyArg(), 1, models.JobStatusRunning, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		mock.ExpectCommit()
+	}
+
+	// Call the method under test
+	current, err := analysisJobRepository.TransitionAttempt(1, 2, models.JobStatusRunning, models.JobStatusSucceeded)
+	assert.NoError(t, err)
+	earlier, err := analysisJobRepository.TransitionAttempt(1, 1, models.JobStatusRunning, models.JobStatusSucceeded)
+	assert.NoError(t, err)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.True(t, current)
+	assert.False(t, earlier)
+}
+
+func TestAnalysisJobRepository_CreateDeadLetter(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Create sample dead letter for the test
+	deadLetter := models.AnalysisDeadLetter{
+		JobID:     3,
+		UserID:    1,
+		VideoURL:  "https://example.com/video.mp4",
+		LastError: "ai server responded with status 500",
+	}
+
+	// Set up expectations for the mock DB to insert the dead letter
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `analysis_dead_letters`").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	createdDeadLetter, err := analysisJobRepository.CreateDeadLetter(&deadLetter)
+	if err != nil {
+		t.Fatalf("Error creating dead letter: %v", err)
+	}
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, uint(1), createdDeadLetter.ID)
+	assert.Equal(t, deadLetter.LastError, createdDeadLetter.LastError)
+}
+
+func TestAnalysisJobRepository_FindDeadLetters(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to return dead letters, newest first
+	mock.ExpectQuery("SELECT \\* FROM `analysis_dead_letters` ORDER BY id desc").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "user_id", "last_error"}).
+			AddRow(2, 5, 1, "ai server responded with status 500").
+			AddRow(1, 3, 1, "ai server responded with status 422"))
+
+	// Call the method under test
+	deadLetters, err := analysisJobRepository.FindDeadLetters()
+	if err != nil {
+		t.Fatalf("Error finding dead letters: %v", err)
+	}
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Len(t, deadLetters, 2)
+	assert.Equal(t, uint(2), deadLetters[0].ID)
+	assert.Equal(t, uint(5), deadLetters[0].JobID)
+}
+
+func TestAnalysisJobRepository_FindDeadLetterById_Error(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to find nothing
+	mock.ExpectQuery("SELECT \\* FROM `analysis_dead_letters` WHERE id = \\?").
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Call the method under test
+	_, err := analysisJobRepository.FindDeadLetterById(9)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestAnalysisJobRepository_RedriveDeadLetter(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	deadLetter := models.AnalysisDeadLetter{ID: 2, JobID: 3, UserID: 1}
+	job := models.AnalysisJob{UserID: 1, Status: models.JobStatusQueued}
+
+	// Set up expectations for the mock DB to claim the dead letter, queue the job and link it
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `analysis_dead_letters` SET `redriven_at`=\\? WHERE id = \\? AND redriven_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `analysis_jobs`").
+		WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectExec("UPDATE `analysis_dead_letters` SET `redrive_job_id`=\\? WHERE id = \\?").
+		WithArgs(8, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	redriven, err := analysisJobRepository.RedriveDeadLetter(&deadLetter, &job)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.True(t, redriven)
+	assert.Equal(t, uint(8), job.ID)
+	assert.Equal(t, uint(8), *deadLetter.RedriveJobID)
+	assert.NotNil(t, deadLetter.RedrivenAt)
+}
+
+func TestAnalysisJobRepository_RedriveDeadLetter_AlreadyRedriven(t *testing.T) {
+	// Create AnalysisJobRepository
+	analysisJobRepository, mock, closeDB := newAnalysisJobRepository(t)
+	defer closeDB()
+
+	deadLetter := models.AnalysisDeadLetter{ID: 2, JobID: 3, UserID: 1}
+	job := models.AnalysisJob{UserID: 1, Status: models.JobStatusQueued}
+
+	// Set up expectations for the mock DB: another request set redriven_at first, so no job is inserted
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `analysis_dead_letters` SET `redriven_at`=\\? WHERE id = \\? AND redriven_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	redriven, err := analysisJobRepository.RedriveDeadLetter(&deadLetter, &job)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.False(t, redriven)
+	assert.Nil(t, deadLetter.RedrivenAt)
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"fmt"
+	"gdsc/baro/app/report/models"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const DefaultBackfillBatchSize = 500
+
+// BackfillLegacyReports fills the numeric columns and report_frames of reports saved before they existed
+// by parsing their formatted strings. Reports are read batchSize at a time and each one is written in its
+// own transaction, so the backfill can be interrupted and run again. Reports whose strings cannot be parsed
+// are logged, left as they are and counted in failed.
+func (repo *ReportRepository) BackfillLegacyReports(batchSize int) (backfilled int, failed int, err error) {
+	if batchSize < 1 {
+		batchSize = DefaultBackfillBatchSize
+	}
+
+	var lastID uint
+	for {
+		var reports []models.Report
+		err := repo.DB.Where("id > ? AND score_value IS NULL", lastID).Order("id").Limit(batchSize).Find(&reports).Error
+		if err != nil {
+			return backfilled, failed, err
+		}
+		if len(reports) == 0 {
+			return backfilled, failed, nil
+		}
+
+		for _, report := range reports {
+			lastID = report.ID
+
+			parsed, err := ParseLegacyReport(report)
+			if err != nil {
+				log.Printf("Skipping backfill of report %d: %v", report.ID, err)
+				failed++
+				continue
+			}
+
+			if err := repo.saveBackfill(parsed); err != nil {
+				return backfilled, failed, err
+			}
+			backfilled++
+		}
+	}
+}
+
+// AssignPublicIDs gives a public ID to the reports saved before public IDs existed, batchSize at a time,
+// and returns how many it updated.
+func (repo *ReportRepository) AssignPublicIDs(batchSize int) (int, error) {
+	if batchSize < 1 {
+		batchSize = DefaultBackfillBatchSize
+	}
+
+	assigned := 0
+	for {
+		var reports []models.Report
+		err := repo.DB.Select("id").Where("public_id IS NULL OR public_id = ?", "").Order("id").Limit(batchSize).Find(&reports).Error
+		if err != nil {
+			return assigned, err
+		}
+		if len(reports) == 0 {
+			return assigned, nil
+		}
+
+		for _, report := range reports {
+			if err := repo.DB.Model(&models.Report{}).Where("id = ?", report.ID).Update("public_id", uuid.NewString()).Error; err != nil {
+				return assigned, err
+			}
+			assigned++
+		}
+	}
+}
+
+func (repo *ReportRepository) saveBackfill(report models.Report) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("report_id = ?", report.ID).Delete(&models.ReportFrame{}).Error; err != nil {
+			return err
+		}
+		if len(report.Frames) > 0 {
+			if err := tx.Create(&report.Frames).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Report{}).Where("id = ?", report.ID).Updates(map[string]interface{}{
+			"score_value":        report.ScoreValue,
+			"normal_ratio_value": report.NormalRatioValue,
+			"fine_count":         report.FineCount,
+			"danger_count":       report.DangerCount,
+			"serious_count":      report.SeriousCount,
+			"very_serious_count": report.VerySeriousCount,
+		}).Error
+	})
+}
+
+// ParseLegacyReport returns report with its numeric columns and frames filled from the legacy strings:
+// Score "87.50", NormalRatio "0.500", Predict "[1 0 1]", StatusFrequencies "[1 2 3 4]"
+// and NeckAngles / Distances "[12.000 13.000]".
+func ParseLegacyReport(report models.Report) (models.Report, error) {
+	score, err := parseFiniteFloat(report.Score)
+	if err != nil {
+		return report, fmt.Errorf("score: %w", err)
+	}
+	normalRatio, err := parseFiniteFloat(report.NormalRatio)
+	if err != nil {
+		return report, fmt.Errorf("normal ratio: %w", err)
+	}
+	predict, err := parseLegacyList(report.Predict, strconv.Atoi)
+	if err != nil {
+		return report, fmt.Errorf("predict: %w", err)
+	}
+	frequencies, err := parseLegacyList(report.StatusFrequencies, strconv.Atoi)
+	if err != nil || len(frequencies) != 4 {
+		return report, fmt.Errorf("status frequencies: %q", report.StatusFrequencies)
+	}
+	angles, err := parseLegacyList(report.NeckAngles, parseFiniteFloat)
+	if err != nil {
+		return report, fmt.Errorf("neck angles: %w", err)
+	}
+	distances, err := parseLegacyList(report.Distances, parseFiniteFloat)
+	if err != nil {
+		return report, fmt.Errorf("distances: %w", err)
+	}
+
+	report.ScoreValue = &score
+	report.NormalRatioValue = &normalRatio
+	report.FineCount = &frequencies[0]
+	report.DangerCount = &frequencies[1]
+	report.SeriousCount = &frequencies[2]
+	report.VerySeriousCount = &frequencies[3]
+
+	report.Frames = make([]models.ReportFrame, max(len(predict), len(angles), len(distances)))
+	for i := range report.Frames {
+		frame := &report.Frames[i]
+		frame.ReportID = report.ID
+		frame.FrameIndex = i
+		if i < len(predict) {
+			frame.Class = &predict[i]
+		}
+		if i < len(angles) {
+			frame.Angle = &angles[i]
+		}
+		if i < len(distances) {
+			frame.Distance = &distances[i]
+		}
+	}
+
+	return report, nil
+}
+
+// parseLegacyList parses a slice formatted with %v or %.3f, e.g. "[1 0 1]".
+func parseLegacyList[T any](value string, parse func(string) (T, error)) ([]T, error) {
+	inner, ok := strings.CutPrefix(strings.TrimSpace(value), "[")
+	if !ok {
+		return nil, fmt.Errorf("not a list: %q", value)
+	}
+	inner, ok = strings.CutSuffix(inner, "]")
+	if !ok {
+		return nil, fmt.Errorf("not a list: %q", value)
+	}
+
+	fields := strings.Fields(inner)
+	values := make([]T, 0, len(fields))
+	for _, field := range fields {
+		v, err := parse(field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+func parseFiniteFloat(value string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("not a finite number: %q", value)
+	}
+
+	return v, nil
+}
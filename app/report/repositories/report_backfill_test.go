@@ -0,0 +1,156 @@
+package repositories_test
+
+import (
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/repositories"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newReportRepository(t *testing.T) (*repositories.ReportRepository, sqlmock.Sqlmock, func()) {
+	// Create mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB: %v", err)
+	}
+
+	// Set up expectations for the mock DB (ex: SELECT VERSION())
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION"}).
+			AddRow("8.0.0"))
+
+	// Create gorm.DB
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error creating gorm.DB: %v", err)
+	}
+
+	return repositories.NewReportRepository(gormDB), mock, func() { db.Close() }
+}
+
+func TestParseLegacyReport(t *testing.T) {
+	// Create a report as it was saved before the numeric columns existed
+	report := models.Report{
+		ID:                7,
+		Predict:           "[1 0 1]",
+		Score:             "87.50",
+		NormalRatio:       "0.667",
+		NeckAngles:        "[12.000 30.500]",
+		Distances:         "[1.000 2.500]",
+		StatusFrequencies: "[1 2 0 0]",
+	}
+
+	// Call the method under test
+	parsed, err := repositories.ParseLegacyReport(report)
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, 87.5, *parsed.ScoreValue)
+	assert.Equal(t, 0.667, *parsed.NormalRatioValue)
+	assert.Equal(t, 1, *parsed.FineCount)
+	assert.Equal(t, 2, *parsed.DangerCount)
+	assert.Equal(t, 0, *parsed.VerySeriousCount)
+
+	assert.Len(t, parsed.Frames, 3)
+	assert.Equal(t, uint(7), parsed.Frames[1].ReportID)
+	assert.Equal(t, 1, parsed.Frames[1].FrameIndex)
+	assert.Equal(t, 0, *parsed.Frames[1].Class)
+	assert.Equal(t, 30.5, *parsed.Frames[1].Angle)
+	assert.Equal(t, 2.5, *parsed.Frames[1].Distance)
+	assert.Nil(t, parsed.Frames[1].Confidence)
+	assert.Nil(t, parsed.Frames[2].Angle)
+}
+
+func TestParseLegacyReport_Invalid(t *testing.T) {
+	valid := models.Report{Predict: "[]", Score: "50.00", NormalRatio: "0.500", NeckAngles: "[]", Distances: "[]", StatusFrequencies: "[0 0 0 0]"}
+
+	// Break one field at a time
+	for name, broken := range map[string]func(*models.Report){
+		"score":       func(r *models.Report) { r.Score = "NaN" },
+		"ratio":       func(r *models.Report) { r.NormalRatio = "" },
+		"predict":     func(r *models.Report) { r.Predict = "Good" },
+		"frequencies": func(r *models.Report) { r.StatusFrequencies = "[1 2]" },
+		"angles":      func(r *models.Report) { r.NeckAngles = "[1.000 x]" },
+	} {
+		report := valid
+		broken(&report)
+
+		// Call the method under test
+		_, err := repositories.ParseLegacyReport(report)
+
+		// Check the result
+		assert.Error(t, err, name)
+	}
+}
+
+func TestReportRepository_BackfillLegacyReports(t *testing.T) {
+	// Create ReportRepository
+	reportRepository, mock, closeDB := newReportRepository(t)
+	defer closeDB()
+
+	columns := []string{"id", "user_id", "predict", "score", "normal_ratio", "neck_angles", "distances", "status_frequencies", "created_at"}
+
+	// Set up expectations for the mock DB: one parseable report and one that is skipped
+	mock.ExpectQuery("SELECT \\* FROM `reports` WHERE id > \\? AND score_value IS NULL ORDER BY id LIMIT 2").
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 1, "[1 0]", "60.00", "0.500", "[10.000 20.000]", "[1.000 2.000]", "[1 1 0 0]", time.Now()).
+			AddRow(2, 1, "Good", "90.000", "90.000", "angle", "distance", "status", time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `report_frames` WHERE report_id = \\?").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `report_frames`").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec("UPDATE `reports` SET").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT \\* FROM `reports` WHERE id > \\? AND score_value IS NULL ORDER BY id LIMIT 2").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	// Call the method under test
+	backfilled, failed, err := reportRepository.BackfillLegacyReports(2)
+
+	// Check that the expectations were met
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, 1, backfilled)
+	assert.Equal(t, 1, failed)
+}
+
+func TestReportRepository_AssignPublicIDs(t *testing.T) {
+	// Create ReportRepository
+	reportRepository, mock, closeDB := newReportRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB: one report without a public ID, then none left
+	mock.ExpectQuery("SELECT `id` FROM `reports` WHERE public_id IS NULL OR public_id = \\? ORDER BY id LIMIT 2").
+		WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `reports` SET `public_id`=\\? WHERE id = \\?").
+		WithArgs(sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT `id` FROM `reports` WHERE public_id IS NULL OR public_id = \\? ORDER BY id LIMIT 2").
+		WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Call the method under test
+	assigned, err := reportRepository.AssignPublicIDs(2)
+
+	// Check that the expectations were met
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, 1, assigned)
+}
@@ -7,6 +7,7 @@ import (
 	users "gdsc/baro/app/user/models"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -14,6 +15,12 @@ type ReportRepositoryInterface interface {
 	Save(report *models.Report) (models.Report, error)
 	FindByUserID(userID uint) ([]models.Report, error)
 	FindById(id uint) (models.Report, error)
+	FindByUserIDWithFrames(userID uint) ([]models.Report, error)
+	FindByIdWithFrames(id uint) (models.Report, error)
+	FindByPublicIDWithFrames(publicID string) (models.Report, error)
+	SaveAlertEvents(events []models.AlertEvent) error
+	FindBatchWithFrames(afterID uint, limit int) ([]models.Report, error)
+	UpdateScore(report *models.Report) error
 	FindByYearAndMonth(userID uint, month string) ([]models.Report, error)
 	FindAll() ([]models.Report, error)
 	FindRankAtAgeAndGender(user *users.User, start, end time.Time) (types.ResponseRank, error)
@@ -29,7 +36,11 @@ func NewReportRepository(db *gorm.DB) *ReportRepository {
 	}
 }
 
+// Save creates the report, giving it a public ID unless it already has one.
 func (repo *ReportRepository) Save(report *models.Report) (models.Report, error) {
+	if report.PublicID == "" {
+		report.PublicID = uuid.NewString()
+	}
 	if err := repo.DB.Create(report).Error; err != nil {
 		return models.Report{}, err
 	}
@@ -48,6 +59,61 @@ func (repo *ReportRepository) FindById(id uint) (models.Report, error) {
 	return report, result.Error
 }
 
+// FindByUserIDWithFrames returns the reports of a user with their frames and alert events.
+func (repo *ReportRepository) FindByUserIDWithFrames(userID uint) ([]models.Report, error) {
+	var reports []models.Report
+	result := repo.DB.Preload("Frames", orderFrames).Preload("Alerts", orderAlerts).Where("user_id = ?", userID).Find(&reports)
+	return reports, result.Error
+}
+
+// FindByIdWithFrames returns a report with its frames and alert events.
+func (repo *ReportRepository) FindByIdWithFrames(id uint) (models.Report, error) {
+	var report models.Report
+	result := repo.DB.Preload("Frames", orderFrames).Preload("Alerts", orderAlerts).Where("id = ?", id).First(&report)
+	return report, result.Error
+}
+
+// FindByPublicIDWithFrames returns a report with its frames and alert events by its public ID.
+func (repo *ReportRepository) FindByPublicIDWithFrames(publicID string) (models.Report, error) {
+	var report models.Report
+	result := repo.DB.Preload("Frames", orderFrames).Preload("Alerts", orderAlerts).Where("public_id = ?", publicID).First(&report)
+	return report, result.Error
+}
+
+func (repo *ReportRepository) SaveAlertEvents(events []models.AlertEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return repo.DB.Create(&events).Error
+}
+
+// FindBatchWithFrames returns up to limit reports with an id greater than afterID, in id order.
+func (repo *ReportRepository) FindBatchWithFrames(afterID uint, limit int) ([]models.Report, error) {
+	var reports []models.Report
+	result := repo.DB.Preload("Frames", orderFrames).Where("id > ?", afterID).Order("id").Limit(limit).Find(&reports)
+	return reports, result.Error
+}
+
+// UpdateScore saves the score columns of report, leaving everything else as it is.
+func (repo *ReportRepository) UpdateScore(report *models.Report) error {
+	return repo.DB.Model(&models.Report{}).Where("id = ?", report.ID).Updates(map[string]interface{}{
+		"score":                            report.Score,
+		"score_value":                      report.ScoreValue,
+		"scoring_profile_version":          report.ScoringProfileVersion,
+		"original_score":                   report.OriginalScore,
+		"original_scoring_profile_version": report.OriginalScoringProfileVersion,
+	}).Error
+}
+
+func orderFrames(db *gorm.DB) *gorm.DB {
+	return db.Order("frame_index")
+}
+
+func orderAlerts(db *gorm.DB) *gorm.DB {
+	return db.Order("occurred_at").Order("id")
+}
+
 func (repo *ReportRepository) FindByYearAndMonth(userID uint, yearAndMonth string) ([]models.Report, error) {
 	var reports []models.Report
 	result := repo.DB.Where("user_id = ? AND DATE_FORMAT(created_at, '%Y%m') = ?", userID, yearAndMonth).Find(&reports)
@@ -67,7 +133,7 @@ func (repo *ReportRepository) FindRankAtAgeAndGender(user *users.User, start, en
 
 	// calculate average score for the user
 	err := repo.DB.Table("reports").
-		Select("avg(score)").
+		Select("avg(score_value)").
 		Joins("inner join users on users.id = reports.user_id").
 		Where("reports.user_id = ?", user.ID).
 		Where("reports.created_at BETWEEN ? AND ?", start, end).
@@ -89,7 +155,7 @@ func (repo *ReportRepository) FindRankAtAgeAndGender(user *users.User, start, en
 
 	// calculate average score for all users in the same age group and gender
 	err = repo.DB.Table("reports").
-		Select("avg(score)").
+		Select("avg(score_value)").
 		Joins("inner join users on users.id = reports.user_id").
 		Where("users.age >= ? AND users.age < ?", ageGroup, ageGroup+10).
 		Where("users.gender = ?", user.Gender).
@@ -104,7 +170,7 @@ func (repo *ReportRepository) FindRankAtAgeAndGender(user *users.User, start, en
 	sql := `
 	SELECT COUNT(*) as rank_count
 	FROM (
-		SELECT reports.user_id, AVG(score) as average_score
+		SELECT reports.user_id, AVG(score_value) as average_score
 		FROM reports
 		INNER JOIN users on users.id = reports.user_id
 		WHERE users.age >= ? AND users.age < ?
@@ -53,7 +53,7 @@ func TestReportrRepository_Save(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample report
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `reports`").
-		WithArgs(report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, report.CreatedAt).
+		WithArgs(sqlmock.AnyArg(), report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, nil, nil, nil, nil, nil, nil, report.ScoringProfileVersion, nil, report.OriginalScoringProfileVersion, report.CreatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -120,7 +120,7 @@ func TestReportRepository_Save_Error(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample report
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `reports`").
-		WithArgs(report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, report.CreatedAt).
+		WithArgs(sqlmock.AnyArg(), report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, nil, nil, nil, nil, nil, nil, report.ScoringProfileVersion, nil, report.OriginalScoringProfileVersion, report.CreatedAt).
 		WillReturnError(err)
 	mock.ExpectRollback()
 
@@ -174,7 +174,7 @@ func TestReportRepository_FindByUserID(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample report
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `reports`").
-		WithArgs(report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, report.CreatedAt).
+		WithArgs(sqlmock.AnyArg(), report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, nil, nil, nil, nil, nil, nil, report.ScoringProfileVersion, nil, report.OriginalScoringProfileVersion, report.CreatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -289,7 +289,7 @@ func TestReportRepository_FindById(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample report
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `reports`").
-		WithArgs(report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, report.CreatedAt).
+		WithArgs(sqlmock.AnyArg(), report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, nil, nil, nil, nil, nil, nil, report.ScoringProfileVersion, nil, report.OriginalScoringProfileVersion, report.CreatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -403,7 +403,7 @@ func TestReportRepository_FindByYearAndMonth(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample report
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `reports`").
-		WithArgs(report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, report.CreatedAt).
+		WithArgs(sqlmock.AnyArg(), report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, nil, nil, nil, nil, nil, nil, report.ScoringProfileVersion, nil, report.OriginalScoringProfileVersion, report.CreatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -523,7 +523,7 @@ func TestReportRepository_FindAll(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample report
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `reports`").
-		WithArgs(report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, report.CreatedAt).
+		WithArgs(sqlmock.AnyArg(), report.UserID, report.AlertCount, report.AnalysisTime, report.Type, report.Predict, report.Score, report.NormalRatio, report.NeckAngles, report.Distances, report.StatusFrequencies, nil, nil, nil, nil, nil, nil, report.ScoringProfileVersion, nil, report.OriginalScoringProfileVersion, report.CreatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -635,7 +635,7 @@ func TestReportRepository_FindRankAtAgeAndGender(t *testing.T) {
 	allAvgScore := 90.0
 
 	// Set up expectations for the mock DB to return the sample report
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(user.ID, start, end).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).
 			AddRow("80.0"))
@@ -647,13 +647,13 @@ func TestReportRepository_FindRankAtAgeAndGender(t *testing.T) {
 			AddRow("100"))
 
 	// Set up expectations for the mock DB to return average score for all users in the same age group and gender
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE \\(users.age >= \\? AND users.age < \\?\\) AND users.gender = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE \\(users.age >= \\? AND users.age < \\?\\) AND users.gender = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(ageGroup, ageGroup+10, user.Gender, start, end).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).
 			AddRow(allAvgScore))
 
 	// Set up expectations for the mock DB to return rank
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) as rank_count FROM \\(\\s*SELECT reports.user_id, AVG\\(score\\) as average_score FROM reports INNER JOIN users\\s+on\\s+users.id = reports.user_id WHERE users.age >= \\? AND users.age < \\? AND users.gender = \\? AND reports.created_at BETWEEN \\? AND \\? GROUP BY reports.user_id\\s*\\) as subquery WHERE average_score > \\?").
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) as rank_count FROM \\(\\s*SELECT reports.user_id, AVG\\(score_value\\) as average_score FROM reports INNER JOIN users\\s+on\\s+users.id = reports.user_id WHERE users.age >= \\? AND users.age < \\? AND users.gender = \\? AND reports.created_at BETWEEN \\? AND \\? GROUP BY reports.user_id\\s*\\) as subquery WHERE average_score > \\?").
 		WithArgs(ageGroup, ageGroup+10, user.Gender, start, end, userAvgScore).
 		WillReturnRows(sqlmock.NewRows([]string{"rank_count"}).
 			AddRow("20"))
@@ -712,7 +712,7 @@ func TestReportRepository_FindRankAtAgeAndGender_Error(t *testing.T) {
 	end := time.Now()
 
 	// Set up expectations for the mock DB to return the sample report
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(user.ID, start, end).
 		WillReturnError(err)
 
@@ -763,7 +763,7 @@ func TestReportRepository_FindRankAtAgeAndGender_Error2(t *testing.T) {
 	ageGroup := 20
 
 	// Set up expectations for the mock DB to return the sample report
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(user.ID, start, end).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).
 			AddRow("80.0"))
@@ -820,7 +820,7 @@ func TestReportRepository_FindRankAtAgeAndGender_Error3(t *testing.T) {
 	ageGroup := 20
 
 	// Set up expectations for the mock DB to return the sample report
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(user.ID, start, end).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).
 			AddRow("80.0"))
@@ -832,7 +832,7 @@ func TestReportRepository_FindRankAtAgeAndGender_Error3(t *testing.T) {
 			AddRow("100"))
 
 	// Set up expectations for the mock DB to return average score for all users in the same age group and gender
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE \\(users.age >= \\? AND users.age < \\?\\) AND users.gender = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE \\(users.age >= \\? AND users.age < \\?\\) AND users.gender = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(ageGroup, ageGroup+10, user.Gender, start, end).
 		WillReturnError(err)
 
@@ -885,7 +885,7 @@ func TestReportRepository_FindRankAtAgeAndGender_Error4(t *testing.T) {
 	allAvgScore := 90.0
 
 	// Set up expectations for the mock DB to return the sample report
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE reports.user_id = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(user.ID, start, end).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).
 			AddRow("80.0"))
@@ -897,13 +897,13 @@ func TestReportRepository_FindRankAtAgeAndGender_Error4(t *testing.T) {
 			AddRow("100"))
 
 	// Set up expectations for the mock DB to return average score for all users in the same age group and gender
-	mock.ExpectQuery("SELECT avg\\(score\\) FROM `reports` inner join users on users.id = reports.user_id WHERE \\(users.age >= \\? AND users.age < \\?\\) AND users.gender = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
+	mock.ExpectQuery("SELECT avg\\(score_value\\) FROM `reports` inner join users on users.id = reports.user_id WHERE \\(users.age >= \\? AND users.age < \\?\\) AND users.gender = \\? AND \\(reports.created_at BETWEEN \\? AND \\?\\)").
 		WithArgs(ageGroup, ageGroup+10, user.Gender, start, end).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).
 			AddRow(allAvgScore))
 
 	// Set up expectations for the mock DB to return rank
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) as rank_count FROM \\(\\s*SELECT reports.user_id, AVG\\(score\\) as average_score FROM reports INNER JOIN users\\s+on\\s+users.id = reports.user_id WHERE users.age >= \\? AND users.age < \\? AND users.gender = \\? AND reports.created_at BETWEEN \\? AND \\? GROUP BY reports.user_id\\s*\\) as subquery WHERE average_score > \\?").
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) as rank_count FROM \\(\\s*SELECT reports.user_id, AVG\\(score_value\\) as average_score FROM reports INNER JOIN users\\s+on\\s+users.id = reports.user_id WHERE users.age >= \\? AND users.age < \\? AND users.gender = \\? AND reports.created_at BETWEEN \\? AND \\? GROUP BY reports.user_id\\s*\\) as subquery WHERE average_score > \\?").
 		WithArgs(ageGroup, ageGroup+10, user.Gender, start, end, userAvgScore).
 		WillReturnError(err)
 
@@ -916,3 +916,130 @@ func TestReportRepository_FindRankAtAgeAndGender_Error4(t *testing.T) {
 	// Check that the expectations were met
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestReportRepository_FindByIdWithFrames(t *testing.T) {
+	// Create ReportRepository
+	reportRepository, mock, closeDB := newReportRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB: the report, then its alert events and frames in order
+	mock.ExpectQuery("SELECT \\* FROM `reports` WHERE id = \\? ORDER BY `reports`.`id` LIMIT 1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "score_value"}).
+			AddRow(1, 1, 75.5))
+	mock.ExpectQuery("SELECT \\* FROM `alert_events` WHERE `alert_events`.`report_id` = \\? ORDER BY occurred_at,id").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "report_id", "type", "severity"}).
+			AddRow(1, 1, "hunched", "high"))
+	mock.ExpectQuery("SELECT \\* FROM `report_frames` WHERE `report_frames`.`report_id` = \\? ORDER BY frame_index").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "report_id", "frame_index", "class", "angle"}).
+			AddRow(1, 1, 0, 1, 12.5).
+			AddRow(2, 1, 1, 0, nil))
+
+	// Call the method under test
+	report, err := reportRepository.FindByIdWithFrames(1)
+
+	// Check that the expectations were met
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, 75.5, *report.ScoreValue)
+	assert.Len(t, report.Frames, 2)
+	assert.Equal(t, 12.5, *report.Frames[0].Angle)
+	assert.Nil(t, report.Frames[1].Angle)
+	assert.Len(t, report.Alerts, 1)
+	assert.Equal(t, "high", report.Alerts[0].Severity)
+}
+
+func TestReportRepository_FindByPublicIDWithFrames(t *testing.T) {
+	// Create ReportRepository
+	reportRepository, mock, closeDB := newReportRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB: the report, then its alert events and frames
+	mock.ExpectQuery("SELECT \\* FROM `reports` WHERE public_id = \\? ORDER BY `reports`.`id` LIMIT 1").
+		WithArgs("2b7c1f3e-9a4d-4e61-8f0a-3c5d7e9b1a24").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "public_id", "user_id"}).
+			AddRow(1, "2b7c1f3e-9a4d-4e61-8f0a-3c5d7e9b1a24", 1))
+	mock.ExpectQuery("SELECT \\* FROM `alert_events` WHERE `alert_events`.`report_id` = \\? ORDER BY occurred_at,id").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "report_id"}))
+	mock.ExpectQuery("SELECT \\* FROM `report_frames` WHERE `report_frames`.`report_id` = \\? ORDER BY frame_index").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "report_id", "frame_index"}).
+			AddRow(1, 1, 0))
+
+	// Call the method under test
+	report, err := reportRepository.FindByPublicIDWithFrames("2b7c1f3e-9a4d-4e61-8f0a-3c5d7e9b1a24")
+
+	// Check that the expectations were met
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, uint(1), report.ID)
+	assert.Len(t, report.Frames, 1)
+}
+
+func TestReportRepository_SaveAlertEvents(t *testing.T) {
+	// Create ReportRepository
+	reportRepository, mock, closeDB := newReportRepository(t)
+	defer closeDB()
+
+	// Create sample events for the test
+	correctedAfter := int64(4000)
+	occurredAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	events := []models.AlertEvent{
+		{ReportID: 1, Type: "hunched", Severity: models.AlertSeverityHigh, OccurredAt: occurredAt, CorrectedAfterMs: &correctedAfter},
+		{ReportID: 1, Type: "hunched", Severity: models.AlertSeverityLow, OccurredAt: occurredAt.Add(time.Minute)},
+	}
+
+	// Set up expectations for the mock DB to insert both events at once
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `alert_events` \\(`report_id`,`type`,`severity`,`occurred_at`,`corrected_after_ms`,`created_at`\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?\\),\\(\\?,\\?,\\?,\\?,\\?,\\?\\)").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	err := reportRepository.SaveAlertEvents(events)
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Nothing is written without events
+	assert.NoError(t, reportRepository.SaveAlertEvents(nil))
+}
+
+func TestReportRepository_UpdateScore(t *testing.T) {
+	// Create ReportRepository
+	reportRepository, mock, closeDB := newReportRepository(t)
+	defer closeDB()
+
+	// Create a rescored report for the test
+	score, original := 50.0, 59.0
+	report := models.Report{
+		ID:                            1,
+		Score:                         "50.00",
+		ScoreValue:                    &score,
+		ScoringProfileVersion:         "v2",
+		OriginalScore:                 &original,
+		OriginalScoringProfileVersion: "v1",
+	}
+
+	// Set up expectations for the mock DB to update only the score columns
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `reports` SET `original_score`=\\?,`original_scoring_profile_version`=\\?,`score`=\\?,`score_value`=\\?,`scoring_profile_version`=\\? WHERE id = \\?").
+		WithArgs(original, "v1", "50.00", score, "v2", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	err := reportRepository.UpdateScore(&report)
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"gdsc/baro/app/report/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ScoringProfileRepositoryInterface interface {
+	Create(profile *models.ScoringProfile) (models.ScoringProfile, error)
+	FindAll() ([]models.ScoringProfile, error)
+	FindByVersion(version string) (models.ScoringProfile, error)
+	FindActive() (models.ScoringProfile, error)
+	Activate(version string) (models.ScoringProfile, error)
+}
+
+type ScoringProfileRepository struct {
+	DB *gorm.DB
+}
+
+func NewScoringProfileRepository(db *gorm.DB) *ScoringProfileRepository {
+	return &ScoringProfileRepository{
+		DB: db,
+	}
+}
+
+func (repo *ScoringProfileRepository) Create(profile *models.ScoringProfile) (models.ScoringProfile, error) {
+	if err := repo.DB.Create(profile).Error; err != nil {
+		return models.ScoringProfile{}, err
+	}
+	return *profile, nil
+}
+
+func (repo *ScoringProfileRepository) FindAll() ([]models.ScoringProfile, error) {
+	var profiles []models.ScoringProfile
+	result := repo.DB.Order("id").Find(&profiles)
+	return profiles, result.Error
+}
+
+func (repo *ScoringProfileRepository) FindByVersion(version string) (models.ScoringProfile, error) {
+	var profile models.ScoringProfile
+	result := repo.DB.Where("version = ?", version).First(&profile)
+	return profile, result.Error
+}
+
+func (repo *ScoringProfileRepository) FindActive() (models.ScoringProfile, error) {
+	var profile models.ScoringProfile
+	result := repo.DB.Where("active = ?", true).First(&profile)
+	return profile, result.Error
+}
+
+// Activate makes version the only active profile.
+func (repo *ScoringProfileRepository) Activate(version string) (models.ScoringProfile, error) {
+	var profile models.ScoringProfile
+
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("version = ?", version).First(&profile).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.ScoringProfile{}).Where("active = ? AND id <> ?", true, profile.ID).Update("active", false).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		profile.Active = true
+		profile.ActivatedAt = &now
+		return tx.Model(&profile).Updates(map[string]interface{}{"active": true, "activated_at": now}).Error
+	})
+	if err != nil {
+		return models.ScoringProfile{}, err
+	}
+
+	return profile, nil
+}
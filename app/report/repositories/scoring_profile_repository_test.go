@@ -0,0 +1,84 @@
+package repositories_test
+
+import (
+	"gdsc/baro/app/report/repositories"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newScoringProfileRepository(t *testing.T) (*repositories.ScoringProfileRepository, sqlmock.Sqlmock, func()) {
+	// Create mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB: %v", err)
+	}
+
+	// Set up expectations for the mock DB (ex: SELECT VERSION())
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION"}).
+			AddRow("8.0.0"))
+
+	// Create gorm.DB
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error creating gorm.DB: %v", err)
+	}
+
+	return repositories.NewScoringProfileRepository(gormDB), mock, func() { db.Close() }
+}
+
+func TestScoringProfileRepository_Activate(t *testing.T) {
+	// Create ScoringProfileRepository
+	scoringProfileRepository, mock, closeDB := newScoringProfileRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB: the other profiles are deactivated in the same transaction
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `scoring_profiles` WHERE version = \\?").
+		WithArgs("v2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version", "rules", "active"}).
+			AddRow(2, "v2", "{}", false))
+	mock.ExpectExec("UPDATE `scoring_profiles` SET `active`=\\? WHERE active = \\? AND id <> \\?").
+		WithArgs(false, true, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `scoring_profiles` SET `activated_at`=\\?,`active`=\\? WHERE `id` = \\?").
+		WithArgs(sqlmock.AnyArg(), true, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	profile, err := scoringProfileRepository.Activate("v2")
+
+	// Check that the expectations were met
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, "v2", profile.Version)
+	assert.True(t, profile.Active)
+	assert.NotNil(t, profile.ActivatedAt)
+}
+
+func TestScoringProfileRepository_Activate_NotFound(t *testing.T) {
+	// Create ScoringProfileRepository
+	scoringProfileRepository, mock, closeDB := newScoringProfileRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB: nothing is changed
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `scoring_profiles` WHERE version = \\?").
+		WithArgs("v9").
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	// Call the method under test
+	_, err := scoringProfileRepository.Activate("v9")
+
+	// Check the result
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
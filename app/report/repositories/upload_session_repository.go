@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"gdsc/baro/app/report/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UploadSessionRepositoryInterface interface {
+	Create(session *models.UploadSession) (models.UploadSession, error)
+	FindByIdAndUserID(id string, userID uint) (models.UploadSession, error)
+	Advance(session *models.UploadSession, fromReceived int64) (bool, error)
+	Update(session *models.UploadSession) (models.UploadSession, error)
+	ClaimUploadToken(key string, expiresAt time.Time) (bool, error)
+	ReleaseUploadToken(key string) error
+}
+
+type UploadSessionRepository struct {
+	DB *gorm.DB
+}
+
+func NewUploadSessionRepository(db *gorm.DB) *UploadSessionRepository {
+	return &UploadSessionRepository{
+		DB: db,
+	}
+}
+
+func (repo *UploadSessionRepository) Create(session *models.UploadSession) (models.UploadSession, error) {
+	if err := repo.DB.Create(session).Error; err != nil {
+		return models.UploadSession{}, err
+	}
+	return *session, nil
+}
+
+func (repo *UploadSessionRepository) FindByIdAndUserID(id string, userID uint) (models.UploadSession, error) {
+	var session models.UploadSession
+	if err := repo.DB.Where("id = ? AND user_id = ?", id, userID).First(&session).Error; err != nil {
+		return models.UploadSession{}, err
+	}
+	return session, nil
+}
+
+// Advance stores the new Received and PartKeys of the session only if no other chunk
+// was accepted since fromReceived. It reports whether this caller's chunk was accepted.
+func (repo *UploadSessionRepository) Advance(session *models.UploadSession, fromReceived int64) (bool, error) {
+	result := repo.DB.Model(&models.UploadSession{}).
+		Where("id = ? AND received = ?", session.ID, fromReceived).
+		Updates(map[string]interface{}{
+			"received":  session.Received,
+			"part_keys": session.PartKeys,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (repo *UploadSessionRepository) Update(session *models.UploadSession) (models.UploadSession, error) {
+	if err := repo.DB.Save(session).Error; err != nil {
+		return models.UploadSession{}, err
+	}
+	return *session, nil
+}
+
+// ClaimUploadToken records that the upload URL of key is being used. It reports whether this caller
+// claimed it, which is false once any upload to the URL has claimed it before.
+func (repo *UploadSessionRepository) ClaimUploadToken(key string, expiresAt time.Time) (bool, error) {
+	result := repo.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UsedUploadToken{ObjectKey: key, ExpiresAt: expiresAt})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleaseUploadToken lets the upload URL of key be used again after an upload to it failed.
+func (repo *UploadSessionRepository) ReleaseUploadToken(key string) error {
+	return repo.DB.Where("object_key = ?", key).Delete(&models.UsedUploadToken{}).Error
+}
@@ -0,0 +1,173 @@
+package repositories_test
+
+import (
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/repositories"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newUploadSessionRepository(t *testing.T) (*repositories.UploadSessionRepository, sqlmock.Sqlmock, func()) {
+	// Create mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB: %v", err)
+	}
+
+	// Set up expectations for the mock DB (ex: SELECT VERSION())
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION"}).
+			AddRow("8.0.0"))
+
+	// Create gorm.DB
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error creating gorm.DB: %v", err)
+	}
+
+	return repositories.NewUploadSessionRepository(gormDB), mock, func() { db.Close() }
+}
+
+func TestUploadSessionRepository_Create(t *testing.T) {
+	// Create UploadSessionRepository
+	uploadSessionRepository, mock, closeDB := newUploadSessionRepository(t)
+	defer closeDB()
+
+	// Create sample session for the test
+	session := models.UploadSession{
+		ID:          "8c6f1f8e-0d4a-4d57-9d2a-1c1f9f0e8a11",
+		UserID:      1,
+		Size:        1024,
+		ContentType: "video/mp4",
+		Status:      models.UploadStatusPending,
+	}
+
+	// Set up expectations for the mock DB to insert the session
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `upload_sessions`").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	createdSession, err := uploadSessionRepository.Create(&session)
+	if err != nil {
+		t.Fatalf("Error creating session: %v", err)
+	}
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, session.ID, createdSession.ID)
+	assert.Equal(t, int64(1024), createdSession.Size)
+}
+
+func TestUploadSessionRepository_FindByIdAndUserID(t *testing.T) {
+	// Create UploadSessionRepository
+	uploadSessionRepository, mock, closeDB := newUploadSessionRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB to return the session
+	mock.ExpectQuery("SELECT \\* FROM `upload_sessions` WHERE id = \\? AND user_id = \\?").
+		WithArgs("abc", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "size", "received", "status"}).
+			AddRow("abc", 1, 1024, 512, models.UploadStatusPending))
+
+	// Call the method under test
+	session, err := uploadSessionRepository.FindByIdAndUserID("abc", 1)
+	if err != nil {
+		t.Fatalf("Error finding session: %v", err)
+	}
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.Equal(t, int64(512), session.Received)
+}
+
+func TestUploadSessionRepository_Advance(t *testing.T) {
+	// Create UploadSessionRepository
+	uploadSessionRepository, mock, closeDB := newUploadSessionRepository(t)
+	defer closeDB()
+
+	// Set up a session that just received its second chunk
+	session := models.UploadSession{ID: "abc", Received: 1024, PartKeys: "uploads/abc/0\nuploads/abc/512"}
+
+	// Set up expectations for the mock DB: the first update wins, a concurrent one with the same offset does not
+	for _, rowsAffected := range []int64{1, 0} {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `upload_sessions` SET `part_keys`=\\?,`received`=\\?,`updated_at`=\\? WHERE id = \\? AND received = \\?").
+			WithArgs(session.PartKeys, session.Received, sqlmock.AnyArg(), "abc", 512).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		mock.ExpectCommit()
+	}
+
+	// Call the method under test
+	first, err := uploadSessionRepository.Advance(&session, 512)
+	assert.NoError(t, err)
+	second, err := uploadSessionRepository.Advance(&session, 512)
+	assert.NoError(t, err)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.True(t, first)
+	assert.False(t, second)
+}
+
+func TestUploadSessionRepository_ClaimUploadToken(t *testing.T) {
+	// Create UploadSessionRepository
+	uploadSessionRepository, mock, closeDB := newUploadSessionRepository(t)
+	defer closeDB()
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	// Set up expectations for the mock DB: the first upload claims the key, a replay finds it taken
+	for _, rowsAffected := range []int64{1, 0} {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `used_upload_tokens` .* ON DUPLICATE KEY UPDATE").
+			WithArgs("videos/1/a.mp4", expiresAt, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		mock.ExpectCommit()
+	}
+
+	// Call the method under test
+	first, err := uploadSessionRepository.ClaimUploadToken("videos/1/a.mp4", expiresAt)
+	assert.NoError(t, err)
+	second, err := uploadSessionRepository.ClaimUploadToken("videos/1/a.mp4", expiresAt)
+	assert.NoError(t, err)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.True(t, first)
+	assert.False(t, second)
+}
+
+func TestUploadSessionRepository_ReleaseUploadToken(t *testing.T) {
+	// Create UploadSessionRepository
+	uploadSessionRepository, mock, closeDB := newUploadSessionRepository(t)
+	defer closeDB()
+
+	// Set up expectations for the mock DB
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `used_upload_tokens` WHERE object_key = \\?").
+		WithArgs("videos/1/a.mp4").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	err := uploadSessionRepository.ReleaseUploadToken("videos/1/a.mp4")
+
+	// Check the results
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/types"
+	"strings"
+)
+
+// Names of the built-in analysis types.
+const (
+	AnalysisTypeSideSitting  = "side_sitting"
+	AnalysisTypeFrontSitting = "front_sitting"
+	AnalysisTypeStanding     = "standing"
+	AnalysisTypeStretch      = "stretch"
+	// AnalysisTypeLegacy is given to jobs and reports stored before types were checked whose type
+	// has no alias. It cannot be submitted.
+	AnalysisTypeLegacy = "legacy"
+)
+
+// Sources an analysis can be submitted from: a video analyzed by the AI server, landmarks sent
+// in one request or a live posture session.
+const (
+	AnalysisSourceVideo     = "video"
+	AnalysisSourceKeypoints = "keypoints"
+	AnalysisSourceSession   = "session"
+)
+
+var (
+	ErrUnknownAnalysisType      = errors.New("unknown analysis type")
+	ErrAnalysisSourceNotAllowed = errors.New("analysis type cannot be submitted this way")
+	ErrAnalysisTooLong          = errors.New("analysis time exceeds the limit of the analysis type")
+)
+
+// AnalysisType describes one kind of posture analysis a report can be made of.
+type AnalysisType struct {
+	Name        string
+	Description string
+	// Sources lists the Analysis* sources the type accepts.
+	Sources []string
+	// MaxAnalysisTime bounds the analysis time of a request in seconds; 0 leaves it unbounded.
+	MaxAnalysisTime int
+	// Analyzer runs the AI server analysis of the type; nil uses the analyzer of the report service.
+	Analyzer analyzers.Analyzer
+	// Parse adapts the AI server response to what reports store before it is validated; nil keeps it as is.
+	Parse func(response *types.ResponseAnalysis)
+	// Validate checks the response before it is scored; nil uses ValidateAnalysis.
+	Validate func(response *types.ResponseAnalysis) error
+	// ScoringProfile is the profile version reports of the type are scored with; empty uses the active profile.
+	ScoringProfile string
+}
+
+// DefaultAnalysisTypes returns the built-in analysis types, all analyzed by the default AI server
+// and scored with the active profile. Types filmed in profile check the neck statuses against the
+// landmarks; the others drop the landmarks.
+func DefaultAnalysisTypes() []AnalysisType {
+	return []AnalysisType{
+		{
+			Name:        AnalysisTypeSideSitting,
+			Description: "Sitting, filmed from the side",
+			Sources:     []string{AnalysisSourceVideo, AnalysisSourceKeypoints, AnalysisSourceSession},
+			Validate:    ValidateProfileAnalysis,
+		},
+		{
+			Name:        AnalysisTypeFrontSitting,
+			Description: "Sitting, filmed from the front",
+			Sources:     []string{AnalysisSourceVideo},
+			Parse:       dropLandmarks,
+		},
+		{
+			Name:        AnalysisTypeStanding,
+			Description: "Standing, filmed from the side",
+			Sources:     []string{AnalysisSourceVideo, AnalysisSourceKeypoints, AnalysisSourceSession},
+			Validate:    ValidateProfileAnalysis,
+		},
+		{
+			Name:            AnalysisTypeStretch,
+			Description:     "Stretching, filmed from the side; neck landmarks are not kept",
+			Sources:         []string{AnalysisSourceVideo},
+			MaxAnalysisTime: 600,
+			Parse:           dropLandmarks,
+		},
+	}
+}
+
+// DefaultAnalysisTypeAliases maps the free-form types clients sent before types were checked to the
+// built-in types. Aliases are matched regardless of case.
+func DefaultAnalysisTypeAliases() map[string]string {
+	return map[string]string{
+		"study": AnalysisTypeSideSitting,
+	}
+}
+
+// LegacyAnalysisType returns the type of jobs and reports whose stored type is neither a registered
+// type nor an alias. It is analyzed by the default AI server and scored with the active profile.
+func LegacyAnalysisType() AnalysisType {
+	return AnalysisType{
+		Name:        AnalysisTypeLegacy,
+		Description: "Stored before analysis types were checked",
+	}
+}
+
+// dropLandmarks discards the neck landmarks of a response, which are only meaningful for a person
+// sitting or standing in profile.
+func dropLandmarks(response *types.ResponseAnalysis) {
+	response.LandmarksInfo = nil
+	response.StatusFrequencies = map[string]int{}
+}
+
+// Check returns an error unless an analysis of analysisTime seconds may be submitted from source.
+func (analysisType AnalysisType) Check(source string, analysisTime int) error {
+	if !analysisType.Supports(source) {
+		return fmt.Errorf("%w: %s from %s", ErrAnalysisSourceNotAllowed, analysisType.Name, source)
+	}
+	if analysisType.MaxAnalysisTime > 0 && analysisTime > analysisType.MaxAnalysisTime {
+		return fmt.Errorf("%w: %s allows %d seconds", ErrAnalysisTooLong, analysisType.Name, analysisType.MaxAnalysisTime)
+	}
+
+	return nil
+}
+
+func (analysisType AnalysisType) Supports(source string) bool {
+	for _, supported := range analysisType.Sources {
+		if supported == source {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validate parses and validates an AI server response for a report of this type.
+func (analysisType AnalysisType) validate(response *types.ResponseAnalysis) error {
+	if analysisType.Parse != nil {
+		analysisType.Parse(response)
+	}
+	if analysisType.Validate != nil {
+		return analysisType.Validate(response)
+	}
+
+	return ValidateAnalysis(response)
+}
+
+// AnalysisTypeRegistry holds the analysis types clients may submit, in the order they are listed,
+// and the aliases the types stored before types were checked are known by.
+type AnalysisTypeRegistry struct {
+	names   []string
+	types   map[string]AnalysisType
+	aliases map[string]string
+}
+
+func NewAnalysisTypeRegistry(analysisTypes ...AnalysisType) *AnalysisTypeRegistry {
+	registry := &AnalysisTypeRegistry{types: map[string]AnalysisType{}, aliases: map[string]string{}}
+	for _, analysisType := range analysisTypes {
+		if _, ok := registry.types[analysisType.Name]; !ok {
+			registry.names = append(registry.names, analysisType.Name)
+		}
+		registry.types[analysisType.Name] = analysisType
+	}
+
+	return registry
+}
+
+// AddAliases makes each alias find the registered type it maps to.
+func (registry *AnalysisTypeRegistry) AddAliases(aliases map[string]string) error {
+	for alias, name := range aliases {
+		if _, ok := registry.types[name]; !ok {
+			return fmt.Errorf("%w: %q is aliased as %q", ErrUnknownAnalysisType, name, alias)
+		}
+		registry.aliases[normalizeAlias(alias)] = name
+	}
+
+	return nil
+}
+
+// Find returns the type called name or aliased as name, or an error wrapping ErrUnknownAnalysisType.
+func (registry *AnalysisTypeRegistry) Find(name string) (AnalysisType, error) {
+	if analysisType, ok := registry.types[name]; ok {
+		return analysisType, nil
+	}
+	if alias, ok := registry.aliases[normalizeAlias(name)]; ok {
+		return registry.types[alias], nil
+	}
+
+	return AnalysisType{}, fmt.Errorf("%w: %q", ErrUnknownAnalysisType, name)
+}
+
+// Resolve returns the type called or aliased as name, or the legacy type for names stored before
+// types were checked that have no alias.
+func (registry *AnalysisTypeRegistry) Resolve(name string) AnalysisType {
+	if analysisType, err := registry.Find(name); err == nil {
+		return analysisType
+	}
+
+	return LegacyAnalysisType()
+}
+
+func (registry *AnalysisTypeRegistry) All() []AnalysisType {
+	analysisTypes := make([]AnalysisType, 0, len(registry.names))
+	for _, name := range registry.names {
+		analysisTypes = append(analysisTypes, registry.types[name])
+	}
+
+	return analysisTypes
+}
+
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
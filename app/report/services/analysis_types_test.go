@@ -0,0 +1,240 @@
+package services_test
+
+import (
+	"context"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnalysisTypeRegistry(t *testing.T) {
+	// Create a registry of the built-in types
+	registry := services.NewAnalysisTypeRegistry(services.DefaultAnalysisTypes()...)
+
+	// Check the results: types are listed in order and unknown names are rejected
+	names := []string{}
+	for _, analysisType := range registry.All() {
+		names = append(names, analysisType.Name)
+	}
+	assert.Equal(t, []string{services.AnalysisTypeSideSitting, services.AnalysisTypeFrontSitting, services.AnalysisTypeStanding, services.AnalysisTypeStretch}, names)
+
+	_, err := registry.Find("Study")
+	assert.ErrorIs(t, err, services.ErrUnknownAnalysisType)
+	_, err = registry.Find(services.AnalysisTypeLegacy)
+	assert.ErrorIs(t, err, services.ErrUnknownAnalysisType)
+
+	// Names stored before types were checked resolve to the legacy type, not to another built-in type
+	assert.Equal(t, services.AnalysisTypeLegacy, registry.Resolve("Study").Name)
+	assert.Equal(t, services.AnalysisTypeStretch, registry.Resolve(services.AnalysisTypeStretch).Name)
+}
+
+func TestAnalysisTypeRegistry_Aliases(t *testing.T) {
+	// Create a registry of the built-in types with the default aliases
+	registry := services.NewAnalysisTypeRegistry(services.DefaultAnalysisTypes()...)
+	assert.NoError(t, registry.AddAliases(services.DefaultAnalysisTypeAliases()))
+
+	// Check the results: legacy types with an alias are found regardless of case
+	for _, name := range []string{"Study", "study", " STUDY "} {
+		analysisType, err := registry.Find(name)
+		assert.NoError(t, err)
+		assert.Equal(t, services.AnalysisTypeSideSitting, analysisType.Name)
+		assert.Equal(t, services.AnalysisTypeSideSitting, registry.Resolve(name).Name)
+	}
+
+	// Legacy types without an alias resolve to the legacy type and cannot be submitted
+	_, err := registry.Find("Game")
+	assert.ErrorIs(t, err, services.ErrUnknownAnalysisType)
+	legacy := registry.Resolve("Game")
+	assert.Equal(t, services.AnalysisTypeLegacy, legacy.Name)
+	assert.Nil(t, legacy.Analyzer)
+	assert.Empty(t, legacy.ScoringProfile)
+	assert.Error(t, legacy.Check(services.AnalysisSourceVideo, 60))
+
+	// An alias must name a registered type
+	err = registry.AddAliases(map[string]string{"Work": "desk"})
+	assert.ErrorIs(t, err, services.ErrUnknownAnalysisType)
+}
+
+func TestHandleCallback_LegacyType(t *testing.T) {
+	tests := []struct {
+		jobType  string
+		wantType string
+	}{
+		{jobType: "Study", wantType: services.AnalysisTypeSideSitting},
+		{jobType: "Game", wantType: services.AnalysisTypeLegacy},
+	}
+
+	for _, test := range tests {
+		t.Run(test.jobType, func(t *testing.T) {
+			// Mock ReportRepository, AnalysisJobRepository, UserUtil
+			mockReportRepository := new(MockReportRepository)
+			mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+			mockUserUtil := new(MockUserUtil)
+
+			// Create ReportService in callback mode
+			reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+			reportService.CallbackURL = "https://api.example.com/internal/analysis/callback"
+			reportService.CallbackSecret = "secret"
+
+			// Set up a job queued before types were checked and its result
+			job := models.AnalysisJob{ID: 4, UserID: 1, VideoURL: "https://example.com/video.mp4", AnalysisTime: 600, Type: test.jobType, Status: models.JobStatusSubmitted, Attempts: 1}
+			result := analyzers.BadPostureFixture()
+			body, timestamp, signature := signedCallback(t, "secret", types.RequestAnalysisCallback{JobID: job.ID, Attempt: job.Attempts, Result: &result})
+
+			// Set up expectations for the mock repository and util
+			mockAnalysisJobRepository.On("FindById", job.ID).Return(job, nil)
+			mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusSubmitted, models.JobStatusRunning).Return(true, nil)
+			mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+			mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusRunning, models.JobStatusSucceeded).Return(true, nil)
+			mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 10, UserID: 1}, nil)
+			mockAnalysisJobRepository.On("Update", mock.AnythingOfType("*models.AnalysisJob")).Return(nil)
+
+			// Call the service
+			err := reportService.HandleCallback(context.Background(), body, timestamp, signature)
+			assert.NoError(t, err)
+
+			// Check the results: the report is stored with the type the legacy type resolves to
+			saved := mockReportRepository.Calls[0].Arguments.Get(0).(*models.Report)
+			assert.Equal(t, test.wantType, saved.Type)
+		})
+	}
+}
+
+func TestAnalysisType_Check(t *testing.T) {
+	// Create a registry of the built-in types
+	registry := services.NewAnalysisTypeRegistry(services.DefaultAnalysisTypes()...)
+	stretch, _ := registry.Find(services.AnalysisTypeStretch)
+
+	// Check the results
+	assert.NoError(t, stretch.Check(services.AnalysisSourceVideo, 600))
+	assert.ErrorIs(t, stretch.Check(services.AnalysisSourceVideo, 601), services.ErrAnalysisTooLong)
+	assert.ErrorIs(t, stretch.Check(services.AnalysisSourceKeypoints, 60), services.ErrAnalysisSourceNotAllowed)
+}
+
+func TestAnalysis_UnknownType(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Call the service
+	_, err := reportService.SubmitAnalysis(&usermodel.User{ID: 1}, types.RequestAnalysis{VideoURL: "https://example.com/video.mp4", Type: "juggling"})
+
+	// Check the results: nothing is queued
+	assert.ErrorIs(t, err, services.ErrUnknownAnalysisType)
+	mockAnalysisJobRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAnalyzeKeypointsForUser_SourceNotAllowed(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Call the service: front view sitting is only analyzed from videos
+	_, err := reportService.AnalyzeKeypointsForUser(usermodel.User{ID: 1}, types.RequestKeypointAnalysis{
+		Type:   services.AnalysisTypeFrontSitting,
+		Frames: []types.KeypointFrame{{LeftShoulder: types.Landmark{X: 0.5, Y: 0.6}, LeftEar: types.Landmark{X: 0.5, Y: 0.4}}},
+	})
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrAnalysisSourceNotAllowed)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestPredict_UsesAnalysisType(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil, ScoringProfileRepository
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+	mockScoringProfileRepository := new(MockScoringProfileRepository)
+
+	// Create ReportService where front view sitting has its own AI server and scoring profile
+	defaultAnalyzer := analyzers.NewFakeAnalyzer()
+	frontAnalyzer := analyzers.NewFakeAnalyzer()
+	analysisTypes := services.DefaultAnalysisTypes()
+	analysisTypes[1].Analyzer = frontAnalyzer
+	analysisTypes[1].ScoringProfile = "front-v1"
+
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, defaultAnalyzer, mockUserUtil)
+	reportService.AnalysisTypes = services.NewAnalysisTypeRegistry(analysisTypes...)
+	reportService.Scoring = services.NewScoringService(mockScoringProfileRepository)
+
+	// Set up expectations for the mock repositories
+	mockScoringProfileRepository.On("FindByVersion", "front-v1").Return(newProfile(t, "front-v1", flatRules(), false), nil)
+	mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 1}, nil)
+
+	// Call the service
+	_, err := reportService.Predict(context.Background(), usermodel.User{ID: 1}, types.RequestAnalysis{VideoURL: "https://example.com/front.mp4", Type: services.AnalysisTypeFrontSitting})
+	assert.NoError(t, err)
+
+	// Check the results: the type's analyzer, parser and profile were used
+	assert.Equal(t, []string{"https://example.com/front.mp4"}, frontAnalyzer.Calls())
+	assert.Empty(t, defaultAnalyzer.Calls())
+
+	saved := mockReportRepository.Calls[0].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, "front-v1", saved.ScoringProfileVersion)
+	assert.Equal(t, "[]", saved.NeckAngles)
+	assert.Nil(t, saved.Frames[0].Angle)
+}
+
+func TestPredict_ValidatesByAnalysisType(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Set up an AI server response whose statuses miss a frame that has landmarks
+	fakeAnalyzer := analyzers.NewFakeAnalyzer()
+	response := analyzers.GoodPostureFixture()
+	response.StatusFrequencies = map[string]int{"Fine": 5}
+	fakeAnalyzer.Responses["https://example.com/video.mp4"] = response
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, fakeAnalyzer, mockUserUtil)
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 1}, nil)
+
+	// Call the service for a type filmed from the side, which measures the statuses on the landmarks
+	_, err := reportService.Predict(context.Background(), usermodel.User{ID: 1}, types.RequestAnalysis{VideoURL: "https://example.com/video.mp4", Type: services.AnalysisTypeSideSitting})
+
+	// Check the results: the response is rejected and nothing is saved
+	var validationErr *services.AnalysisValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, services.RejectStatusCount, validationErr.Reason)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
+
+	// Call the service for a type filmed from the front, which drops the landmarks and statuses
+	_, err = reportService.Predict(context.Background(), usermodel.User{ID: 1}, types.RequestAnalysis{VideoURL: "https://example.com/video.mp4", Type: services.AnalysisTypeFrontSitting})
+
+	// Check the results: the same response is accepted
+	assert.NoError(t, err)
+	mockReportRepository.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestFindAnalysisTypes(t *testing.T) {
+	// Create ReportService
+	reportService := services.NewReportService(new(MockReportRepository), new(MockAnalysisJobRepository), analyzers.NewFakeAnalyzer(), new(MockUserUtil))
+
+	// Call the service
+	analysisTypes := reportService.FindAnalysisTypes()
+
+	// Check the results
+	assert.Len(t, analysisTypes, 4)
+	assert.Equal(t, services.AnalysisTypeStretch, analysisTypes[3].Name)
+	assert.Equal(t, []string{services.AnalysisSourceVideo}, analysisTypes[3].Sources)
+	assert.Equal(t, 600, analysisTypes[3].MaxAnalysisTime)
+}
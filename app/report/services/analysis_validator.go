@@ -0,0 +1,171 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gdsc/baro/app/report/types"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Reasons an AI server response is rejected for, used as AnalysisValidationError.Reason
+// and as the keys of the rejection counters.
+const (
+	RejectEmptyResult       = "empty_result"
+	RejectLengthMismatch    = "length_mismatch"
+	RejectInvalidClass      = "invalid_class"
+	RejectConfidenceRange   = "confidence_out_of_range"
+	RejectRatioRange        = "ratio_out_of_range"
+	RejectUnknownStatus     = "unknown_status"
+	RejectNegativeFrequency = "negative_frequency"
+	RejectLandmarkCount     = "landmark_count"
+	RejectInvalidLandmark   = "invalid_landmark"
+	RejectStatusCount       = "status_count"
+)
+
+// KnownStatuses are the StatusFrequencies keys the AI server may report.
+var KnownStatuses = []string{"Fine", "Danger", "Serious", "Very Serious"}
+
+// ErrInvalidAnalysis is wrapped by every *AnalysisValidationError.
+var ErrInvalidAnalysis = errors.New("invalid ai server response")
+
+// AnalysisValidationError explains why an AI server response was not scored.
+type AnalysisValidationError struct {
+	Reason string
+	Detail string
+}
+
+func (e *AnalysisValidationError) Error() string {
+	return fmt.Sprintf("invalid ai server response (%s): %s", e.Reason, e.Detail)
+}
+
+func (e *AnalysisValidationError) Unwrap() error {
+	return ErrInvalidAnalysis
+}
+
+// ValidateAnalysis checks a response before it is scored: there is at least one frame, every frame has
+// a class of 0 or 1, a confidence between 0 and 100 and a finite landmark, the ratios lie between 0 and 100
+// and StatusFrequencies only counts known statuses. Landmarks may be left out altogether, as posture
+// sessions classified on the device do.
+func ValidateAnalysis(response *types.ResponseAnalysis) error {
+	frames := len(response.Result)
+	if frames == 0 {
+		return &AnalysisValidationError{Reason: RejectEmptyResult, Detail: "result is empty"}
+	}
+	if len(response.Scores) != frames {
+		return &AnalysisValidationError{Reason: RejectLengthMismatch, Detail: fmt.Sprintf("%d scores for %d results", len(response.Scores), frames)}
+	}
+	if len(response.LandmarksInfo) != 0 && len(response.LandmarksInfo) != frames {
+		return &AnalysisValidationError{Reason: RejectLandmarkCount, Detail: fmt.Sprintf("%d landmarks for %d results", len(response.LandmarksInfo), frames)}
+	}
+
+	for i, class := range response.Result {
+		if class != 0 && class != 1 {
+			return &AnalysisValidationError{Reason: RejectInvalidClass, Detail: fmt.Sprintf("result[%d] is %d", i, class)}
+		}
+	}
+	for i, score := range response.Scores {
+		if !inRange(score, 0, 100) {
+			return &AnalysisValidationError{Reason: RejectConfidenceRange, Detail: fmt.Sprintf("scores[%d] is %v", i, score)}
+		}
+	}
+	for name, ratio := range map[string]float64{"normal_ratio": response.NormalRatio, "hunched_ratio": response.HunchedRatio} {
+		if !inRange(ratio, 0, 100) {
+			return &AnalysisValidationError{Reason: RejectRatioRange, Detail: fmt.Sprintf("%s is %v", name, ratio)}
+		}
+	}
+
+	for name, count := range response.StatusFrequencies {
+		if !isKnownStatus(name) {
+			return &AnalysisValidationError{Reason: RejectUnknownStatus, Detail: fmt.Sprintf("status %q is unknown", name)}
+		}
+		if count < 0 {
+			return &AnalysisValidationError{Reason: RejectNegativeFrequency, Detail: fmt.Sprintf("status %q counts %d", name, count)}
+		}
+	}
+
+	for i, landmarks := range response.LandmarksInfo {
+		for _, value := range []float64{landmarks.Angle, landmarks.VerticalDistanceCM, landmarks.LeftShoulder.X, landmarks.LeftShoulder.Y, landmarks.LeftEar.X, landmarks.LeftEar.Y} {
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				return &AnalysisValidationError{Reason: RejectInvalidLandmark, Detail: fmt.Sprintf("landmarks_info[%d] is not finite", i)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateProfileAnalysis is ValidateAnalysis for types filmed in profile, whose neck status is measured
+// on the landmarks of every frame: a response with landmarks must count as many statuses as frames.
+func ValidateProfileAnalysis(response *types.ResponseAnalysis) error {
+	if err := ValidateAnalysis(response); err != nil {
+		return err
+	}
+	if len(response.LandmarksInfo) == 0 {
+		return nil
+	}
+
+	statuses := 0
+	for _, count := range response.StatusFrequencies {
+		statuses += count
+	}
+	if statuses != len(response.Result) {
+		return &AnalysisValidationError{Reason: RejectStatusCount, Detail: fmt.Sprintf("%d statuses for %d results", statuses, len(response.Result))}
+	}
+
+	return nil
+}
+
+func inRange(value float64, low float64, high float64) bool {
+	return !math.IsNaN(value) && value >= low && value <= high
+}
+
+func isKnownStatus(name string) bool {
+	for _, status := range KnownStatuses {
+		if status == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AnalysisRejections counts the AI server responses this process rejected, by reason.
+type AnalysisRejections struct {
+	mu      sync.Mutex
+	reasons map[string]int64
+}
+
+func NewAnalysisRejections() *AnalysisRejections {
+	return &AnalysisRejections{reasons: map[string]int64{}}
+}
+
+// Record counts err if it is an *AnalysisValidationError.
+func (rejections *AnalysisRejections) Record(err error) {
+	var validationErr *AnalysisValidationError
+	if !errors.As(err, &validationErr) {
+		return
+	}
+
+	rejections.mu.Lock()
+	defer rejections.mu.Unlock()
+
+	rejections.reasons[validationErr.Reason]++
+}
+
+func (rejections *AnalysisRejections) Snapshot() types.ResponseAnalysisRejections {
+	rejections.mu.Lock()
+	defer rejections.mu.Unlock()
+
+	response := types.ResponseAnalysisRejections{Reasons: make([]types.ResponseRejectionReason, 0, len(rejections.reasons))}
+	for reason, count := range rejections.reasons {
+		response.Total += count
+		response.Reasons = append(response.Reasons, types.ResponseRejectionReason{Reason: reason, Count: count})
+	}
+	sort.Slice(response.Reasons, func(i, j int) bool {
+		return response.Reasons[i].Reason < response.Reasons[j].Reason
+	})
+
+	return response
+}
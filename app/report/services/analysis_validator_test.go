@@ -0,0 +1,91 @@
+package services_test
+
+import (
+	"context"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidateAnalysis(t *testing.T) {
+	// Set up test data: each case breaks one rule of the good posture fixture
+	tests := []struct {
+		name   string
+		mutate func(response *types.ResponseAnalysis)
+		reason string
+	}{
+		{"empty result", func(r *types.ResponseAnalysis) { r.Result, r.Scores, r.LandmarksInfo = nil, nil, nil }, services.RejectEmptyResult},
+		{"missing score", func(r *types.ResponseAnalysis) { r.Scores = r.Scores[1:] }, services.RejectLengthMismatch},
+		{"missing landmark", func(r *types.ResponseAnalysis) { r.LandmarksInfo = r.LandmarksInfo[1:] }, services.RejectLandmarkCount},
+		{"unknown class", func(r *types.ResponseAnalysis) { r.Result[2] = 2 }, services.RejectInvalidClass},
+		{"confidence over 100", func(r *types.ResponseAnalysis) { r.Scores[0] = 100.1 }, services.RejectConfidenceRange},
+		{"confidence NaN", func(r *types.ResponseAnalysis) { r.Scores[0] = math.NaN() }, services.RejectConfidenceRange},
+		{"negative ratio", func(r *types.ResponseAnalysis) { r.NormalRatio = -1 }, services.RejectRatioRange},
+		{"unknown status", func(r *types.ResponseAnalysis) { r.StatusFrequencies["Okay"] = 1 }, services.RejectUnknownStatus},
+		{"negative frequency", func(r *types.ResponseAnalysis) { r.StatusFrequencies["Fine"] = -1 }, services.RejectNegativeFrequency},
+		{"infinite angle", func(r *types.ResponseAnalysis) { r.LandmarksInfo[1].Angle = math.Inf(1) }, services.RejectInvalidLandmark},
+	}
+
+	// The fixtures themselves are valid
+	good, bad := analyzers.GoodPostureFixture(), analyzers.BadPostureFixture()
+	assert.NoError(t, services.ValidateAnalysis(&good))
+	assert.NoError(t, services.ValidateAnalysis(&bad))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := analyzers.GoodPostureFixture()
+			test.mutate(&response)
+
+			// Call the method under test
+			err := services.ValidateAnalysis(&response)
+
+			// Check the result
+			var validationErr *services.AnalysisValidationError
+			assert.ErrorIs(t, err, services.ErrInvalidAnalysis)
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, test.reason, validationErr.Reason)
+		})
+	}
+}
+
+func TestRunJob_RejectsInvalidAnalysis(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Set up an analyzer that answers with fewer scores than results
+	fakeAnalyzer := analyzers.NewFakeAnalyzer()
+	fakeAnalyzer.Default.Scores = fakeAnalyzer.Default.Scores[:3]
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, fakeAnalyzer, mockUserUtil)
+
+	job := &models.AnalysisJob{ID: 1, UserID: 1, VideoURL: "test", Status: models.JobStatusRunning}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 0, models.JobStatusRunning, models.JobStatusSucceeded).Return(true, nil)
+	mockAnalysisJobRepository.On("Update", job).Return(nil)
+	mockAnalysisJobRepository.On("CreateDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter")).Return(nil)
+
+	// Call the service
+	err := reportService.RunJob(context.Background(), job)
+
+	// Check the results: nothing is saved, the job records why and the rejection is counted
+	assert.ErrorIs(t, err, services.ErrInvalidAnalysis)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
+	assert.Equal(t, models.JobStatusFailed, job.Status)
+	assert.Equal(t, "invalid ai server response (length_mismatch): 3 scores for 6 results", job.ErrorMessage)
+	assert.Equal(t, types.ResponseAnalysisRejections{
+		Total:   1,
+		Reasons: []types.ResponseRejectionReason{{Reason: services.RejectLengthMismatch, Count: 1}},
+	}, reportService.FindAnalysisRejections())
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gdsc/baro/app/report/analyzers"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultAnalysisWorkers = 4
+	DefaultMaxQueued       = 100
+	DefaultRetryAfter      = 30 * time.Second
+	DefaultPollInterval    = 5 * time.Second
+	DefaultStaleMargin     = 5 * time.Minute
+	DefaultSweepInterval   = time.Minute
+	DefaultCallbackTimeout = 10 * time.Minute
+	DefaultMaxJobAttempts  = 3
+)
+
+// DefaultStaleAfter is StaleAfterFor the default AI server timeout and retry policy.
+var DefaultStaleAfter = StaleAfterFor(analyzers.DefaultTimeout, analyzers.DefaultRetryPolicy())
+
+// StaleAfterFor returns how long a job may run before its worker is presumed gone: the longest an
+// analysis can take with timeout per AI server request and the retries of policy, plus DefaultStaleMargin.
+// A shorter time would requeue jobs whose worker is still analyzing them.
+func StaleAfterFor(timeout time.Duration, policy analyzers.RetryPolicy) time.Duration {
+	return policy.MaxDuration(timeout) + DefaultStaleMargin
+}
+
+// QueueFullError is returned by SubmitAnalysis when MaxQueued jobs are already waiting.
+type QueueFullError struct {
+	RetryAfter time.Duration
+}
+
+func (e *QueueFullError) Error() string {
+	return fmt.Sprintf("analysis queue is full, retry after %s", e.RetryAfter)
+}
+
+// AnalysisWorkerPool runs the analysis jobs stored by SubmitAnalysis.
+// Jobs are claimed from the database rather than handed over in memory,
+// so anything still queued when the process stops is picked up on the next start.
+// At most Workers analyses run at once; MaxQueued bounds how many may wait (0 means unbounded).
+// Every SweepInterval, jobs that have been running for longer than StaleAfter are put back in the
+// queue, so a job whose worker went away is retried whenever its process stopped, or failed once it
+// used up its attempts; submitted jobs whose callback is overdue are expired.
+type AnalysisWorkerPool struct {
+	Service       *ReportService
+	Workers       int
+	MaxQueued     int
+	RetryAfter    time.Duration
+	PollInterval  time.Duration
+	StaleAfter    time.Duration
+	SweepInterval time.Duration
+	wakeup        chan struct{}
+	inFlight      atomic.Int64
+}
+
+func NewAnalysisWorkerPool(service *ReportService, workers int) *AnalysisWorkerPool {
+	if workers < 1 {
+		workers = DefaultAnalysisWorkers
+	}
+
+	return &AnalysisWorkerPool{
+		Service:       service,
+		Workers:       workers,
+		MaxQueued:     DefaultMaxQueued,
+		RetryAfter:    DefaultRetryAfter,
+		PollInterval:  DefaultPollInterval,
+		StaleAfter:    DefaultStaleAfter,
+		SweepInterval: DefaultSweepInterval,
+		wakeup:        make(chan struct{}, workers),
+	}
+}
+
+// InFlight returns how many jobs this process is running right now.
+func (pool *AnalysisWorkerPool) InFlight() int64 {
+	return pool.inFlight.Load()
+}
+
+// Start starts the workers and the sweep that requeues stale jobs.
+// Both stop when ctx is cancelled.
+func (pool *AnalysisWorkerPool) Start(ctx context.Context) {
+	go pool.sweep(ctx)
+
+	for i := 0; i < pool.Workers; i++ {
+		go pool.run(ctx)
+	}
+}
+
+// Notify wakes up an idle worker without waiting for the next poll.
+func (pool *AnalysisWorkerPool) Notify() {
+	select {
+	case pool.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// sweep requeues stale jobs and expires overdue submitted jobs right away, picking up those left
+// by a previous process, and again every SweepInterval for jobs that go stale later.
+func (pool *AnalysisWorkerPool) sweep(ctx context.Context) {
+	ticker := time.NewTicker(pool.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		pool.requeueStale()
+		pool.expireSubmitted()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (pool *AnalysisWorkerPool) requeueStale() {
+	startedBefore := time.Now().Add(-pool.StaleAfter)
+
+	requeued, err := pool.Service.AnalysisJobRepository.RequeueStale(startedBefore, pool.Service.MaxJobAttempts)
+	if err != nil {
+		log.Printf("failed to requeue stale analysis jobs: %v", err)
+		return
+	}
+	if requeued > 0 {
+		log.Printf("requeued %d stale analysis jobs", requeued)
+		pool.Notify()
+	}
+
+	failed, err := pool.Service.FailAbandonedJobs(startedBefore)
+	if err != nil {
+		log.Printf("failed to fail abandoned analysis jobs: %v", err)
+		return
+	}
+	if failed > 0 {
+		log.Printf("failed %d analysis jobs abandoned on every attempt", failed)
+	}
+}
+
+func (pool *AnalysisWorkerPool) expireSubmitted() {
+	expired, err := pool.Service.ExpireSubmittedJobs(time.Now())
+	if err != nil {
+		log.Printf("failed to expire submitted analysis jobs: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("expired %d analysis jobs waiting for a callback", expired)
+		pool.Notify()
+	}
+}
+
+func (pool *AnalysisWorkerPool) run(ctx context.Context) {
+	ticker := time.NewTicker(pool.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for pool.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-pool.wakeup:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (pool *AnalysisWorkerPool) runNext(ctx context.Context) bool {
+	job, err := pool.Service.AnalysisJobRepository.ClaimNext()
+	if err != nil {
+		log.Printf("failed to claim analysis job: %v", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	pool.inFlight.Add(1)
+	defer pool.inFlight.Add(-1)
+
+	if err := pool.Service.RunJob(ctx, job); err != nil {
+		log.Printf("analysis job %d [user %d, %s]: %v", job.ID, job.UserID, job.VideoURL, err)
+	}
+
+	return true
+}
@@ -0,0 +1,39 @@
+package services_test
+
+import (
+	"context"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnalysisWorkerPool_SweepsStaleJobs(t *testing.T) {
+	// Mock AnalysisJobRepository with an empty queue
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockAnalysisJobRepository.On("ClaimNext").Return((*models.AnalysisJob)(nil), nil)
+
+	var sweeps atomic.Int64
+	mockAnalysisJobRepository.On("RequeueStale", mock.AnythingOfType("time.Time"), services.DefaultMaxJobAttempts).
+		Run(func(mock.Arguments) { sweeps.Add(1) }).
+		Return(int64(0), nil)
+	mockAnalysisJobRepository.On("FindStartedBefore", mock.Anything, mock.AnythingOfType("time.Time")).Return([]models.AnalysisJob{}, nil)
+
+	// Create a pool that sweeps often
+	reportService := services.NewReportService(new(MockReportRepository), mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), new(MockUserUtil))
+	pool := services.NewAnalysisWorkerPool(reportService, 1)
+	pool.SweepInterval = 10 * time.Millisecond
+
+	// Start the pool
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	// Check the results: stale jobs are swept again after startup, not just once
+	assert.Eventually(t, func() bool { return sweeps.Load() >= 3 }, time.Second, 5*time.Millisecond)
+}
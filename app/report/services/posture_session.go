@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/types"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultAlertAfter    = 10 * time.Second
+	DefaultScoreInterval = 5 * time.Second
+	DefaultBreakAfter    = 50 * time.Minute
+)
+
+// Kinds of PostureEvent.
+const (
+	PostureEventAlert = "alert"
+	PostureEventScore = "score"
+	PostureEventBreak = "break"
+)
+
+var (
+	ErrPostureSessionEmpty      = errors.New("posture session has no observations")
+	ErrPostureSessionOutOfOrder = errors.New("posture session observations must not go back in time")
+	ErrPostureSessionTooLong    = errors.New("posture session has too many observations")
+)
+
+// PostureEvent is what the server tells the app during a posture session.
+// Score and NormalRatio are set for every kind; HunchedFor and Severity only for alerts.
+type PostureEvent struct {
+	Kind        string
+	Elapsed     time.Duration
+	Score       float64
+	NormalRatio float64
+	HunchedFor  time.Duration
+	Severity    string
+}
+
+// sessionAlert is an alert raised during a session; correctedAfter stays nil until the user sits up.
+type sessionAlert struct {
+	elapsed        time.Duration
+	severity       string
+	correctedAfter *time.Duration
+}
+
+// PostureSession follows a user sitting in front of the app and decides when to alert them.
+// An alert is raised once the user has been hunched for AlertAfter without a break, and again
+// every AlertAfter while they stay hunched. The running score is reported every ScoreInterval
+// and a break is suggested every BreakAfter. Running scores use Rules; the saved report is scored
+// like any other, with the profile active when the session ends.
+//
+// Observations are either frames of landmarks, classified like a keypoint analysis, or posture
+// states the app classified itself. The report keeps the landmarks only if every observation had them.
+// Every alert is saved as an AlertEvent of the report, timed from StartedAt.
+type PostureSession struct {
+	Type          string
+	StartedAt     time.Time
+	Rules         types.ScoringRules
+	Keypoints     *analyzers.KeypointAnalyzer
+	AlertAfter    time.Duration
+	ScoreInterval time.Duration
+	BreakAfter    time.Duration
+
+	result        []int
+	scores        []float64
+	landmarks     []types.LandmarkInfo
+	statuses      map[string]int
+	withoutFrames bool
+	alerts        []sessionAlert
+	elapsed       time.Duration
+	hunchedSince  *time.Duration
+	lastScore     time.Duration
+	lastBreak     time.Duration
+}
+
+func NewPostureSession(sessionType string, rules types.ScoringRules, keypoints *analyzers.KeypointAnalyzer) *PostureSession {
+	return &PostureSession{
+		Type:          sessionType,
+		StartedAt:     time.Now(),
+		Rules:         rules,
+		Keypoints:     keypoints,
+		AlertAfter:    DefaultAlertAfter,
+		ScoreInterval: DefaultScoreInterval,
+		BreakAfter:    DefaultBreakAfter,
+		statuses:      map[string]int{},
+	}
+}
+
+// ObserveFrame classifies the landmarks of a frame taken elapsed after the session started.
+func (session *PostureSession) ObserveFrame(elapsed time.Duration, frame types.KeypointFrame) ([]PostureEvent, error) {
+	class, confidence, landmarks := session.Keypoints.AnalyzeFrame(frame)
+	if err := session.observe(elapsed, class, confidence); err != nil {
+		return nil, err
+	}
+
+	status := analyzers.NeckStatus(landmarks.Angle)
+	session.landmarks = append(session.landmarks, landmarks)
+	session.statuses[status]++
+
+	return session.events(class, alertSeverity(status)), nil
+}
+
+// ObserveState records a posture the app classified itself, with a confidence between 0 and 100.
+func (session *PostureSession) ObserveState(elapsed time.Duration, hunched bool, confidence float64) ([]PostureEvent, error) {
+	class := 1
+	if hunched {
+		class = 0
+	}
+	if err := session.observe(elapsed, class, confidence); err != nil {
+		return nil, err
+	}
+
+	session.withoutFrames = true
+
+	return session.events(class, models.AlertSeverityMedium), nil
+}
+
+func (session *PostureSession) observe(elapsed time.Duration, class int, confidence float64) error {
+	if elapsed < session.elapsed {
+		return ErrPostureSessionOutOfOrder
+	}
+	if len(session.result) >= types.MaxKeypointFrames {
+		return ErrPostureSessionTooLong
+	}
+
+	session.elapsed = elapsed
+	session.result = append(session.result, class)
+	session.scores = append(session.scores, confidence)
+
+	return nil
+}
+
+func (session *PostureSession) events(class int, severity string) []PostureEvent {
+	var events []PostureEvent
+
+	if class == 1 {
+		session.hunchedSince = nil
+		session.correct()
+	} else if session.hunchedSince == nil {
+		since := session.elapsed
+		session.hunchedSince = &since
+	} else if hunchedFor := session.elapsed - *session.hunchedSince; hunchedFor >= session.AlertAfter {
+		session.alerts = append(session.alerts, sessionAlert{elapsed: session.elapsed, severity: severity})
+		since := session.elapsed
+		session.hunchedSince = &since
+
+		event := session.event(PostureEventAlert, hunchedFor)
+		event.Severity = severity
+		events = append(events, event)
+	}
+
+	if due(&session.lastScore, session.ScoreInterval, session.elapsed) {
+		events = append(events, session.event(PostureEventScore, 0))
+	}
+	if due(&session.lastBreak, session.BreakAfter, session.elapsed) {
+		events = append(events, session.event(PostureEventBreak, 0))
+	}
+
+	return events
+}
+
+// correct marks the alerts raised since the user last sat upright as corrected now.
+func (session *PostureSession) correct() {
+	for i := len(session.alerts) - 1; i >= 0 && session.alerts[i].correctedAfter == nil; i-- {
+		correctedAfter := session.elapsed - session.alerts[i].elapsed
+		session.alerts[i].correctedAfter = &correctedAfter
+	}
+}
+
+func alertSeverity(status string) string {
+	switch status {
+	case "Very Serious":
+		return models.AlertSeverityHigh
+	case "Serious":
+		return models.AlertSeverityMedium
+	default:
+		return models.AlertSeverityLow
+	}
+}
+
+// due reports whether another interval has passed since last, and moves last to the latest interval boundary.
+func due(last *time.Duration, interval time.Duration, elapsed time.Duration) bool {
+	if interval <= 0 || elapsed < *last+interval {
+		return false
+	}
+
+	*last = elapsed - elapsed%interval
+	return true
+}
+
+func (session *PostureSession) event(kind string, hunchedFor time.Duration) PostureEvent {
+	score, _ := strconv.ParseFloat(CalculateScoresWithRules(session.Rules, session.result, session.scores), 64)
+
+	return PostureEvent{
+		Kind:        kind,
+		Elapsed:     session.elapsed,
+		Score:       score,
+		NormalRatio: session.normalRatio(),
+		HunchedFor:  hunchedFor,
+	}
+}
+
+func (session *PostureSession) normalRatio() float64 {
+	normal := 0
+	for _, class := range session.result {
+		normal += class
+	}
+
+	return float64(normal) / float64(len(session.result)) * 100
+}
+
+// Alerts returns how many alerts the session raised so far.
+func (session *PostureSession) Alerts() int {
+	return len(session.alerts)
+}
+
+// AlertEvents returns the alerts of the session as events of the report it was saved as.
+func (session *PostureSession) AlertEvents(reportID uint) []models.AlertEvent {
+	events := make([]models.AlertEvent, 0, len(session.alerts))
+	for _, alert := range session.alerts {
+		event := models.AlertEvent{
+			ReportID:   reportID,
+			Type:       models.AlertTypeHunched,
+			Severity:   alert.severity,
+			OccurredAt: session.StartedAt.Add(alert.elapsed),
+		}
+		if alert.correctedAfter != nil {
+			correctedAfterMs := alert.correctedAfter.Milliseconds()
+			event.CorrectedAfterMs = &correctedAfterMs
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// Analysis returns the session as the AI server would have reported it.
+func (session *PostureSession) Analysis() (types.ResponseAnalysis, error) {
+	if len(session.result) == 0 {
+		return types.ResponseAnalysis{}, ErrPostureSessionEmpty
+	}
+
+	response := types.ResponseAnalysis{
+		Result:            session.result,
+		Scores:            session.scores,
+		NormalRatio:       session.normalRatio(),
+		StatusFrequencies: session.statuses,
+	}
+	response.HunchedRatio = 100 - response.NormalRatio
+	if !session.withoutFrames {
+		response.LandmarksInfo = session.landmarks
+	}
+
+	return response, nil
+}
+
+// Input returns the analysis request the session replaces, with the alerts it raised.
+func (session *PostureSession) Input() types.RequestAnalysis {
+	return types.RequestAnalysis{
+		AlertCount:   len(session.alerts),
+		AnalysisTime: int(session.elapsed.Seconds()),
+		Type:         session.Type,
+	}
+}
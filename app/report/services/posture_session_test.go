@@ -0,0 +1,187 @@
+package services_test
+
+import (
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	uprightFrame = types.KeypointFrame{LeftShoulder: types.Landmark{X: 0.5, Y: 0.6}, LeftEar: types.Landmark{X: 0.5, Y: 0.3}}
+	hunchedFrame = types.KeypointFrame{LeftShoulder: types.Landmark{X: 0.5, Y: 0.6}, LeftEar: types.Landmark{X: 0.7, Y: 0.4}}
+)
+
+func newPostureSession() *services.PostureSession {
+	return services.NewPostureSession(services.AnalysisTypeSideSitting, services.DefaultScoringRules(), analyzers.NewKeypointAnalyzer())
+}
+
+func TestPostureSession_Alerts(t *testing.T) {
+	// Create a session that only raises alerts
+	session := newPostureSession()
+	session.ScoreInterval = 0
+	session.BreakAfter = 0
+
+	// Sit upright, then hunch for 21 seconds
+	events := map[int][]services.PostureEvent{}
+	for second := 0; second <= 21; second++ {
+		frame := hunchedFrame
+		if second == 0 {
+			frame = uprightFrame
+		}
+
+		// Call the method under test
+		observed, err := session.ObserveFrame(time.Duration(second)*time.Second, frame)
+		assert.NoError(t, err)
+		if len(observed) > 0 {
+			events[second] = observed
+		}
+	}
+
+	// Check the result: an alert after 10 hunched seconds, and another 10 seconds later
+	assert.Len(t, events, 2)
+	assert.Equal(t, services.PostureEventAlert, events[11][0].Kind)
+	assert.Equal(t, 10*time.Second, events[11][0].HunchedFor)
+	assert.Equal(t, services.PostureEventAlert, events[21][0].Kind)
+	assert.Equal(t, 2, session.Alerts())
+	assert.Equal(t, types.RequestAnalysis{AlertCount: 2, AnalysisTime: 21, Type: services.AnalysisTypeSideSitting}, session.Input())
+}
+
+func TestPostureSession_UprightResetsAlert(t *testing.T) {
+	// Create a session that only raises alerts
+	session := newPostureSession()
+	session.ScoreInterval = 0
+	session.BreakAfter = 0
+
+	// Hunch for 9 seconds, sit up once, then hunch for 9 seconds again
+	for second := 0; second <= 19; second++ {
+		frame := hunchedFrame
+		if second == 10 {
+			frame = uprightFrame
+		}
+
+		// Call the method under test
+		events, err := session.ObserveFrame(time.Duration(second)*time.Second, frame)
+
+		// Check the result
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	}
+	assert.Equal(t, 0, session.Alerts())
+}
+
+func TestPostureSession_ScoresAndBreaks(t *testing.T) {
+	// Create a session suggesting a break every minute
+	session := newPostureSession()
+	session.BreakAfter = time.Minute
+
+	// Sit upright for a minute, one state every 2.5 seconds
+	kinds := map[string]int{}
+	var last services.PostureEvent
+	for elapsed := time.Duration(0); elapsed <= time.Minute; elapsed += 2500 * time.Millisecond {
+		// Call the method under test
+		events, err := session.ObserveState(elapsed, false, 99.9)
+		assert.NoError(t, err)
+		for _, event := range events {
+			kinds[event.Kind]++
+			last = event
+		}
+	}
+
+	// Check the result: a score every 5 seconds and one break suggestion at the end
+	assert.Equal(t, map[string]int{services.PostureEventScore: 12, services.PostureEventBreak: 1}, kinds)
+	assert.Equal(t, services.PostureEventBreak, last.Kind)
+	assert.Equal(t, 100.0, last.Score)
+	assert.Equal(t, 100.0, last.NormalRatio)
+}
+
+func TestPostureSession_OutOfOrder(t *testing.T) {
+	// Create a session
+	session := newPostureSession()
+
+	// Call the method under test going back in time
+	_, err := session.ObserveFrame(2*time.Second, uprightFrame)
+	assert.NoError(t, err)
+	_, err = session.ObserveFrame(time.Second, uprightFrame)
+
+	// Check the result
+	assert.ErrorIs(t, err, services.ErrPostureSessionOutOfOrder)
+}
+
+func TestPostureSession_Analysis(t *testing.T) {
+	// An empty session has nothing to report
+	_, err := newPostureSession().Analysis()
+	assert.ErrorIs(t, err, services.ErrPostureSessionEmpty)
+
+	// A session of frames keeps the landmarks
+	session := newPostureSession()
+	session.ObserveFrame(0, uprightFrame)
+	session.ObserveFrame(time.Second, hunchedFrame)
+
+	response, err := session.Analysis()
+	assert.NoError(t, err)
+	assert.NoError(t, services.ValidateAnalysis(&response))
+	assert.Len(t, response.LandmarksInfo, 2)
+	assert.Equal(t, map[string]int{"Fine": 1, "Very Serious": 1}, response.StatusFrequencies)
+
+	// Once the app sends a state, the landmarks no longer cover every observation and are left out
+	session.ObserveState(2*time.Second, true, 97)
+
+	response, err = session.Analysis()
+	assert.NoError(t, err)
+	assert.NoError(t, services.ValidateAnalysis(&response))
+	assert.Equal(t, []int{1, 0, 0}, response.Result)
+	assert.Empty(t, response.LandmarksInfo)
+}
+
+func TestFinishPostureSession(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Start a session, hunch long enough for one alert and sit up 3 seconds later
+	session, err := reportService.StartPostureSession(services.AnalysisTypeSideSitting)
+	assert.NoError(t, err)
+	session.ObserveState(0, true, 99)
+	session.ObserveState(12*time.Second, true, 99)
+	session.ObserveState(15*time.Second, false, 99)
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 10, PublicID: "r-10", UserID: 1, AnalysisTime: 15}, nil)
+	mockReportRepository.On("SaveAlertEvents", mock.AnythingOfType("[]models.AlertEvent")).Return(nil)
+
+	// Call the method under test
+	response, err := reportService.FinishPostureSession(usermodel.User{ID: 1}, session)
+
+	// Check the results: the report carries the alerts the server raised
+	assert.NoError(t, err)
+	assert.Equal(t, "r-10", response.PublicID)
+
+	saved := mockReportRepository.Calls[0].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, 1, saved.AlertCount)
+	assert.Equal(t, 15, saved.AnalysisTime)
+	assert.Equal(t, services.AnalysisTypeSideSitting, saved.Type)
+	assert.Equal(t, "[0 0 1]", saved.Predict)
+
+	// Check the results: the alert is saved with the report and timed from the session start
+	alerts := mockReportRepository.Calls[1].Arguments.Get(0).([]models.AlertEvent)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, uint(10), alerts[0].ReportID)
+	assert.Equal(t, models.AlertTypeHunched, alerts[0].Type)
+	assert.Equal(t, models.AlertSeverityMedium, alerts[0].Severity)
+	assert.Equal(t, session.StartedAt.Add(12*time.Second), alerts[0].OccurredAt)
+	assert.Equal(t, int64(3000), *alerts[0].CorrectedAfterMs)
+
+	assert.Len(t, response.AlertTimeline, 1)
+	assert.Equal(t, types.ResponseAlertMetrics{Count: 1, Corrected: 1, MeanTimeToCorrectMs: 3000, AlertsPerHour: 240}, response.AlertMetrics)
+}
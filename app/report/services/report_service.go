@@ -1,146 +1,912 @@
 package services
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"gdsc/baro/app/report/analyzers"
 	"gdsc/baro/app/report/models"
 	"gdsc/baro/app/report/repositories"
 	"gdsc/baro/app/report/types"
 	usermodel "gdsc/baro/app/user/models"
 	"gdsc/baro/global/fcm"
+	"gdsc/baro/global/urlpolicy"
 	"gdsc/baro/global/utils"
-	"io"
-	"os"
+	"log"
+	"math"
+	"sort"
+	"strconv"
 	"time"
 
-	"net/http"
-	"net/url"
-
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// ErrJobNotPending is returned by HandleCallback when the job is not waiting for a result,
+// e.g. because the callback was already delivered.
+var ErrJobNotPending = errors.New("analysis job is not waiting for a callback")
+
+// ErrJobSuperseded is returned to the worker of a job that was requeued or expired while it ran;
+// the job is left to whoever holds it now.
+var ErrJobSuperseded = errors.New("analysis job was taken over by another attempt")
+
+// ErrJobAbandoned is recorded on jobs whose worker went away on every attempt.
+var ErrJobAbandoned = errors.New("analysis job was abandoned by its worker on every attempt")
+
+// ErrCallbackTimeout is recorded on jobs whose callback did not arrive within CallbackTimeout on their
+// last attempt.
+var ErrCallbackTimeout = errors.New("ai server did not post the analysis result in time")
+
+// DefaultNumericReportIDSunset is when numeric report IDs stop being accepted unless configured otherwise.
+var DefaultNumericReportIDSunset = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrReportNotFound is returned for reports that do not exist and for reports of other users alike,
+// so report IDs cannot be probed.
+var ErrReportNotFound = errors.New("report not found")
+
+// ErrAnalysisJobNotFound is returned for analysis jobs that do not exist and for jobs of other users alike.
+var ErrAnalysisJobNotFound = errors.New("analysis job not found")
+
+// VideoURLVerifier decides whether a user may submit a video URL for analysis.
+type VideoURLVerifier interface {
+	VerifyVideoURL(ctx context.Context, userID uint, videoURL string) error
+}
+
+// VideoReader turns the URL of a stored video into one the AI server can download it from.
+type VideoReader interface {
+	ReadURL(videoURL string) (string, error)
+}
+
 type ReportServiceInterface interface {
-	Analysis(c *gin.Context, input types.RequestAnalysis) (string, error)
+	Analysis(c *gin.Context, input types.RequestAnalysis) (types.ResponseAnalysisJob, error)
+	SubmitAnalysis(user *usermodel.User, input types.RequestAnalysis) (types.ResponseAnalysisJob, error)
+	FindAnalysisJob(c *gin.Context, id uint) (types.ResponseAnalysisJob, error)
+	FindAnalysisJobByUserID(userID uint, id uint) (types.ResponseAnalysisJob, error)
 	FindReportByCurrentUser(c *gin.Context) ([]types.ResponseReport, error)
-	FindById(c *gin.Context, id uint) (types.ResponseReport, error)
+	FindById(c *gin.Context, id string) (types.ResponseReport, error)
+	AnalyzeKeypoints(c *gin.Context, input types.RequestKeypointAnalysis) (types.ResponseReportV2, error)
+	AnalyzeKeypointsForUser(user usermodel.User, input types.RequestKeypointAnalysis) (types.ResponseReportV2, error)
+	StartPostureSession(sessionType string) (*PostureSession, error)
+	RecordAlertEvents(c *gin.Context, id string, input types.RequestAlertEvents) (types.ResponseReportV2, error)
+	RecordAlertEventsForUser(userID uint, id string, input types.RequestAlertEvents) (types.ResponseReportV2, error)
+	FinishPostureSession(user usermodel.User, session *PostureSession) (types.ResponseReportV2, error)
+	FindReportsV2(c *gin.Context) ([]types.ResponseReportV2, error)
+	FindReportV2(c *gin.Context, id string) (types.ResponseReportV2, error)
+	FindReportsV2ByUserID(userID uint) ([]types.ResponseReportV2, error)
+	FindReportV2ByUserID(userID uint, id string) (types.ResponseReportV2, error)
+	FindTimeseries(c *gin.Context, id string, input types.RequestTimeseries) (types.ResponseTimeseries, error)
+	FindTimeseriesByUserID(userID uint, id string, input types.RequestTimeseries) (types.ResponseTimeseries, error)
 	FindReportSummaryByMonth(c *gin.Context, yearAndMonth string) ([]types.ResponseReportSummary, error)
 	FindAll() ([]types.ResponseReport, error)
+	FindAllV2() ([]types.ResponseReportV2, error)
 	FindRankAtAgeAndGender(c *gin.Context) (types.ResponseRank, error)
+	FindDeadLetters() ([]types.ResponseDeadLetter, error)
+	RedriveDeadLetter(id uint) (types.ResponseAnalysisJob, error)
+	FindAnalyzerEndpoints() []types.ResponseAnalyzerEndpoint
+	FindAnalysisQueue() (types.ResponseAnalysisQueue, error)
+	FindAnalysisRejections() types.ResponseAnalysisRejections
+	FindAnalysisTypes() []types.ResponseAnalysisType
+	CheckAnalysisType(name string, source string, analysisTime int) error
+	HandleCallback(ctx context.Context, body []byte, timestamp string, signature string) error
 }
 
 type ReportService struct {
-	ReportRepository repositories.ReportRepositoryInterface
-	UserUtil         utils.UserUtilInterface
+	ReportRepository      repositories.ReportRepositoryInterface
+	AnalysisJobRepository repositories.AnalysisJobRepositoryInterface
+	Analyzer              analyzers.Analyzer
+	UserUtil              utils.UserUtilInterface
+	Workers               *AnalysisWorkerPool
+	// Keypoints scores landmarks sent by the app without calling the AI server.
+	Keypoints *analyzers.KeypointAnalyzer
+	// CallbackURL switches RunJob to the asynchronous protocol: jobs are submitted to the AI server,
+	// which posts the result to CallbackURL signed with CallbackSecret.
+	CallbackURL    string
+	CallbackSecret string
+	// CallbackTimeout is how long a submitted job waits for its callback. Overdue jobs are queued again
+	// until they have been attempted MaxJobAttempts times, then failed.
+	CallbackTimeout time.Duration
+	MaxJobAttempts  int
+	// VideoURLs, when set, restricts SubmitAnalysis to the video URLs it accepts.
+	VideoURLs VideoURLVerifier
+	// VideoReader, when set, signs the video URLs sent to the AI server, so stored videos stay private.
+	VideoReader VideoReader
+	// URLPolicy, when set, keeps SubmitAnalysis from sending the AI server to internal addresses.
+	URLPolicy *urlpolicy.Policy
+	// Scoring provides the active scoring profile; without it reports are scored with the default profile.
+	Scoring ScoringServiceInterface
+	// Rejections counts the AI server responses that failed ValidateAnalysis.
+	Rejections *AnalysisRejections
+	// AnalysisTypes are the types an analysis may be submitted as.
+	AnalysisTypes *AnalysisTypeRegistry
+	// NumericReportIDsUntil is when reports stop being found by their numeric ID; only public IDs
+	// work from then on.
+	NumericReportIDsUntil time.Time
 }
 
-func NewReportService(reportRepository repositories.ReportRepositoryInterface, userUtil utils.UserUtilInterface) *ReportService {
-	return &ReportService{
-		ReportRepository: reportRepository,
-		UserUtil:         userUtil,
+func NewReportService(reportRepository repositories.ReportRepositoryInterface, analysisJobRepository repositories.AnalysisJobRepositoryInterface, analyzer analyzers.Analyzer, userUtil utils.UserUtilInterface) *ReportService {
+	service := &ReportService{
+		ReportRepository:      reportRepository,
+		AnalysisJobRepository: analysisJobRepository,
+		Analyzer:              analyzer,
+		UserUtil:              userUtil,
+		Keypoints:             analyzers.NewKeypointAnalyzer(),
+		CallbackTimeout:       DefaultCallbackTimeout,
+		MaxJobAttempts:        DefaultMaxJobAttempts,
+		Rejections:            NewAnalysisRejections(),
+		AnalysisTypes:         NewAnalysisTypeRegistry(DefaultAnalysisTypes()...),
+		NumericReportIDsUntil: DefaultNumericReportIDSunset,
 	}
+	// The default aliases all map to built-in types, so they cannot fail to be added
+	_ = service.AnalysisTypes.AddAliases(DefaultAnalysisTypeAliases())
+	service.Workers = NewAnalysisWorkerPool(service, DefaultAnalysisWorkers)
+
+	return service
 }
 
-var REQUEST_URL string
+func (service *ReportService) Analysis(c *gin.Context, input types.RequestAnalysis) (types.ResponseAnalysisJob, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	return service.SubmitAnalysis(user, input)
+}
+
+// SubmitAnalysis stores the request as a queued job and wakes up the workers.
+// The analysis itself runs later, see RunJob.
+// It returns a *QueueFullError when MaxQueued jobs are already waiting. The queue depth is
+// checked before the insert, so concurrent submissions may overshoot MaxQueued slightly.
+func (service *ReportService) SubmitAnalysis(user *usermodel.User, input types.RequestAnalysis) (types.ResponseAnalysisJob, error) {
+	if err := service.CheckAnalysisType(input.Type, AnalysisSourceVideo, input.AnalysisTime); err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+	if service.URLPolicy != nil {
+		if err := service.URLPolicy.Check(context.Background(), input.VideoURL); err != nil {
+			return types.ResponseAnalysisJob{}, err
+		}
+	}
+	if service.VideoURLs != nil {
+		if err := service.VideoURLs.VerifyVideoURL(context.Background(), user.ID, input.VideoURL); err != nil {
+			return types.ResponseAnalysisJob{}, err
+		}
+	}
+
+	if service.Workers.MaxQueued > 0 {
+		queued, err := service.AnalysisJobRepository.CountByStatus(models.JobStatusQueued)
+		if err != nil {
+			return types.ResponseAnalysisJob{}, err
+		}
+		if queued >= int64(service.Workers.MaxQueued) {
+			return types.ResponseAnalysisJob{}, &QueueFullError{RetryAfter: service.Workers.RetryAfter}
+		}
+	}
+
+	job := models.AnalysisJob{
+		UserID:       user.ID,
+		VideoURL:     input.VideoURL,
+		AlertCount:   input.AlertCount,
+		AnalysisTime: input.AnalysisTime,
+		Type:         input.Type,
+		Status:       models.JobStatusQueued,
+	}
+
+	savedJob, err := service.AnalysisJobRepository.Create(&job)
+	if err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
 
-func init() {
-	REQUEST_URL = os.Getenv("AI_SERVER_API_URL")
+	service.Workers.Notify()
+
+	return toResponseAnalysisJob(savedJob), nil
 }
 
-func (service *ReportService) Analysis(c *gin.Context, input types.RequestAnalysis) (string, error) {
+func (service *ReportService) FindAnalysisJob(c *gin.Context, id uint) (types.ResponseAnalysisJob, error) {
 	user, err := service.UserUtil.FindCurrentUser(c)
 	if err != nil {
-		return "Not Found User", err
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	return service.FindAnalysisJobByUserID(user.ID, id)
+}
+
+func (service *ReportService) FindAnalysisJobByUserID(userID uint, id uint) (types.ResponseAnalysisJob, error) {
+	job, err := service.AnalysisJobRepository.FindByIdAndUserID(id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.ResponseAnalysisJob{}, ErrAnalysisJobNotFound
+	}
+	if err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	return toResponseAnalysisJob(job), nil
+}
+
+func toResponseAnalysisJob(job models.AnalysisJob) types.ResponseAnalysisJob {
+	return types.ResponseAnalysisJob{
+		ID:             job.ID,
+		Status:         job.Status,
+		ReportID:       job.ReportID,
+		ReportPublicID: job.ReportPublicID,
+		ErrorMessage:   job.ErrorMessage,
+		CreatedAt:      job.CreatedAt,
+		UpdatedAt:      job.UpdatedAt,
+	}
+}
+
+// RunJob analyzes a claimed job and records the outcome on it.
+// A job that cannot be analyzed is moved to the dead-letter table and the user is told it failed.
+func (service *ReportService) RunJob(ctx context.Context, job *models.AnalysisJob) error {
+	user, err := service.UserUtil.FindUserByID(job.UserID)
+	if err != nil {
+		return service.failJob(job, nil, err)
+	}
+
+	if service.CallbackURL != "" {
+		return service.submitJob(ctx, job, user)
+	}
+
+	response, err := service.analyze(ctx, jobInput(job))
+	if err != nil {
+		return service.failJob(job, user, err)
+	}
+
+	return service.finishJob(job, user, &response)
+}
+
+// submitJob hands the job to the AI server, which later calls HandleCallback with the result.
+// The job is marked submitted first so a fast callback never finds it still running.
+func (service *ReportService) submitJob(ctx context.Context, job *models.AnalysisJob, user *usermodel.User) error {
+	submitter, ok := service.analyzer(job.Type).(analyzers.Submitter)
+	if !ok {
+		return service.failJob(job, user, analyzers.ErrCallbackUnsupported)
+	}
+
+	if err := service.claimJob(job, models.JobStatusSubmitted); err != nil {
+		return err
+	}
+
+	videoURL, err := service.readURL(job.VideoURL)
+	if err != nil {
+		return service.failJob(job, user, err)
+	}
+
+	request := types.RequestAnalysisToAi{
+		VideoURL:    videoURL,
+		JobID:       job.ID,
+		Attempt:     job.Attempts,
+		CallbackURL: service.CallbackURL,
+	}
+	if err := submitter.Submit(ctx, request); err != nil {
+		return service.failJob(job, user, err)
 	}
 
-	u, _ := url.Parse(REQUEST_URL)
+	return nil
+}
+
+// ExpireSubmittedJobs handles the jobs whose callback is overdue at now, e.g. because the AI server
+// crashed or the callback was rejected. Each job is claimed the same way HandleCallback claims it,
+// so a callback arriving meanwhile is not handled twice. It returns how many jobs were expired.
+func (service *ReportService) ExpireSubmittedJobs(now time.Time) (int, error) {
+	jobs, err := service.AnalysisJobRepository.FindStartedBefore(models.JobStatusSubmitted, now.Add(-service.CallbackTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range jobs {
+		job := &jobs[i]
+		claimed, err := service.AnalysisJobRepository.Transition(job.ID, models.JobStatusSubmitted, models.JobStatusRunning)
+		if err != nil {
+			return expired, err
+		}
+		if !claimed {
+			continue
+		}
+		job.Status = models.JobStatusRunning
+		expired++
+
+		if err := service.expireJob(job); err != nil {
+			log.Printf("analysis job %d [user %d, %s]: %v", job.ID, job.UserID, job.VideoURL, err)
+		}
+	}
+
+	return expired, nil
+}
+
+// expireJob queues an overdue job again, or fails it once it has used up its attempts.
+func (service *ReportService) expireJob(job *models.AnalysisJob) error {
+	if job.Attempts < service.MaxJobAttempts {
+		return service.claimJob(job, models.JobStatusQueued)
+	}
+
+	user, err := service.UserUtil.FindUserByID(job.UserID)
+	if err != nil {
+		return service.failJob(job, nil, ErrCallbackTimeout)
+	}
+
+	return service.failJob(job, user, ErrCallbackTimeout)
+}
+
+// FailAbandonedJobs fails the jobs running since before startedBefore that have used up their attempts,
+// which RequeueStale leaves running. It returns how many jobs were failed.
+func (service *ReportService) FailAbandonedJobs(startedBefore time.Time) (int, error) {
+	jobs, err := service.AnalysisJobRepository.FindStartedBefore(models.JobStatusRunning, startedBefore)
+	if err != nil {
+		return 0, err
+	}
 
-	message := "Video submitted successfully"
+	failed := 0
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Attempts < service.MaxJobAttempts {
+			continue
+		}
 
-	errCh := make(chan error, 1)
-	go func() {
-		errCh <- Predict(*service, u.String(), *user, input)
-	}()
+		user, _ := service.UserUtil.FindUserByID(job.UserID)
+		err := service.failJob(job, user, ErrJobAbandoned)
+		if errors.Is(err, ErrJobSuperseded) {
+			continue
+		}
+		failed++
 
-	defer func() {
-		if err := <-errCh; err != nil {
-			fmt.Println(err, " [", user.ID, ", ", input.VideoURL, "]")
+		if err != ErrJobAbandoned {
+			log.Printf("analysis job %d [user %d, %s]: %v", job.ID, job.UserID, job.VideoURL, err)
 		}
-	}()
+	}
 
-	return message, nil
+	return failed, nil
 }
 
-func Predict(service ReportService, url string, user usermodel.User, input types.RequestAnalysis) error {
-	response, err := HandleRequest(url, input.VideoURL)
+// HandleCallback verifies a result posted by the AI server and finishes the matching submitted job
+// the same way RunJob does for a synchronous analysis.
+func (service *ReportService) HandleCallback(ctx context.Context, body []byte, timestamp string, signature string) error {
+	if err := analyzers.VerifyCallback(service.CallbackSecret, timestamp, signature, body, time.Now(), analyzers.DefaultCallbackTolerance); err != nil {
+		return err
+	}
+
+	var callback types.RequestAnalysisCallback
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return err
+	}
+
+	job, err := service.AnalysisJobRepository.FindById(callback.JobID)
 	if err != nil {
 		return err
 	}
 
-	result, scores, nomalRatio, statusFrequencies, distances, landmarksInfo := ParseAnalysis(&response)
-	score := CalculateScores(result, scores)
+	// A callback for an earlier attempt arriving after the job was requeued and submitted again
+	// must not complete the current attempt.
+	claimed, err := service.AnalysisJobRepository.TransitionAttempt(job.ID, callback.Attempt, models.JobStatusSubmitted, models.JobStatusRunning)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrJobNotPending
+	}
+	job.Status = models.JobStatusRunning
 
-	report := models.Report{
-		UserID:            user.ID,
-		AlertCount:        input.AlertCount,
-		AnalysisTime:      input.AnalysisTime,
-		Type:              input.Type,
-		Predict:           fmt.Sprintf("%v", result),
-		Score:             score,
-		NormalRatio:       nomalRatio,
-		StatusFrequencies: statusFrequencies,
-		Distances:         distances,
-		NeckAngles:        landmarksInfo,
+	// The callback has been delivered; from here on failures are recorded on the job
+	// rather than returned to the AI server.
+	if err := service.completeCallback(&job, callback); err != nil {
+		log.Printf("analysis job %d [user %d, %s]: %v", job.ID, job.UserID, job.VideoURL, err)
 	}
-	savedReport, _ := service.ReportRepository.Save(&report)
 
-	title, body, _ := GenerateMessage(savedReport.CreatedAt.String())
-	return _SendPushNotification(user, title, body)
+	return nil
 }
 
-func _SendPushNotification(user usermodel.User, title string, body string) error {
-	err := fcm.SendPushNotification(user.FcmToken, title, body)
+func (service *ReportService) completeCallback(job *models.AnalysisJob, callback types.RequestAnalysisCallback) error {
+	user, err := service.UserUtil.FindUserByID(job.UserID)
 	if err != nil {
+		return service.failJob(job, nil, err)
+	}
+
+	if callback.Error != "" || callback.Result == nil {
+		return service.failJob(job, user, fmt.Errorf("ai server reported an error: %s", callback.Error))
+	}
+
+	return service.finishJob(job, user, callback.Result)
+}
+
+func jobInput(job *models.AnalysisJob) types.RequestAnalysis {
+	return types.RequestAnalysis{
+		VideoURL:     job.VideoURL,
+		AlertCount:   job.AlertCount,
+		AnalysisTime: job.AnalysisTime,
+		Type:         job.Type,
+	}
+}
+
+// finishJob marks the job succeeded, saves its report and tells the user it is ready. The job is
+// claimed first, so nothing is saved or sent by a worker whose job was requeued or expired meanwhile.
+func (service *ReportService) finishJob(job *models.AnalysisJob, user *usermodel.User, response *types.ResponseAnalysis) error {
+	if err := service.claimJob(job, models.JobStatusSucceeded); err != nil {
 		return err
 	}
 
+	report, err := service.saveReport(*user, jobInput(job), response)
+	if err != nil {
+		return service.recordFailure(job, user, err)
+	}
+
+	if err := service.completeJob(job, report); err != nil {
+		return err
+	}
+
+	title, body, _ := GenerateMessage(report.CreatedAt.String())
+	return _SendPushNotification(*user, title, body)
+}
+
+// claimJob moves the job from its current status to status, as long as the attempt this worker runs
+// still holds it. It returns ErrJobSuperseded otherwise.
+func (service *ReportService) claimJob(job *models.AnalysisJob, status string) error {
+	claimed, err := service.AnalysisJobRepository.TransitionAttempt(job.ID, job.Attempts, job.Status, status)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return fmt.Errorf("%w: job %d, attempt %d", ErrJobSuperseded, job.ID, job.Attempts)
+	}
+
+	job.Status = status
 	return nil
 }
 
-func HandleRequest(url string, videoURL string) (types.ResponseAnalysis, error) {
-	requestBody, err := json.Marshal(map[string]string{
-		"video_url": videoURL,
-	})
+func (service *ReportService) completeJob(job *models.AnalysisJob, report models.Report) error {
+	now := time.Now()
+	job.Status = models.JobStatusSucceeded
+	job.ReportID = &report.ID
+	job.ReportPublicID = report.PublicID
+	job.ErrorMessage = ""
+	job.FinishedAt = &now
+
+	_, err := service.AnalysisJobRepository.Update(job)
+	return err
+}
+
+// failJob fails the job unless it was taken over meanwhile, moves it to the dead-letter table and
+// tells the user. It returns cause, joined with anything that went wrong on the way.
+func (service *ReportService) failJob(job *models.AnalysisJob, user *usermodel.User, cause error) error {
+	if err := service.claimJob(job, models.JobStatusFailed); err != nil {
+		return errors.Join(cause, err)
+	}
+
+	return service.recordFailure(job, user, cause)
+}
+
+// recordFailure records cause on a job this worker has claimed.
+func (service *ReportService) recordFailure(job *models.AnalysisJob, user *usermodel.User, cause error) error {
+	now := time.Now()
+	job.Status = models.JobStatusFailed
+	job.ErrorMessage = cause.Error()
+	job.FinishedAt = &now
+
+	if _, err := service.AnalysisJobRepository.Update(job); err != nil {
+		return errors.Join(cause, err)
+	}
+
+	deadLetter := models.AnalysisDeadLetter{
+		JobID:        job.ID,
+		UserID:       job.UserID,
+		VideoURL:     job.VideoURL,
+		AlertCount:   job.AlertCount,
+		AnalysisTime: job.AnalysisTime,
+		Type:         job.Type,
+		LastError:    cause.Error(),
+	}
+	if _, err := service.AnalysisJobRepository.CreateDeadLetter(&deadLetter); err != nil {
+		return errors.Join(cause, err)
+	}
+
+	if user == nil {
+		return cause
+	}
+
+	title, body := GenerateFailureMessage(job.CreatedAt)
+	if err := _SendPushNotification(*user, title, body); err != nil {
+		return errors.Join(cause, err)
+	}
+
+	return cause
+}
+
+func (service *ReportService) FindDeadLetters() ([]types.ResponseDeadLetter, error) {
+	deadLetters, err := service.AnalysisJobRepository.FindDeadLetters()
 	if err != nil {
-		return types.ResponseAnalysis{}, err
+		return nil, err
+	}
+
+	var responseDeadLetters []types.ResponseDeadLetter
+	for _, deadLetter := range deadLetters {
+		responseDeadLetters = append(responseDeadLetters, types.ResponseDeadLetter{
+			ID:           deadLetter.ID,
+			JobID:        deadLetter.JobID,
+			UserID:       deadLetter.UserID,
+			VideoURL:     deadLetter.VideoURL,
+			Type:         deadLetter.Type,
+			LastError:    deadLetter.LastError,
+			RedriveJobID: deadLetter.RedriveJobID,
+			RedrivenAt:   deadLetter.RedrivenAt,
+			CreatedAt:    deadLetter.CreatedAt,
+		})
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
+	return responseDeadLetters, nil
+}
+
+// FindAnalysisQueue returns the queue depth across all instances and the jobs running in this process.
+func (service *ReportService) FindAnalysisQueue() (types.ResponseAnalysisQueue, error) {
+	queued, err := service.AnalysisJobRepository.CountByStatus(models.JobStatusQueued)
 	if err != nil {
-		return types.ResponseAnalysis{}, err
+		return types.ResponseAnalysisQueue{}, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	response, err := client.Do(req)
+	running, err := service.AnalysisJobRepository.CountByStatus(models.JobStatusRunning)
 	if err != nil {
-		return types.ResponseAnalysis{}, err
+		return types.ResponseAnalysisQueue{}, err
 	}
-	defer response.Body.Close()
 
-	body, err := io.ReadAll(response.Body)
+	return types.ResponseAnalysisQueue{
+		Queued:    queued,
+		Running:   running,
+		InFlight:  service.Workers.InFlight(),
+		Workers:   service.Workers.Workers,
+		MaxQueued: service.Workers.MaxQueued,
+	}, nil
+}
+
+// FindAnalysisRejections returns how many AI server responses this process rejected, by reason.
+func (service *ReportService) FindAnalysisRejections() types.ResponseAnalysisRejections {
+	return service.Rejections.Snapshot()
+}
+
+// FindAnalyzerEndpoints returns the state of the AI server endpoints, including those of analysis types
+// with their own analyzer. It is empty when no analyzer talks to AI server endpoints (e.g. the fake analyzer).
+func (service *ReportService) FindAnalyzerEndpoints() []types.ResponseAnalyzerEndpoint {
+	endpoints := []types.ResponseAnalyzerEndpoint{}
+
+	seen := map[analyzers.Analyzer]bool{}
+	for _, analysisType := range service.AnalysisTypes.All() {
+		analyzer := service.analyzer(analysisType.Name)
+		if seen[analyzer] {
+			continue
+		}
+		seen[analyzer] = true
+
+		if reporter, ok := analyzer.(analyzers.StatusReporter); ok {
+			endpoints = append(endpoints, reporter.Status()...)
+		}
+	}
+	if reporter, ok := service.Analyzer.(analyzers.StatusReporter); ok && !seen[service.Analyzer] {
+		endpoints = append(endpoints, reporter.Status()...)
+	}
+
+	return endpoints
+}
+
+// FindAnalysisTypes lists the types an analysis may be submitted as.
+func (service *ReportService) FindAnalysisTypes() []types.ResponseAnalysisType {
+	analysisTypes := service.AnalysisTypes.All()
+
+	responseTypes := make([]types.ResponseAnalysisType, 0, len(analysisTypes))
+	for _, analysisType := range analysisTypes {
+		responseTypes = append(responseTypes, types.ResponseAnalysisType{
+			Name:            analysisType.Name,
+			Description:     analysisType.Description,
+			Sources:         analysisType.Sources,
+			MaxAnalysisTime: analysisType.MaxAnalysisTime,
+			ScoringProfile:  analysisType.ScoringProfile,
+		})
+	}
+
+	return responseTypes
+}
+
+// CheckAnalysisType returns an error unless an analysis of analysisTime seconds may be submitted
+// as the type called name from source.
+func (service *ReportService) CheckAnalysisType(name string, source string, analysisTime int) error {
+	analysisType, err := service.AnalysisTypes.Find(name)
 	if err != nil {
-		return types.ResponseAnalysis{}, err
+		return err
+	}
+
+	return analysisType.Check(source, analysisTime)
+}
+
+// analyzer returns the analyzer of the analysis type called name, or the default one.
+func (service *ReportService) analyzer(name string) analyzers.Analyzer {
+	if analyzer := service.AnalysisTypes.Resolve(name).Analyzer; analyzer != nil {
+		return analyzer
 	}
 
-	var data types.ResponseAnalysis
-	err = json.Unmarshal(body, &data)
+	return service.Analyzer
+}
+
+// RedriveDeadLetter queues a new job for a dead-lettered submission.
+// A dead letter can only be redriven once; if the new job fails it gets its own dead letter.
+func (service *ReportService) RedriveDeadLetter(id uint) (types.ResponseAnalysisJob, error) {
+	deadLetter, err := service.AnalysisJobRepository.FindDeadLetterById(id)
+	if err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	job := models.AnalysisJob{
+		UserID:       deadLetter.UserID,
+		VideoURL:     deadLetter.VideoURL,
+		AlertCount:   deadLetter.AlertCount,
+		AnalysisTime: deadLetter.AnalysisTime,
+		Type:         deadLetter.Type,
+		Status:       models.JobStatusQueued,
+	}
+
+	redriven, err := service.AnalysisJobRepository.RedriveDeadLetter(&deadLetter, &job)
+	if err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+	if !redriven {
+		return types.ResponseAnalysisJob{}, fmt.Errorf("dead letter %d was already redriven", id)
+	}
+
+	service.Workers.Notify()
+
+	return toResponseAnalysisJob(job), nil
+}
+
+func (service *ReportService) Predict(ctx context.Context, user usermodel.User, input types.RequestAnalysis) (models.Report, error) {
+	response, err := service.analyze(ctx, input)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	return service.saveReport(user, input, &response)
+}
+
+// analyze runs the AI server analysis of input without saving a report.
+func (service *ReportService) analyze(ctx context.Context, input types.RequestAnalysis) (types.ResponseAnalysis, error) {
+	videoURL, err := service.readURL(input.VideoURL)
 	if err != nil {
-		fmt.Println("응답 파싱 에러:", err)
 		return types.ResponseAnalysis{}, err
 	}
 
-	return data, nil
+	return service.analyzer(input.Type).Analyze(ctx, videoURL)
+}
+
+// readURL returns the URL the AI server downloads the video from; the job keeps the unsigned URL.
+func (service *ReportService) readURL(videoURL string) (string, error) {
+	if service.VideoReader == nil {
+		return videoURL, nil
+	}
+
+	return service.VideoReader.ReadURL(videoURL)
+}
+
+func (service *ReportService) AnalyzeKeypoints(c *gin.Context, input types.RequestKeypointAnalysis) (types.ResponseReportV2, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	return service.AnalyzeKeypointsForUser(*user, input)
+}
+
+// AnalyzeKeypointsForUser scores the landmarks right away and saves the report; no job is queued.
+func (service *ReportService) AnalyzeKeypointsForUser(user usermodel.User, input types.RequestKeypointAnalysis) (types.ResponseReportV2, error) {
+	if err := service.CheckAnalysisType(input.Type, AnalysisSourceKeypoints, input.AnalysisTime); err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	response := service.Keypoints.Analyze(input.Frames)
+
+	return service.saveAnalysis(user, types.RequestAnalysis{
+		AlertCount:   input.AlertCount,
+		AnalysisTime: input.AnalysisTime,
+		Type:         input.Type,
+	}, &response)
+}
+
+// StartPostureSession starts a live session scored with the scoring profile of its type.
+func (service *ReportService) StartPostureSession(sessionType string) (*PostureSession, error) {
+	analysisType, err := service.AnalysisTypes.Find(sessionType)
+	if err != nil {
+		return nil, err
+	}
+	if err := analysisType.Check(AnalysisSourceSession, 0); err != nil {
+		return nil, err
+	}
+
+	_, rules, err := service.rules(analysisType)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPostureSession(sessionType, rules, service.Keypoints), nil
+}
+
+// FinishPostureSession saves the report of a session with the alerts it raised.
+func (service *ReportService) FinishPostureSession(user usermodel.User, session *PostureSession) (types.ResponseReportV2, error) {
+	response, err := session.Analysis()
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	report, err := service.saveReport(user, session.Input(), &response)
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	report.Alerts = session.AlertEvents(report.ID)
+	if err := service.ReportRepository.SaveAlertEvents(report.Alerts); err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	responseReport := toResponseReportV2(report)
+	responseReport.Breakdown = service.explainReport(report, map[string]types.ScoringRules{})
+
+	return responseReport, nil
+}
+
+func (service *ReportService) RecordAlertEvents(c *gin.Context, id string, input types.RequestAlertEvents) (types.ResponseReportV2, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	return service.RecordAlertEventsForUser(user.ID, id, input)
+}
+
+// RecordAlertEventsForUser adds alert events to a report of the user and returns the report with its timeline.
+func (service *ReportService) RecordAlertEventsForUser(userID uint, id string, input types.RequestAlertEvents) (types.ResponseReportV2, error) {
+	report, err := service.findOwnedReport(userID, id)
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	events := make([]models.AlertEvent, 0, len(input.Events))
+	for _, event := range input.Events {
+		events = append(events, models.AlertEvent{
+			ReportID:         report.ID,
+			Type:             event.Type,
+			Severity:         event.Severity,
+			OccurredAt:       event.OccurredAt,
+			CorrectedAfterMs: event.CorrectedAfterMs,
+		})
+	}
+	if err := service.ReportRepository.SaveAlertEvents(events); err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	report.Alerts = append(report.Alerts, events...)
+	sort.SliceStable(report.Alerts, func(i, j int) bool {
+		return report.Alerts[i].OccurredAt.Before(report.Alerts[j].OccurredAt)
+	})
+
+	response := toResponseReportV2(report)
+	response.Breakdown = service.explainReport(report, map[string]types.ScoringRules{})
+
+	return response, nil
+}
+
+// saveAnalysis saves an analysis computed on this server and returns the report right away.
+func (service *ReportService) saveAnalysis(user usermodel.User, input types.RequestAnalysis, response *types.ResponseAnalysis) (types.ResponseReportV2, error) {
+	report, err := service.saveReport(user, input, response)
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	responseReport := toResponseReportV2(report)
+	responseReport.Breakdown = service.explainReport(report, map[string]types.ScoringRules{})
+
+	return responseReport, nil
+}
+
+func (service *ReportService) saveReport(user usermodel.User, input types.RequestAnalysis, response *types.ResponseAnalysis) (models.Report, error) {
+	analysisType := service.AnalysisTypes.Resolve(input.Type)
+	if err := analysisType.validate(response); err != nil {
+		service.Rejections.Record(err)
+		return models.Report{}, err
+	}
+
+	version, rules, err := service.rules(analysisType)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	result, scores, nomalRatio, statusFrequencies, distances, landmarksInfo := ParseAnalysis(response)
+	score := CalculateScoresWithRules(rules, result, scores)
+
+	report := models.Report{
+		UserID:                user.ID,
+		AlertCount:            input.AlertCount,
+		AnalysisTime:          input.AnalysisTime,
+		Type:                  analysisType.Name,
+		Predict:               fmt.Sprintf("%v", result),
+		Score:                 score,
+		NormalRatio:           nomalRatio,
+		StatusFrequencies:     statusFrequencies,
+		Distances:             distances,
+		NeckAngles:            landmarksInfo,
+		NormalRatioValue:      floatPtr(response.NormalRatio),
+		FineCount:             intPtr(response.StatusFrequencies["Fine"]),
+		DangerCount:           intPtr(response.StatusFrequencies["Danger"]),
+		SeriousCount:          intPtr(response.StatusFrequencies["Serious"]),
+		VerySeriousCount:      intPtr(response.StatusFrequencies["Very Serious"]),
+		Frames:                BuildReportFrames(response),
+		ScoringProfileVersion: version,
+	}
+	if scoreValue, err := strconv.ParseFloat(score, 64); err == nil && !math.IsNaN(scoreValue) && !math.IsInf(scoreValue, 0) {
+		report.ScoreValue = &scoreValue
+	}
+
+	return service.ReportRepository.Save(&report)
+}
+
+// rules returns the scoring profile of an analysis type, or the active one when the type has none.
+func (service *ReportService) rules(analysisType AnalysisType) (string, types.ScoringRules, error) {
+	if analysisType.ScoringProfile == "" || service.Scoring == nil {
+		return service.activeRules()
+	}
+
+	rules, err := service.Scoring.Rules(analysisType.ScoringProfile)
+	if err != nil {
+		return "", types.ScoringRules{}, err
+	}
+
+	return analysisType.ScoringProfile, rules, nil
+}
+
+// activeRules returns the active scoring profile, or the default one without a scoring service.
+func (service *ReportService) activeRules() (string, types.ScoringRules, error) {
+	if service.Scoring == nil {
+		return models.DefaultScoringProfileVersion, DefaultScoringRules(), nil
+	}
+
+	return service.Scoring.ActiveRules()
+}
+
+// BuildReportFrames turns the per-frame results of an analysis into report_frames rows.
+// Results and landmarks are matched by position; a value missing on either side is left NULL.
+func BuildReportFrames(response *types.ResponseAnalysis) []models.ReportFrame {
+	count := max(len(response.Result), len(response.LandmarksInfo))
+	frames := make([]models.ReportFrame, 0, count)
+
+	for i := 0; i < count; i++ {
+		frame := models.ReportFrame{FrameIndex: i}
+		if i < len(response.Result) {
+			frame.Class = intPtr(response.Result[i])
+		}
+		if i < len(response.Scores) {
+			frame.Confidence = floatPtr(response.Scores[i])
+		}
+		if i < len(response.LandmarksInfo) {
+			landmarks := response.LandmarksInfo[i]
+			frame.Angle = floatPtr(landmarks.Angle)
+			frame.Distance = floatPtr(landmarks.VerticalDistanceCM)
+			frame.LeftShoulderX = floatPtr(landmarks.LeftShoulder.X)
+			frame.LeftShoulderY = floatPtr(landmarks.LeftShoulder.Y)
+			frame.LeftEarX = floatPtr(landmarks.LeftEar.X)
+			frame.LeftEarY = floatPtr(landmarks.LeftEar.Y)
+		}
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func _SendPushNotification(user usermodel.User, title string, body string) error {
+	err := fcm.SendPushNotification(user.FcmToken, title, body)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func ParseAnalysis(response *types.ResponseAnalysis) ([]int, []float64, string, string, string, string) {
@@ -177,7 +943,14 @@ func ParseAnalysis(response *types.ResponseAnalysis) ([]int, []float64, string,
 	return result, scores, fmt.Sprintf("%.3f", nomalRatio), fmt.Sprintf("%v", statusFrequencies), fmt.Sprintf("%.3f", distances), fmt.Sprintf("%.3f", angles)
 }
 
+// CalculateScores scores with the rules of the default scoring profile.
 func CalculateScores(result []int, scores []float64) string {
+	return CalculateScoresWithRules(DefaultScoringRules(), result, scores)
+}
+
+// CalculateScoresWithRules weighs every frame by the first tier its confidence reaches and
+// returns the mean weight times 100.
+func CalculateScoresWithRules(rules types.ScoringRules, result []int, scores []float64) string {
 	normalCases := make([]float64, 0, len(result))
 	abnormalCases := make([]float64, 0, len(result))
 
@@ -195,37 +968,94 @@ func CalculateScores(result []int, scores []float64) string {
 	var totalScore float64
 
 	for _, score := range normalCases {
-		switch {
-		case score >= 99.8:
-			totalScore += 1.0 * caseScore
-		case score >= 99:
-			totalScore += 0.97 * caseScore
-		case score >= 96:
-			totalScore += 0.94 * caseScore
-		case score >= 93:
-			totalScore += 0.9 * caseScore
-		default:
-			totalScore += 0.86 * caseScore
-		}
+		totalScore += tierWeight(rules.Normal, rules.NormalDefault, score) * caseScore
 	}
 
 	for _, score := range abnormalCases {
-		switch {
-		case score >= 99.5:
-			totalScore += 0.18 * caseScore
-		case score >= 98:
-			totalScore += 0.23 * caseScore
-		case score >= 95:
-			totalScore += 0.3 * caseScore
-		case score >= 90:
-			totalScore += 0.35 * caseScore
-		default:
-			totalScore += 0.43 * caseScore
+		totalScore += tierWeight(rules.Abnormal, rules.AbnormalDefault, score) * caseScore
+	}
+
+	return fmt.Sprintf("%.2f", totalScore)
+}
+
+func tierWeight(tiers []types.ScoreTier, defaultWeight float64, score float64) float64 {
+	for _, tier := range tiers {
+		if score >= tier.MinConfidence {
+			return tier.Weight
 		}
+	}
 
+	return defaultWeight
+}
+
+// MaxTopDeductions is how many bands ScoreBreakdown.TopDeductions lists at most.
+const MaxTopDeductions = 3
+
+// ExplainScore splits what CalculateScoresWithRules adds up into the tiers of rules: how many frames
+// each tier weighed, the points they added and the points they cost compared to a weight of 1.
+func ExplainScore(rules types.ScoringRules, result []int, scores []float64) types.ScoreBreakdown {
+	bands := make([]types.ScoreBand, 0, len(rules.Normal)+len(rules.Abnormal)+2)
+	bands = appendBands(bands, "normal", rules.Normal, rules.NormalDefault)
+	normalBands := len(bands)
+	bands = appendBands(bands, "abnormal", rules.Abnormal, rules.AbnormalDefault)
+
+	for i, r := range result {
+		if r == 1 {
+			bands[tierIndex(rules.Normal, scores[i])].Frames++
+		} else {
+			bands[normalBands+tierIndex(rules.Abnormal, scores[i])].Frames++
+		}
 	}
 
-	return fmt.Sprintf("%.2f", totalScore)
+	breakdown := types.ScoreBreakdown{
+		TotalFrames:   len(result),
+		Bands:         bands,
+		TopDeductions: []types.ScoreBand{},
+	}
+	if len(result) == 0 {
+		return breakdown
+	}
+
+	caseScore := 100.0 / float64(len(result))
+	for i := range bands {
+		bands[i].Points = roundScore(bands[i].Weight * float64(bands[i].Frames) * caseScore)
+		bands[i].Deduction = roundScore((1 - bands[i].Weight) * float64(bands[i].Frames) * caseScore)
+		if bands[i].Deduction > 0 {
+			breakdown.TopDeductions = append(breakdown.TopDeductions, bands[i])
+		}
+	}
+
+	sort.SliceStable(breakdown.TopDeductions, func(i, j int) bool {
+		return breakdown.TopDeductions[i].Deduction > breakdown.TopDeductions[j].Deduction
+	})
+	if len(breakdown.TopDeductions) > MaxTopDeductions {
+		breakdown.TopDeductions = breakdown.TopDeductions[:MaxTopDeductions]
+	}
+
+	return breakdown
+}
+
+func appendBands(bands []types.ScoreBand, class string, tiers []types.ScoreTier, defaultWeight float64) []types.ScoreBand {
+	for _, tier := range tiers {
+		bands = append(bands, types.ScoreBand{Class: class, MinConfidence: tier.MinConfidence, Weight: tier.Weight})
+	}
+
+	return append(bands, types.ScoreBand{Class: class, Weight: defaultWeight})
+}
+
+// tierIndex returns the index of the tier tierWeight picks, len(tiers) for the default weight.
+func tierIndex(tiers []types.ScoreTier, score float64) int {
+	for i, tier := range tiers {
+		if score >= tier.MinConfidence {
+			return i
+		}
+	}
+
+	return len(tiers)
+}
+
+func roundScore(value float64) float64 {
+	return math.Round(value*100) / 100
 }
 
 func GenerateMessage(date string) (string, string, error) {
@@ -255,6 +1085,13 @@ func GenerateMessage(date string) (string, string, error) {
 	return title, body, nil
 }
 
+func GenerateFailureMessage(requestedAt time.Time) (string, string) {
+	title := "자세 분석에 실패했어요"
+	body := fmt.Sprintf("%d년 %d월 %d일에 요청한 분석을 완료하지 못했습니다. 다시 측정해 주세요.", requestedAt.Year(), requestedAt.Month(), requestedAt.Day())
+
+	return title, body
+}
+
 func (service *ReportService) FindReportByCurrentUser(c *gin.Context) ([]types.ResponseReport, error) {
 	user, err := service.UserUtil.FindCurrentUser(c)
 	if err != nil {
@@ -270,6 +1107,7 @@ func (service *ReportService) FindReportByCurrentUser(c *gin.Context) ([]types.R
 	for _, report := range reports {
 		responseReport := types.ResponseReport{
 			ID:                report.ID,
+			PublicID:          report.PublicID,
 			UserID:            report.UserID,
 			AlertCount:        report.AlertCount,
 			AnalysisTime:      report.AnalysisTime,
@@ -288,14 +1126,20 @@ func (service *ReportService) FindReportByCurrentUser(c *gin.Context) ([]types.R
 	return responseReports, nil
 }
 
-func (service *ReportService) FindById(c *gin.Context, id uint) (types.ResponseReport, error) {
-	report, err := service.ReportRepository.FindById(id)
+func (service *ReportService) FindById(c *gin.Context, id string) (types.ResponseReport, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseReport{}, err
+	}
+
+	report, err := service.findOwnedReport(user.ID, id)
 	if err != nil {
 		return types.ResponseReport{}, err
 	}
 
 	responseReport := types.ResponseReport{
 		ID:                report.ID,
+		PublicID:          report.PublicID,
 		UserID:            report.UserID,
 		AlertCount:        report.AlertCount,
 		AnalysisTime:      report.AnalysisTime,
@@ -312,6 +1156,242 @@ func (service *ReportService) FindById(c *gin.Context, id uint) (types.ResponseR
 	return responseReport, nil
 }
 
+// findOwnedReport loads a report of the user with its frames and alert events. id is the public ID of
+// the report, or until NumericReportIDsUntil its numeric ID for clients from before public IDs. Every
+// lookup of a single report goes through here, so a report is only ever shown to its owner.
+func (service *ReportService) findOwnedReport(userID uint, id string) (models.Report, error) {
+	var report models.Report
+	var err error
+	if numericID, parseErr := strconv.ParseUint(id, 10, 32); parseErr == nil {
+		if !time.Now().Before(service.NumericReportIDsUntil) {
+			return models.Report{}, ErrReportNotFound
+		}
+		report, err = service.ReportRepository.FindByIdWithFrames(uint(numericID))
+	} else {
+		report, err = service.ReportRepository.FindByPublicIDWithFrames(id)
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && report.UserID != userID {
+		return models.Report{}, ErrReportNotFound
+	}
+
+	return report, err
+}
+
+func (service *ReportService) FindReportsV2(c *gin.Context) ([]types.ResponseReportV2, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.FindReportsV2ByUserID(user.ID)
+}
+
+func (service *ReportService) FindReportV2(c *gin.Context, id string) (types.ResponseReportV2, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	return service.FindReportV2ByUserID(user.ID, id)
+}
+
+func (service *ReportService) FindReportsV2ByUserID(userID uint) ([]types.ResponseReportV2, error) {
+	reports, err := service.ReportRepository.FindByUserIDWithFrames(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := map[string]types.ScoringRules{}
+	responseReports := make([]types.ResponseReportV2, 0, len(reports))
+	for _, report := range reports {
+		response := toResponseReportV2(report)
+		response.Breakdown = service.explainReport(report, rules)
+		responseReports = append(responseReports, response)
+	}
+
+	return responseReports, nil
+}
+
+// FindReportV2ByUserID returns the report only if it belongs to the user.
+func (service *ReportService) FindReportV2ByUserID(userID uint, id string) (types.ResponseReportV2, error) {
+	report, err := service.findOwnedReport(userID, id)
+	if err != nil {
+		return types.ResponseReportV2{}, err
+	}
+
+	response := toResponseReportV2(report)
+	response.Breakdown = service.explainReport(report, map[string]types.ScoringRules{})
+
+	return response, nil
+}
+
+func (service *ReportService) FindTimeseries(c *gin.Context, id string, input types.RequestTimeseries) (types.ResponseTimeseries, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseTimeseries{}, err
+	}
+
+	return service.FindTimeseriesByUserID(user.ID, id, input)
+}
+
+// FindTimeseriesByUserID returns one metric of a report of the user over time, downsampled so charts
+// need not download every frame.
+func (service *ReportService) FindTimeseriesByUserID(userID uint, id string, input types.RequestTimeseries) (types.ResponseTimeseries, error) {
+	report, err := service.findOwnedReport(userID, id)
+	if err != nil {
+		return types.ResponseTimeseries{}, err
+	}
+
+	points := input.Points
+	if points == 0 {
+		points = types.DefaultTimeseriesPoints
+	}
+	method := input.Method
+	if method == "" {
+		method = types.TimeseriesMethodLTTB
+		if input.Metric == types.TimeseriesMetricClass {
+			method = types.TimeseriesMethodBucket
+		}
+	}
+
+	series := ReportTimeseries(report, input.Metric)
+	if method == types.TimeseriesMethodBucket {
+		series = BucketSeries(series, points)
+	} else {
+		series = LTTB(series, points)
+	}
+
+	return types.ResponseTimeseries{
+		ReportPublicID: report.PublicID,
+		Metric:         input.Metric,
+		Method:         method,
+		TotalFrames:    len(report.Frames),
+		Points:         series,
+	}, nil
+}
+
+// explainReport breaks the score of report down with the rules of the profile it was scored with.
+// It returns nil for reports whose frames carry no confidence, such as backfilled legacy reports,
+// and caches the rules it loads in rules.
+func (service *ReportService) explainReport(report models.Report, rules map[string]types.ScoringRules) *types.ScoreBreakdown {
+	result, scores, ok := frameScores(report.Frames)
+	if !ok {
+		return nil
+	}
+
+	version := report.ScoringProfileVersion
+	if version == "" {
+		version = models.DefaultScoringProfileVersion
+	}
+
+	versionRules, cached := rules[version]
+	if !cached {
+		switch {
+		case service.Scoring != nil:
+			var err error
+			if versionRules, err = service.Scoring.Rules(version); err != nil {
+				return nil
+			}
+		case version == models.DefaultScoringProfileVersion:
+			versionRules = DefaultScoringRules()
+		default:
+			return nil
+		}
+		rules[version] = versionRules
+	}
+
+	breakdown := ExplainScore(versionRules, result, scores)
+	return &breakdown
+}
+
+// toResponseReportV2 builds the typed response from the numeric columns and frames.
+// Reports that have not been backfilled yet are parsed from their legacy strings on the fly.
+func toResponseReportV2(report models.Report) types.ResponseReportV2 {
+	if report.ScoreValue == nil {
+		if parsed, err := repositories.ParseLegacyReport(report); err == nil {
+			report = parsed
+		}
+	}
+
+	response := types.ResponseReportV2{
+		PublicID:          report.PublicID,
+		UserID:            report.UserID,
+		AlertCount:        report.AlertCount,
+		AnalysisTime:      report.AnalysisTime,
+		Type:              report.Type,
+		Predict:           []int{},
+		NeckAngles:        []float64{},
+		Distances:         []float64{},
+		StatusFrequencies: map[string]int{},
+		ScoringProfile:    report.ScoringProfileVersion,
+		CreatedAt:         report.CreatedAt,
+	}
+	if report.ScoreValue != nil {
+		response.Score = *report.ScoreValue
+	}
+	if report.NormalRatioValue != nil {
+		response.NormalRatio = *report.NormalRatioValue
+	}
+
+	for name, count := range map[string]*int{
+		"Fine":         report.FineCount,
+		"Danger":       report.DangerCount,
+		"Serious":      report.SeriousCount,
+		"Very Serious": report.VerySeriousCount,
+	} {
+		if count != nil {
+			response.StatusFrequencies[name] = *count
+		}
+	}
+
+	response.AlertTimeline, response.AlertMetrics = alertTimeline(report.Alerts, report.AnalysisTime)
+
+	for _, frame := range report.Frames {
+		if frame.Class != nil {
+			response.Predict = append(response.Predict, *frame.Class)
+		}
+		if frame.Angle != nil {
+			response.NeckAngles = append(response.NeckAngles, *frame.Angle)
+		}
+		if frame.Distance != nil {
+			response.Distances = append(response.Distances, *frame.Distance)
+		}
+	}
+
+	return response
+}
+
+// alertTimeline returns the alert events in order and the metrics derived from them.
+func alertTimeline(alerts []models.AlertEvent, analysisTime int) ([]types.ResponseAlertEvent, types.ResponseAlertMetrics) {
+	timeline := make([]types.ResponseAlertEvent, 0, len(alerts))
+	metrics := types.ResponseAlertMetrics{Count: len(alerts)}
+
+	var totalCorrectedMs int64
+	for _, alert := range alerts {
+		timeline = append(timeline, types.ResponseAlertEvent{
+			ID:               alert.ID,
+			Type:             alert.Type,
+			Severity:         alert.Severity,
+			OccurredAt:       alert.OccurredAt,
+			CorrectedAfterMs: alert.CorrectedAfterMs,
+		})
+		if alert.CorrectedAfterMs != nil {
+			metrics.Corrected++
+			totalCorrectedMs += *alert.CorrectedAfterMs
+		}
+	}
+
+	if metrics.Corrected > 0 {
+		metrics.MeanTimeToCorrectMs = roundScore(float64(totalCorrectedMs) / float64(metrics.Corrected))
+	}
+	if analysisTime > 0 {
+		metrics.AlertsPerHour = roundScore(float64(len(alerts)) / (float64(analysisTime) / 3600))
+	}
+
+	return timeline, metrics
+}
+
 func (service *ReportService) FindReportSummaryByMonth(c *gin.Context, yearAndMonth string) ([]types.ResponseReportSummary, error) {
 	user, err := service.UserUtil.FindCurrentUser(c)
 	if err != nil {
@@ -324,6 +1404,7 @@ func (service *ReportService) FindReportSummaryByMonth(c *gin.Context, yearAndMo
 	for _, report := range reports {
 		responseReport := types.ResponseReportSummary{
 			ID:        report.ID,
+			PublicID:  report.PublicID,
 			CreatedAt: report.CreatedAt,
 		}
 		responseReports = append(responseReports, responseReport)
@@ -339,6 +1420,7 @@ func (service *ReportService) FindAll() ([]types.ResponseReport, error) {
 	for _, report := range reports {
 		responseReport := types.ResponseReport{
 			ID:                report.ID,
+			PublicID:          report.PublicID,
 			UserID:            report.UserID,
 			AlertCount:        report.AlertCount,
 			AnalysisTime:      report.AnalysisTime,
@@ -357,6 +1439,21 @@ func (service *ReportService) FindAll() ([]types.ResponseReport, error) {
 	return responseReports, nil
 }
 
+// FindAllV2 returns the reports of every user, without their frames.
+func (service *ReportService) FindAllV2() ([]types.ResponseReportV2, error) {
+	reports, err := service.ReportRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responseReports := make([]types.ResponseReportV2, 0, len(reports))
+	for _, report := range reports {
+		responseReports = append(responseReports, toResponseReportV2(report))
+	}
+
+	return responseReports, nil
+}
+
 func (service *ReportService) FindRankAtAgeAndGender(c *gin.Context) (types.ResponseRank, error) {
 	user, err := service.UserUtil.FindCurrentUser(c)
 	if err != nil {
@@ -1,19 +1,24 @@
 package services_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"gdsc/baro/app/report/analyzers"
 	"gdsc/baro/app/report/models"
 	"gdsc/baro/app/report/services"
 	"gdsc/baro/app/report/types"
 	usermodel "gdsc/baro/app/user/models"
-	"os"
+	"gdsc/baro/global/urlpolicy"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 type MockReportRepository struct {
@@ -25,6 +30,11 @@ func (m *MockReportRepository) Save(report *models.Report) (models.Report, error
 	return *args.Get(0).(*models.Report), args.Error(1)
 }
 
+func (m *MockReportRepository) SaveAlertEvents(events []models.AlertEvent) error {
+	args := m.Called(events)
+	return args.Error(0)
+}
+
 func (m *MockReportRepository) FindByUserID(userID uint) ([]models.Report, error) {
 	args := m.Called(userID)
 	return args.Get(0).([]models.Report), args.Error(1)
@@ -38,6 +48,31 @@ func (m *MockReportRepository) FindById(id uint) (models.Report, error) {
 	return models.Report{}, args.Error(1)
 }
 
+func (m *MockReportRepository) FindByUserIDWithFrames(userID uint) ([]models.Report, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) FindByIdWithFrames(id uint) (models.Report, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) FindByPublicIDWithFrames(publicID string) (models.Report, error) {
+	args := m.Called(publicID)
+	return args.Get(0).(models.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) FindBatchWithFrames(afterID uint, limit int) ([]models.Report, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]models.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) UpdateScore(report *models.Report) error {
+	args := m.Called(report)
+	return args.Error(0)
+}
+
 func (m *MockReportRepository) FindByYearAndMonth(userID uint, month string) ([]models.Report, error) {
 	args := m.Called(userID, month)
 	if tmp := args.Get(0); tmp != nil {
@@ -51,74 +86,766 @@ func (m *MockReportRepository) FindAll() ([]models.Report, error) {
 	return args.Get(0).([]models.Report), args.Error(1)
 }
 
-func (m *MockReportRepository) FindRankAtAgeAndGender(user *usermodel.User, start, end time.Time) (types.ResponseRank, error) {
-	args := m.Called(user, start, end)
-	return args.Get(0).(types.ResponseRank), args.Error(1)
+func (m *MockReportRepository) FindRankAtAgeAndGender(user *usermodel.User, start, end time.Time) (types.ResponseRank, error) {
+	args := m.Called(user, start, end)
+	return args.Get(0).(types.ResponseRank), args.Error(1)
+}
+
+type MockAnalysisJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnalysisJobRepository) Create(job *models.AnalysisJob) (models.AnalysisJob, error) {
+	args := m.Called(job)
+	return *args.Get(0).(*models.AnalysisJob), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) FindById(id uint) (models.AnalysisJob, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.AnalysisJob), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) FindByIdAndUserID(id uint, userID uint) (models.AnalysisJob, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(models.AnalysisJob), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) ClaimNext() (*models.AnalysisJob, error) {
+	args := m.Called()
+	return args.Get(0).(*models.AnalysisJob), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) Update(job *models.AnalysisJob) (models.AnalysisJob, error) {
+	args := m.Called(job)
+	return *job, args.Error(0)
+}
+
+func (m *MockAnalysisJobRepository) RequeueStale(startedBefore time.Time, maxAttempts int) (int64, error) {
+	args := m.Called(startedBefore, maxAttempts)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) FindStartedBefore(status string, startedBefore time.Time) ([]models.AnalysisJob, error) {
+	args := m.Called(status, startedBefore)
+	return args.Get(0).([]models.AnalysisJob), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) CountByStatus(status string) (int64, error) {
+	args := m.Called(status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) Transition(id uint, from string, to string) (bool, error) {
+	args := m.Called(id, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) TransitionAttempt(id uint, attempt int, from string, to string) (bool, error) {
+	args := m.Called(id, attempt, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) CreateDeadLetter(deadLetter *models.AnalysisDeadLetter) (models.AnalysisDeadLetter, error) {
+	args := m.Called(deadLetter)
+	return *deadLetter, args.Error(0)
+}
+
+func (m *MockAnalysisJobRepository) FindDeadLetters() ([]models.AnalysisDeadLetter, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AnalysisDeadLetter), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) FindDeadLetterById(id uint) (models.AnalysisDeadLetter, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.AnalysisDeadLetter), args.Error(1)
+}
+
+func (m *MockAnalysisJobRepository) RedriveDeadLetter(deadLetter *models.AnalysisDeadLetter, job *models.AnalysisJob) (bool, error) {
+	args := m.Called(deadLetter, job)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockUserUtil struct {
+	mock.Mock
+}
+
+func (m *MockUserUtil) FindCurrentUser(c *gin.Context) (*usermodel.User, error) {
+	args := m.Called(c)
+	return args.Get(0).(*usermodel.User), args.Error(1)
+}
+
+func (m *MockUserUtil) FindUserByID(id uint) (*usermodel.User, error) {
+	args := m.Called(id)
+	return args.Get(0).(*usermodel.User), args.Error(1)
+}
+
+func TestAnalysis(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up sample user for the test
+	user := usermodel.User{
+		ID:       1,
+		Name:     "test",
+		Nickname: "test",
+		Email:    "test@gmail.com",
+		Age:      20,
+		Gender:   "male",
+	}
+
+	// Set up expectations for the mock repository and util
+	request := types.RequestAnalysis{
+		VideoURL:     "test",
+		AlertCount:   10,
+		AnalysisTime: 1800,
+		Type:         services.AnalysisTypeSideSitting,
+	}
+
+	// Set up the job the repository returns after saving
+	savedJob := &models.AnalysisJob{
+		ID:           1,
+		UserID:       user.ID,
+		VideoURL:     request.VideoURL,
+		AlertCount:   request.AlertCount,
+		AnalysisTime: request.AnalysisTime,
+		Type:         request.Type,
+		Status:       models.JobStatusQueued,
+	}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&user, nil)
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusQueued).Return(int64(0), nil)
+	mockAnalysisJobRepository.On("Create", mock.AnythingOfType("*models.AnalysisJob")).Return(savedJob, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	responseJob, err := reportService.Analysis(c, request)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockReportRepository.AssertExpectations(t)
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockUserUtil.AssertExpectations(t)
+
+	// Check the results
+	assert.Equal(t, savedJob.ID, responseJob.ID)
+	assert.Equal(t, models.JobStatusQueued, responseJob.Status)
+	assert.Nil(t, responseJob.ReportID)
+}
+
+func TestAnalysis_QueueFull(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService with a queue of at most 2 jobs
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.Workers.MaxQueued = 2
+	reportService.Workers.RetryAfter = 45 * time.Second
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusQueued).Return(int64(2), nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	request := types.RequestAnalysis{
+		VideoURL:     "test",
+		AlertCount:   10,
+		AnalysisTime: 1800,
+		Type:         services.AnalysisTypeSideSitting,
+	}
+
+	// Call the service
+	_, err := reportService.Analysis(c, request)
+
+	// Assert that the expectations were met; no job is created
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockAnalysisJobRepository.AssertNotCalled(t, "Create", mock.Anything)
+
+	// Check the results
+	var queueFullErr *services.QueueFullError
+	assert.ErrorAs(t, err, &queueFullErr)
+	assert.Equal(t, 45*time.Second, queueFullErr.RetryAfter)
+}
+
+func TestAnalysis_URLNotAllowed(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService that only accepts https URLs
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.URLPolicy = urlpolicy.NewPolicy([]string{"https"}, nil)
+
+	// Set up expectations for the mock util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	request := types.RequestAnalysis{
+		VideoURL: "http://169.254.169.254/latest/meta-data",
+		Type:     services.AnalysisTypeSideSitting,
+	}
+
+	// Call the service
+	_, err := reportService.Analysis(c, request)
+
+	// Check the results: nothing reaches the queue
+	assert.ErrorIs(t, err, urlpolicy.ErrNotAllowed)
+	mockAnalysisJobRepository.AssertNotCalled(t, "CountByStatus", mock.Anything)
+	mockAnalysisJobRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestFindAnalysisQueue(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusQueued).Return(int64(12), nil)
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusRunning).Return(int64(3), nil)
+
+	// Call the service
+	queue, err := reportService.FindAnalysisQueue()
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results
+	assert.Equal(t, int64(12), queue.Queued)
+	assert.Equal(t, int64(3), queue.Running)
+	assert.Equal(t, int64(0), queue.InFlight)
+	assert.Equal(t, services.DefaultAnalysisWorkers, queue.Workers)
+	assert.Equal(t, services.DefaultMaxQueued, queue.MaxQueued)
+}
+
+func TestAnalysis_CreateJobError(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusQueued).Return(int64(0), nil)
+	mockAnalysisJobRepository.On("Create", mock.AnythingOfType("*models.AnalysisJob")).Return(&models.AnalysisJob{}, errors.New("database error"))
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	request := types.RequestAnalysis{
+		VideoURL:     "test",
+		AlertCount:   10,
+		AnalysisTime: 1800,
+		Type:         services.AnalysisTypeSideSitting,
+	}
+
+	// Call the service
+	_, err := reportService.Analysis(c, request)
+	assert.Error(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockUserUtil.AssertExpectations(t)
+}
+
+func TestFindAnalysisJob(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up sample job for the test
+	reportID := uint(7)
+	job := models.AnalysisJob{
+		ID:       3,
+		UserID:   1,
+		Status:   models.JobStatusSucceeded,
+		ReportID: &reportID,
+	}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("FindByIdAndUserID", uint(3), uint(1)).Return(job, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	responseJob, err := reportService.FindAnalysisJob(c, 3)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockUserUtil.AssertExpectations(t)
+
+	// Check the results
+	assert.Equal(t, job.ID, responseJob.ID)
+	assert.Equal(t, job.Status, responseJob.Status)
+	assert.Equal(t, &reportID, responseJob.ReportID)
+}
+
+func TestFindAnalysisJob_NotFound(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("FindByIdAndUserID", uint(3), uint(1)).Return(models.AnalysisJob{}, gorm.ErrRecordNotFound)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service: jobs that do not exist and jobs of other users are not found alike
+	_, err := reportService.FindAnalysisJob(c, 3)
+	assert.ErrorIs(t, err, services.ErrAnalysisJobNotFound)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockUserUtil.AssertExpectations(t)
+}
+
+func TestRunJob_PredictError(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Set up an analyzer that fails
+	fakeAnalyzer := analyzers.NewFakeAnalyzer()
+	fakeAnalyzer.Err = errors.New("ai server unavailable")
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, fakeAnalyzer, mockUserUtil)
+
+	// Set up a running job whose video cannot be analyzed
+	job := &models.AnalysisJob{
+		ID:       1,
+		UserID:   1,
+		VideoURL: "test",
+		Status:   models.JobStatusRunning,
+	}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 0, models.JobStatusRunning, models.JobStatusFailed).Return(true, nil)
+	mockAnalysisJobRepository.On("Update", job).Return(nil)
+	mockAnalysisJobRepository.On("CreateDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter")).Return(nil)
+
+	// Call the service (the failure push itself fails without Firebase credentials)
+	err := reportService.RunJob(context.Background(), job)
+	assert.ErrorContains(t, err, "ai server unavailable")
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockUserUtil.AssertExpectations(t)
+
+	// Check the results
+	assert.Equal(t, models.JobStatusFailed, job.Status)
+	assert.Equal(t, "ai server unavailable", job.ErrorMessage)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Nil(t, job.ReportID)
+
+	// Check the dead letter
+	deadLetter := mockAnalysisJobRepository.Calls[2].Arguments.Get(0).(*models.AnalysisDeadLetter)
+	assert.Equal(t, job.ID, deadLetter.JobID)
+	assert.Equal(t, job.VideoURL, deadLetter.VideoURL)
+	assert.Equal(t, "ai server unavailable", deadLetter.LastError)
+}
+
+func TestRunJob_NoUser(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up a running job owned by a deleted user
+	job := &models.AnalysisJob{
+		ID:     1,
+		UserID: 1,
+		Status: models.JobStatusRunning,
+	}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindUserByID", uint(1)).Return((*usermodel.User)(nil), errors.New("not found user"))
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 0, models.JobStatusRunning, models.JobStatusFailed).Return(true, nil)
+	mockAnalysisJobRepository.On("Update", job).Return(nil)
+	mockAnalysisJobRepository.On("CreateDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter")).Return(nil)
+
+	// Call the service
+	err := reportService.RunJob(context.Background(), job)
+	assert.EqualError(t, err, "not found user")
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockUserUtil.AssertExpectations(t)
+
+	// Check the results
+	assert.Equal(t, models.JobStatusFailed, job.Status)
+	assert.Equal(t, "not found user", job.ErrorMessage)
+}
+
+func TestRunJob_Superseded(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up a job on its first attempt that was requeued and claimed again while it ran
+	job := &models.AnalysisJob{ID: 1, UserID: 1, VideoURL: "test", Status: models.JobStatusRunning, Attempts: 1}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusRunning, models.JobStatusSucceeded).Return(false, nil)
+
+	// Call the service
+	err := reportService.RunJob(context.Background(), job)
+
+	// Check the results: the later attempt owns the job, so nothing is saved or recorded
+	assert.ErrorIs(t, err, services.ErrJobSuperseded)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
+	mockAnalysisJobRepository.AssertNotCalled(t, "Update", mock.Anything)
+	assert.Equal(t, models.JobStatusRunning, job.Status)
+}
+
+func TestRunJob_SubmitsWithCallback(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService in callback mode
+	fakeAnalyzer := analyzers.NewFakeAnalyzer()
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, fakeAnalyzer, mockUserUtil)
+	reportService.CallbackURL = "https://api.example.com/internal/analysis/callback"
+
+	// Set up a running job
+	job := &models.AnalysisJob{ID: 4, UserID: 1, VideoURL: "https://example.com/video.mp4", Status: models.JobStatusRunning, Attempts: 1}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusRunning, models.JobStatusSubmitted).Return(true, nil)
+
+	// Call the service
+	err := reportService.RunJob(context.Background(), job)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met; nothing is analyzed synchronously
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
+	assert.Empty(t, fakeAnalyzer.Calls())
+
+	// Check the results
+	assert.Equal(t, models.JobStatusSubmitted, job.Status)
+	assert.Equal(t, []types.RequestAnalysisToAi{{
+		VideoURL:    job.VideoURL,
+		JobID:       job.ID,
+		Attempt:     1,
+		CallbackURL: reportService.CallbackURL,
+	}}, fakeAnalyzer.Submitted())
+}
+
+// signedCallback returns a callback body with its timestamp and signature.
+func signedCallback(t *testing.T, secret string, callback types.RequestAnalysisCallback) ([]byte, string, string) {
+	body, err := json.Marshal(callback)
+	assert.NoError(t, err)
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return body, timestamp, analyzers.SignCallback(secret, timestamp, body)
+}
+
+func TestHandleCallback(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService in callback mode
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.CallbackURL = "https://api.example.com/internal/analysis/callback"
+	reportService.CallbackSecret = "secret"
+
+	// Set up the submitted job and its result
+	job := models.AnalysisJob{ID: 4, UserID: 1, VideoURL: "https://example.com/video.mp4", AlertCount: 2, AnalysisTime: 600, Type: services.AnalysisTypeSideSitting, Status: models.JobStatusSubmitted, Attempts: 1}
+	result := analyzers.BadPostureFixture()
+	body, timestamp, signature := signedCallback(t, "secret", types.RequestAnalysisCallback{JobID: job.ID, Attempt: job.Attempts, Result: &result})
+
+	// Set up expectations for the mock repository and util
+	mockAnalysisJobRepository.On("FindById", job.ID).Return(job, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusSubmitted, models.JobStatusRunning).Return(true, nil)
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusRunning, models.JobStatusSucceeded).Return(true, nil)
+	mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 10, UserID: 1, Score: "29.33"}, nil)
+	mockAnalysisJobRepository.On("Update", mock.AnythingOfType("*models.AnalysisJob")).Return(nil)
+
+	// Call the service (the result push fails without Firebase credentials and is only logged)
+	err := reportService.HandleCallback(context.Background(), body, timestamp, signature)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockReportRepository.AssertExpectations(t)
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results
+	saved := mockReportRepository.Calls[0].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, "29.33", saved.Score)
+	assert.Equal(t, 2, saved.AlertCount)
+	assert.Equal(t, services.AnalysisTypeSideSitting, saved.Type)
+
+	updated := mockAnalysisJobRepository.Calls[3].Arguments.Get(0).(*models.AnalysisJob)
+	assert.Equal(t, models.JobStatusSucceeded, updated.Status)
+	assert.Equal(t, uint(10), *updated.ReportID)
+}
+
+func TestHandleCallback_ReportedError(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService in callback mode
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.CallbackSecret = "secret"
+
+	// Set up the submitted job and a failed result
+	job := models.AnalysisJob{ID: 4, UserID: 1, VideoURL: "https://example.com/video.mp4", Status: models.JobStatusSubmitted, Attempts: 1}
+	body, timestamp, signature := signedCallback(t, "secret", types.RequestAnalysisCallback{JobID: job.ID, Attempt: job.Attempts, Error: "no person detected"})
+
+	// Set up expectations for the mock repository and util
+	mockAnalysisJobRepository.On("FindById", job.ID).Return(job, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusSubmitted, models.JobStatusRunning).Return(true, nil)
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusRunning, models.JobStatusFailed).Return(true, nil)
+	mockAnalysisJobRepository.On("Update", mock.AnythingOfType("*models.AnalysisJob")).Return(nil)
+	mockAnalysisJobRepository.On("CreateDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter")).Return(nil)
+
+	// Call the service; the callback itself was delivered successfully
+	err := reportService.HandleCallback(context.Background(), body, timestamp, signature)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
+
+	// Check the results
+	deadLetter := mockAnalysisJobRepository.Calls[4].Arguments.Get(0).(*models.AnalysisDeadLetter)
+	assert.Equal(t, "ai server reported an error: no person detected", deadLetter.LastError)
+}
+
+func TestHandleCallback_InvalidSignature(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService in callback mode
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.CallbackSecret = "secret"
+
+	// Sign the callback with another secret
+	result := analyzers.GoodPostureFixture()
+	body, timestamp, signature := signedCallback(t, "guessed", types.RequestAnalysisCallback{JobID: 4, Result: &result})
+
+	// Call the service
+	err := reportService.HandleCallback(context.Background(), body, timestamp, signature)
+
+	// Check the results; the job is never looked up
+	assert.ErrorIs(t, err, analyzers.ErrInvalidSignature)
+	mockAnalysisJobRepository.AssertNotCalled(t, "FindById", mock.Anything)
+}
+
+func TestHandleCallback_AlreadyDelivered(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService in callback mode
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.CallbackSecret = "secret"
+
+	// Set up a job that was already completed by an earlier callback
+	job := models.AnalysisJob{ID: 4, UserID: 1, Status: models.JobStatusSucceeded, Attempts: 1}
+	result := analyzers.GoodPostureFixture()
+	body, timestamp, signature := signedCallback(t, "secret", types.RequestAnalysisCallback{JobID: job.ID, Attempt: job.Attempts, Result: &result})
+
+	// Set up expectations for the mock repository
+	mockAnalysisJobRepository.On("FindById", job.ID).Return(job, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusSubmitted, models.JobStatusRunning).Return(false, nil)
+
+	// Call the service
+	err := reportService.HandleCallback(context.Background(), body, timestamp, signature)
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrJobNotPending)
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
 }
 
-type MockUserUtil struct {
-	mock.Mock
-}
+func TestHandleCallback_SupersededAttempt(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
 
-func (m *MockUserUtil) FindCurrentUser(c *gin.Context) (*usermodel.User, error) {
-	args := m.Called(c)
-	return args.Get(0).(*usermodel.User), args.Error(1)
+	// Create ReportService in callback mode
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.CallbackSecret = "secret"
+
+	// Set up a job that expired and was submitted again, and a late callback for its first attempt
+	job := models.AnalysisJob{ID: 4, UserID: 1, Status: models.JobStatusSubmitted, Attempts: 2}
+	result := analyzers.GoodPostureFixture()
+	body, timestamp, signature := signedCallback(t, "secret", types.RequestAnalysisCallback{JobID: job.ID, Attempt: 1, Result: &result})
+
+	// Set up expectations for the mock repository
+	mockAnalysisJobRepository.On("FindById", job.ID).Return(job, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 1, models.JobStatusSubmitted, models.JobStatusRunning).Return(false, nil)
+
+	// Call the service
+	err := reportService.HandleCallback(context.Background(), body, timestamp, signature)
+
+	// Check the results: the current attempt still waits for its own callback
+	assert.ErrorIs(t, err, services.ErrJobNotPending)
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockReportRepository.AssertNotCalled(t, "Save", mock.Anything)
 }
 
-func TestAnalysis(t *testing.T) {
-	// Mock ReportRepository, UserUtil
+func TestExpireSubmittedJobs(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
-	// Set up environment variables
-	os.Setenv("AI_SERVER_API_URL", "http://localhost:5000/predict")
+	// Create ReportService in callback mode
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.CallbackURL = "https://api.example.com/internal/analysis/callback"
 
-	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
-
-	// Set up sample user for the test
-	user := usermodel.User{
-		ID:       1,
-		Name:     "test",
-		Nickname: "test",
-		Email:    "test@gmail.com",
-		Age:      20,
-		Gender:   "male",
-	}
+	// Set up overdue jobs: one can be retried, one has used up its attempts,
+	// and one got its callback while the sweep ran
+	now := time.Now()
+	retried := models.AnalysisJob{ID: 4, UserID: 1, Attempts: 1, Status: models.JobStatusSubmitted}
+	exhausted := models.AnalysisJob{ID: 5, UserID: 1, Attempts: reportService.MaxJobAttempts, Status: models.JobStatusSubmitted}
+	delivered := models.AnalysisJob{ID: 6, UserID: 1, Attempts: 1, Status: models.JobStatusSubmitted}
 
 	// Set up expectations for the mock repository and util
-	request := types.RequestAnalysis{
-		VideoURL:     "test",
-		AlertCount:   10,
-		AnalysisTime: 1800,
-		Type:         "Study",
+	mockAnalysisJobRepository.On("FindStartedBefore", models.JobStatusSubmitted, now.Add(-reportService.CallbackTimeout)).Return([]models.AnalysisJob{retried, exhausted, delivered}, nil)
+	mockAnalysisJobRepository.On("Transition", uint(4), models.JobStatusSubmitted, models.JobStatusRunning).Return(true, nil)
+	mockAnalysisJobRepository.On("Transition", uint(5), models.JobStatusSubmitted, models.JobStatusRunning).Return(true, nil)
+	mockAnalysisJobRepository.On("Transition", uint(6), models.JobStatusSubmitted, models.JobStatusRunning).Return(false, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", uint(4), 1, models.JobStatusRunning, models.JobStatusQueued).Return(true, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", uint(5), reportService.MaxJobAttempts, models.JobStatusRunning, models.JobStatusFailed).Return(true, nil)
+	mockAnalysisJobRepository.On("Update", mock.AnythingOfType("*models.AnalysisJob")).Return(nil)
+	mockAnalysisJobRepository.On("CreateDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter")).Return(nil)
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+
+	// Call the service (the failure push fails without Firebase credentials and is only logged)
+	expired, err := reportService.ExpireSubmittedJobs(now)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results: the first job is queued again, the second is dead-lettered
+	assert.Equal(t, 2, expired)
+
+	var updated []*models.AnalysisJob
+	var deadLetters []*models.AnalysisDeadLetter
+	for _, call := range mockAnalysisJobRepository.Calls {
+		switch call.Method {
+		case "Update":
+			updated = append(updated, call.Arguments.Get(0).(*models.AnalysisJob))
+		case "CreateDeadLetter":
+			deadLetters = append(deadLetters, call.Arguments.Get(0).(*models.AnalysisDeadLetter))
+		}
 	}
+	assert.Len(t, updated, 1)
+	assert.Equal(t, uint(5), updated[0].ID)
+	assert.Equal(t, models.JobStatusFailed, updated[0].Status)
 
-	// Set up expectations for the mock repository and util
-	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&user, nil)
+	assert.Len(t, deadLetters, 1)
+	assert.Equal(t, uint(5), deadLetters[0].JobID)
+	assert.Equal(t, services.ErrCallbackTimeout.Error(), deadLetters[0].LastError)
+}
 
-	// Create a test context
-	c, _ := gin.CreateTestContext(nil)
+func TestFailAbandonedJobs(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up stale jobs: one with attempts left, one out of attempts and one finished by its worker meanwhile
+	startedBefore := time.Now()
+	retried := models.AnalysisJob{ID: 4, UserID: 1, Status: models.JobStatusRunning, Attempts: 1}
+	exhausted := models.AnalysisJob{ID: 5, UserID: 1, Status: models.JobStatusRunning, Attempts: reportService.MaxJobAttempts}
+	finished := models.AnalysisJob{ID: 6, UserID: 1, Status: models.JobStatusRunning, Attempts: reportService.MaxJobAttempts}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindUserByID", uint(1)).Return((*usermodel.User)(nil), errors.New("not found user"))
+	mockAnalysisJobRepository.On("FindStartedBefore", models.JobStatusRunning, startedBefore).Return([]models.AnalysisJob{retried, exhausted, finished}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", uint(5), reportService.MaxJobAttempts, models.JobStatusRunning, models.JobStatusFailed).Return(true, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", uint(6), reportService.MaxJobAttempts, models.JobStatusRunning, models.JobStatusFailed).Return(false, nil)
+	mockAnalysisJobRepository.On("Update", mock.AnythingOfType("*models.AnalysisJob")).Return(nil)
+	mockAnalysisJobRepository.On("CreateDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter")).Return(nil)
 
 	// Call the service
-	responseMessage, err := reportService.Analysis(c, request)
-	assert.NoError(t, err)
+	failed, err := reportService.FailAbandonedJobs(startedBefore)
 
-	// Assert that the expectations were met
-	mockReportRepository.AssertExpectations(t)
-	mockUserUtil.AssertExpectations(t)
+	// Check the results: only the job out of attempts is failed and moved to the dead-letter table
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failed)
+	mockAnalysisJobRepository.AssertNumberOfCalls(t, "Update", 1)
+	mockAnalysisJobRepository.AssertNumberOfCalls(t, "CreateDeadLetter", 1)
 
-	// Check the results
-	assert.Equal(t, "Video submitted successfully", responseMessage)
+	deadLetter := mockAnalysisJobRepository.Calls[3].Arguments.Get(0).(*models.AnalysisDeadLetter)
+	assert.Equal(t, uint(5), deadLetter.JobID)
+	assert.Equal(t, services.ErrJobAbandoned.Error(), deadLetter.LastError)
 }
 
 func TestAnalysis_NoUser(t *testing.T) {
 	// Mock ReportRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up expectations for the mock repository and util
 	mockUserUtil.On("FindCurrentUser", mock.Anything).Return((*usermodel.User)(nil), errors.New("record not found"))
@@ -130,7 +857,7 @@ func TestAnalysis_NoUser(t *testing.T) {
 		VideoURL:     "test",
 		AlertCount:   10,
 		AnalysisTime: 1800,
-		Type:         "Study",
+		Type:         services.AnalysisTypeSideSitting,
 	}
 
 	// Call the service
@@ -142,29 +869,209 @@ func TestAnalysis_NoUser(t *testing.T) {
 	mockUserUtil.AssertExpectations(t)
 }
 
-func TestPredict_InvalidURL(t *testing.T) {
-	// Mock ReportRepository, UserUtil
+func TestFindDeadLetters(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	service := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up sample dead letters for the test
+	deadLetters := []models.AnalysisDeadLetter{
+		{ID: 2, JobID: 5, UserID: 1, VideoURL: "https://example.com/b.mp4", LastError: "ai server responded with status 500"},
+		{ID: 1, JobID: 3, UserID: 1, VideoURL: "https://example.com/a.mp4", LastError: "ai server responded with status 422"},
+	}
 
-	// Set up invalid URL
-	url := ""
+	// Set up expectations for the mock repository
+	mockAnalysisJobRepository.On("FindDeadLetters").Return(deadLetters, nil)
 
 	// Call the service
-	err := services.Predict(*service, url, usermodel.User{}, types.RequestAnalysis{})
-	assert.Error(t, err)
+	responseDeadLetters, err := reportService.FindDeadLetters()
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results
+	assert.Equal(t, len(deadLetters), len(responseDeadLetters))
+	for i, deadLetter := range deadLetters {
+		assert.Equal(t, deadLetter.ID, responseDeadLetters[i].ID)
+		assert.Equal(t, deadLetter.JobID, responseDeadLetters[i].JobID)
+		assert.Equal(t, deadLetter.LastError, responseDeadLetters[i].LastError)
+	}
+}
+
+func TestRedriveDeadLetter(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up sample dead letter for the test
+	deadLetter := models.AnalysisDeadLetter{ID: 1, JobID: 3, UserID: 1, VideoURL: "https://example.com/a.mp4", Type: services.AnalysisTypeSideSitting}
+
+	// Set up expectations for the mock repository
+	mockAnalysisJobRepository.On("FindDeadLetterById", uint(1)).Return(deadLetter, nil)
+	mockAnalysisJobRepository.On("RedriveDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter"), mock.AnythingOfType("*models.AnalysisJob")).
+		Run(func(args mock.Arguments) { args.Get(1).(*models.AnalysisJob).ID = 8 }).
+		Return(true, nil)
+
+	// Call the service
+	responseJob, err := reportService.RedriveDeadLetter(1)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results
+	assert.Equal(t, uint(8), responseJob.ID)
+	assert.Equal(t, models.JobStatusQueued, responseJob.Status)
+
+	queued := mockAnalysisJobRepository.Calls[1].Arguments.Get(1).(*models.AnalysisJob)
+	assert.Equal(t, deadLetter.VideoURL, queued.VideoURL)
+	assert.Equal(t, deadLetter.Type, queued.Type)
+}
+
+func TestRedriveDeadLetter_AlreadyRedriven(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up a dead letter that another request redrives first
+	deadLetter := models.AnalysisDeadLetter{ID: 1, JobID: 3, UserID: 1}
+
+	// Set up expectations for the mock repository
+	mockAnalysisJobRepository.On("FindDeadLetterById", uint(1)).Return(deadLetter, nil)
+	mockAnalysisJobRepository.On("RedriveDeadLetter", mock.AnythingOfType("*models.AnalysisDeadLetter"), mock.AnythingOfType("*models.AnalysisJob")).Return(false, nil)
+
+	// Call the service
+	_, err := reportService.RedriveDeadLetter(1)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results
+	assert.EqualError(t, err, "dead letter 1 was already redriven")
+}
+
+func TestFindAnalyzerEndpoints(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService with a balanced analyzer behind the retry analyzer
+	balancer := analyzers.NewBalancedAnalyzer([]*analyzers.Endpoint{
+		analyzers.NewEndpoint("http://ai-1", "", analyzers.NewFakeAnalyzer(), analyzers.NewCircuitBreaker(3, time.Minute)),
+		analyzers.NewEndpoint("http://ai-2", "", analyzers.NewFakeAnalyzer(), analyzers.NewCircuitBreaker(3, time.Minute)),
+	}, analyzers.RoundRobin)
+	analyzer := analyzers.NewRetryAnalyzer(balancer, analyzers.DefaultRetryPolicy())
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzer, mockUserUtil)
+
+	// Call the service
+	endpoints := reportService.FindAnalyzerEndpoints()
+
+	// Check the results
+	assert.Len(t, endpoints, 2)
+	assert.Equal(t, "http://ai-1", endpoints[0].URL)
+	assert.True(t, endpoints[0].Healthy)
+	assert.Equal(t, "closed", endpoints[0].CircuitState)
+
+	// The fake analyzer has no endpoints
+	fakeService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	assert.Empty(t, fakeService.FindAnalyzerEndpoints())
 }
 
-func TestHandleRequest_Error(t *testing.T) {
-	// Set up invalid URL
-	url := ""
+func TestPredict_AnalyzerError(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Set up an analyzer that fails
+	fakeAnalyzer := analyzers.NewFakeAnalyzer()
+	fakeAnalyzer.Err = errors.New("ai server unavailable")
+
+	// Create ReportService
+	service := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, fakeAnalyzer, mockUserUtil)
 
 	// Call the service
-	_, err := services.HandleRequest(url, url)
+	_, err := service.Predict(context.Background(), usermodel.User{}, types.RequestAnalysis{VideoURL: "test"})
 	assert.Error(t, err)
+
+	// Assert that nothing was saved
+	mockReportRepository.AssertExpectations(t)
+}
+
+func TestAnalysisFlow_WithFakeServer(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Serve fixtures over HTTP so the real AI client is exercised offline
+	fakeAnalyzer := analyzers.NewFakeAnalyzer()
+	fakeAnalyzer.Responses["https://example.com/bad.mp4"] = analyzers.BadPostureFixture()
+	server := analyzers.NewFakeServer(fakeAnalyzer)
+	defer server.Close()
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewHTTPAnalyzer(server.URL, time.Second), mockUserUtil)
+
+	// Set up sample user for the test
+	user := usermodel.User{ID: 1, Nickname: "test", FcmToken: "test_token"}
+
+	// Set up the job as it is stored and later claimed by a worker
+	job := &models.AnalysisJob{
+		ID:           1,
+		UserID:       user.ID,
+		VideoURL:     "https://example.com/bad.mp4",
+		AlertCount:   3,
+		AnalysisTime: 1800,
+		Type:         services.AnalysisTypeSideSitting,
+		Status:       models.JobStatusQueued,
+	}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&user, nil)
+	mockUserUtil.On("FindUserByID", user.ID).Return(&user, nil)
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusQueued).Return(int64(0), nil)
+	mockAnalysisJobRepository.On("Create", mock.AnythingOfType("*models.AnalysisJob")).Return(job, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 0, models.JobStatusRunning, models.JobStatusSucceeded).Return(true, nil)
+	mockAnalysisJobRepository.On("Update", job).Return(nil)
+	mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 10, UserID: user.ID, Score: "29.33"}, nil)
+
+	// Submit the analysis
+	c, _ := gin.CreateTestContext(nil)
+	submitted, err := reportService.Analysis(c, types.RequestAnalysis{VideoURL: job.VideoURL, AlertCount: 3, AnalysisTime: 1800, Type: services.AnalysisTypeSideSitting})
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, submitted.Status)
+
+	// Run the job as a worker would (the push itself fails without Firebase credentials)
+	job.Status = models.JobStatusRunning
+	_ = reportService.RunJob(context.Background(), job)
+
+	// Assert that the expectations were met
+	mockReportRepository.AssertExpectations(t)
+	mockAnalysisJobRepository.AssertExpectations(t)
+	mockUserUtil.AssertExpectations(t)
+
+	// Check the saved report and the job outcome
+	savedReport := mockReportRepository.Calls[0].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, "29.33", savedReport.Score)
+	assert.Equal(t, "[0 0 0 0 0 0]", savedReport.Predict)
+	assert.Equal(t, models.JobStatusSucceeded, job.Status)
+	assert.Equal(t, uint(10), *job.ReportID)
+	assert.Equal(t, []string{"https://example.com/bad.mp4"}, fakeAnalyzer.Calls())
 }
 
 func TestParseAnalysis(t *testing.T) {
@@ -353,6 +1260,18 @@ func TestGenerateMessage(t *testing.T) {
 	}
 }
 
+func TestGenerateFailureMessage(t *testing.T) {
+	// Set up test data
+	requestedAt := time.Date(2024, 2, 1, 12, 4, 5, 0, time.UTC)
+
+	// Execute method under test
+	title, body := services.GenerateFailureMessage(requestedAt)
+
+	// Assert result
+	assert.Equal(t, "자세 분석에 실패했어요", title)
+	assert.Equal(t, "2024년 2월 1일에 요청한 분석을 완료하지 못했습니다. 다시 측정해 주세요.", body)
+}
+
 func TestGenerateMessage_InvaildData(t *testing.T) {
 	// Set up invalid test data
 	testTime := "2024-02-01"
@@ -369,10 +1288,11 @@ func TestGenerateMessage_InvaildData(t *testing.T) {
 func TestFindReportByCurrentUser(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up sample user for the test
 	user := usermodel.User{
@@ -391,7 +1311,7 @@ func TestFindReportByCurrentUser(t *testing.T) {
 			UserID:            1,
 			AlertCount:        10,
 			AnalysisTime:      1800,
-			Type:              "Study",
+			Type:              services.AnalysisTypeSideSitting,
 			Predict:           "Good",
 			Score:             "90.000",
 			NormalRatio:       "90.000",
@@ -404,7 +1324,7 @@ func TestFindReportByCurrentUser(t *testing.T) {
 			UserID:            1,
 			AlertCount:        10,
 			AnalysisTime:      1800,
-			Type:              "Study",
+			Type:              services.AnalysisTypeSideSitting,
 			Predict:           "Good",
 			Score:             "90.000",
 			NormalRatio:       "90.000",
@@ -449,10 +1369,11 @@ func TestFindReportByCurrentUser(t *testing.T) {
 func TestFindReportByCurrentUser_NoUser(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up expectations for the mock repository and util
 	mockUserUtil.On("FindCurrentUser", mock.Anything).Return((*usermodel.User)(nil), errors.New("record not found"))
@@ -475,10 +1396,11 @@ func TestFindReportByCurrentUser_NoUser(t *testing.T) {
 func TestFindReportByCurrentUser_NoUser2(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up sample user for the test
 	user := usermodel.User{
@@ -512,10 +1434,11 @@ func TestFindReportByCurrentUser_NoUser2(t *testing.T) {
 func TestFindReportByCurrentUser_NoReport(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up sample user for the test
 	user := usermodel.User{
@@ -547,18 +1470,21 @@ func TestFindReportByCurrentUser_NoReport(t *testing.T) {
 func TestFindById(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
-	// Set up sample report for the test
+	// Set up sample user and report for the test
+	user := usermodel.User{ID: 1}
 	report := models.Report{
 		ID:                1,
+		PublicID:          "0b6f1c8e-2d4a-4f4e-9a57-3c1d2e5f7a90",
 		UserID:            1,
 		AlertCount:        10,
 		AnalysisTime:      1800,
-		Type:              "Study",
+		Type:              services.AnalysisTypeSideSitting,
 		Predict:           "Good",
 		Score:             "90.000",
 		NormalRatio:       "90.000",
@@ -567,14 +1493,15 @@ func TestFindById(t *testing.T) {
 		StatusFrequencies: "status",
 	}
 
-	// Set up expectations for the mock repository
-	mockReportRepository.On("FindById", mock.Anything).Return(report, nil)
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&user, nil)
+	mockReportRepository.On("FindByPublicIDWithFrames", report.PublicID).Return(report, nil)
 
 	// Create a test context
 	c, _ := gin.CreateTestContext(nil)
 
 	// Call the service
-	responseReport, err := reportService.FindById(c, 1)
+	responseReport, err := reportService.FindById(c, report.PublicID)
 
 	// Assert that the expectations were met
 	mockReportRepository.AssertExpectations(t)
@@ -583,6 +1510,7 @@ func TestFindById(t *testing.T) {
 	// Check the results
 	assert.NoError(t, err)
 	assert.Equal(t, report.ID, responseReport.ID)
+	assert.Equal(t, report.PublicID, responseReport.PublicID)
 	assert.Equal(t, report.UserID, responseReport.UserID)
 	assert.Equal(t, report.AlertCount, responseReport.AlertCount)
 	assert.Equal(t, report.AnalysisTime, responseReport.AnalysisTime)
@@ -598,36 +1526,63 @@ func TestFindById(t *testing.T) {
 func TestFindById_NoReport(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 	assert.NotNil(t, reportService)
 
-	// Set up expectations for the mock repository
-	mockReportRepository.On("FindById", mock.Anything).Return(models.Report{}, errors.New("record not found"))
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockReportRepository.On("FindByIdWithFrames", uint(1)).Return(models.Report{}, gorm.ErrRecordNotFound)
 
 	// Create a test context
 	c, _ := gin.CreateTestContext(nil)
 
 	// Call the service
-	_, err := reportService.FindById(c, 1)
+	_, err := reportService.FindById(c, "1")
 
 	// Assert that the expectations were met
 	mockReportRepository.AssertExpectations(t)
 	mockUserUtil.AssertExpectations(t)
 
 	// Check the results
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, services.ErrReportNotFound)
+}
+
+func TestFindById_OtherUser(t *testing.T) {
+	// Mock UserRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository and util: report 2 belongs to user 2
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockReportRepository.On("FindByIdWithFrames", uint(2)).Return(models.Report{ID: 2, UserID: 2, Score: "90.000"}, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	response, err := reportService.FindById(c, "2")
+
+	// Check the results: the report is reported missing and nothing of it leaks
+	assert.ErrorIs(t, err, services.ErrReportNotFound)
+	assert.Equal(t, types.ResponseReport{}, response)
 }
 
 func TestFindReportSummaryByMonth(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up sample user for the test
 	user := usermodel.User{
@@ -646,7 +1601,7 @@ func TestFindReportSummaryByMonth(t *testing.T) {
 			UserID:            1,
 			AlertCount:        10,
 			AnalysisTime:      1800,
-			Type:              "Study",
+			Type:              services.AnalysisTypeSideSitting,
 			Predict:           "Good",
 			Score:             "90.000",
 			NormalRatio:       "90.000",
@@ -660,7 +1615,7 @@ func TestFindReportSummaryByMonth(t *testing.T) {
 			UserID:            1,
 			AlertCount:        10,
 			AnalysisTime:      1800,
-			Type:              "Study",
+			Type:              services.AnalysisTypeSideSitting,
 			Predict:           "Good",
 			Score:             "90.000",
 			NormalRatio:       "90.000",
@@ -700,10 +1655,11 @@ func TestFindReportSummaryByMonth(t *testing.T) {
 func TestFindReportSummaryByMonth_NoUser(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up expectations for the mock repository and util
 	mockUserUtil.On("FindCurrentUser", mock.Anything).Return((*usermodel.User)(nil), errors.New("record not found"))
@@ -726,10 +1682,11 @@ func TestFindReportSummaryByMonth_NoUser(t *testing.T) {
 func TestFindReportSummaryByMonth_NoReport(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up sample user for the test
 	user := usermodel.User{
@@ -764,10 +1721,11 @@ func TestFindReportSummaryByMonth_NoReport(t *testing.T) {
 func TestFindAll(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up sample reports for the test
 	reports := []models.Report{
@@ -776,7 +1734,7 @@ func TestFindAll(t *testing.T) {
 			UserID:            1,
 			AlertCount:        10,
 			AnalysisTime:      1800,
-			Type:              "Study",
+			Type:              services.AnalysisTypeSideSitting,
 			Predict:           "Good",
 			Score:             "90.000",
 			NormalRatio:       "90.000",
@@ -790,7 +1748,7 @@ func TestFindAll(t *testing.T) {
 			UserID:            1,
 			AlertCount:        30,
 			AnalysisTime:      3600,
-			Type:              "Study",
+			Type:              services.AnalysisTypeSideSitting,
 			Predict:           "Good",
 			Score:             "90.000",
 			NormalRatio:       "90.000",
@@ -823,10 +1781,11 @@ func TestFindAll(t *testing.T) {
 func TestFindAll_NoReport(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 	assert.NotNil(t, reportService)
 
 	// Set up expectations for the mock repository
@@ -845,10 +1804,11 @@ func TestFindAll_NoReport(t *testing.T) {
 func TestFindRankAtAgeAndGender(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up sample user for the test
 	user := usermodel.User{
@@ -897,10 +1857,11 @@ func TestFindRankAtAgeAndGender(t *testing.T) {
 func TestFindRankAtAgeAndGender_NoUser(t *testing.T) {
 	// Mock UserRepository, UserUtil
 	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
 	mockUserUtil := new(MockUserUtil)
 
 	// Create ReportService
-	reportService := services.NewReportService(mockReportRepository, mockUserUtil)
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
 
 	// Set up expectations for the mock repository and util
 	mockUserUtil.On("FindCurrentUser", mock.Anything).Return((*usermodel.User)(nil), errors.New("record not found"))
@@ -916,3 +1877,230 @@ func TestFindRankAtAgeAndGender_NoUser(t *testing.T) {
 	mockReportRepository.AssertExpectations(t)
 	mockUserUtil.AssertExpectations(t)
 }
+
+func TestBuildReportFrames(t *testing.T) {
+	// Create an analysis with more results than landmarks
+	response := &types.ResponseAnalysis{
+		Result: []int{1, 0},
+		Scores: []float64{99.5, 80},
+		LandmarksInfo: []types.LandmarkInfo{
+			{LeftShoulder: types.Landmark{X: 1, Y: 2}, LeftEar: types.Landmark{X: 3, Y: 4}, VerticalDistanceCM: 5, Angle: 6},
+		},
+	}
+
+	// Call the method under test
+	frames := services.BuildReportFrames(response)
+
+	// Check the result
+	assert.Len(t, frames, 2)
+	assert.Equal(t, 0, frames[0].FrameIndex)
+	assert.Equal(t, 1, *frames[0].Class)
+	assert.Equal(t, 99.5, *frames[0].Confidence)
+	assert.Equal(t, 6.0, *frames[0].Angle)
+	assert.Equal(t, 5.0, *frames[0].Distance)
+	assert.Equal(t, 3.0, *frames[0].LeftEarX)
+	assert.Equal(t, 1, frames[1].FrameIndex)
+	assert.Equal(t, 0, *frames[1].Class)
+	assert.Nil(t, frames[1].Angle)
+}
+
+func TestFindReportsV2(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Create a backfilled report and one that still only has the legacy strings
+	score, ratio, fine, danger, serious, verySerious := 75.5, 0.5, 1, 1, 0, 0
+	class, angle, distance := 1, 12.3, 4.5
+	reports := []models.Report{
+		{
+			ID: 1, UserID: 1, Type: services.AnalysisTypeSideSitting,
+			ScoreValue: &score, NormalRatioValue: &ratio,
+			FineCount: &fine, DangerCount: &danger, SeriousCount: &serious, VerySeriousCount: &verySerious,
+			Frames: []models.ReportFrame{{FrameIndex: 0, Class: &class, Angle: &angle, Distance: &distance}},
+		},
+		{
+			ID: 2, UserID: 1, Type: services.AnalysisTypeSideSitting,
+			Predict: "[0 1]", Score: "60.00", NormalRatio: "0.500", NeckAngles: "[20.000 10.000]", Distances: "[1.000 2.000]", StatusFrequencies: "[1 0 1 0]",
+		},
+	}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockReportRepository.On("FindByUserIDWithFrames", uint(1)).Return(reports, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	response, err := reportService.FindReportsV2(c)
+
+	// Check the results
+	assert.NoError(t, err)
+	assert.Len(t, response, 2)
+	assert.Equal(t, []int{1}, response[0].Predict)
+	assert.Equal(t, 75.5, response[0].Score)
+	assert.Equal(t, []float64{12.3}, response[0].NeckAngles)
+	assert.Equal(t, []float64{4.5}, response[0].Distances)
+	assert.Equal(t, map[string]int{"Fine": 1, "Danger": 1, "Serious": 0, "Very Serious": 0}, response[0].StatusFrequencies)
+
+	assert.Equal(t, []int{0, 1}, response[1].Predict)
+	assert.Equal(t, 60.0, response[1].Score)
+	assert.Equal(t, 0.5, response[1].NormalRatio)
+	assert.Equal(t, []float64{20, 10}, response[1].NeckAngles)
+	assert.Equal(t, 1, response[1].StatusFrequencies["Serious"])
+}
+
+func TestFindReportV2ByUserID_OtherUser(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindByIdWithFrames", uint(3)).Return(models.Report{ID: 3, UserID: 2}, nil)
+
+	// Call the service
+	_, err := reportService.FindReportV2ByUserID(1, "3")
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrReportNotFound)
+}
+
+func TestFindReportV2ByUserID_NumericIDSunset(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService whose numeric report IDs are past their sunset
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.NumericReportIDsUntil = time.Now().Add(-time.Hour)
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindByPublicIDWithFrames", "r-3").Return(models.Report{ID: 3, PublicID: "r-3", UserID: 1}, nil)
+
+	// Call the service with the numeric and the public ID of the same report
+	_, numericErr := reportService.FindReportV2ByUserID(1, "3")
+	response, err := reportService.FindReportV2ByUserID(1, "r-3")
+
+	// Check the results: only the public ID still finds the report
+	assert.ErrorIs(t, numericErr, services.ErrReportNotFound)
+	mockReportRepository.AssertNotCalled(t, "FindByIdWithFrames", mock.Anything)
+	assert.NoError(t, err)
+	assert.Equal(t, "r-3", response.PublicID)
+}
+
+func TestAnalyzeKeypointsForUser(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService with an analyzer that must not be called
+	fakeAnalyzer := analyzers.NewFakeAnalyzer()
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, fakeAnalyzer, mockUserUtil)
+
+	// Create an upright and a hunched frame
+	input := types.RequestKeypointAnalysis{
+		AlertCount:   1,
+		AnalysisTime: 60,
+		Type:         services.AnalysisTypeSideSitting,
+		Frames: []types.KeypointFrame{
+			{LeftShoulder: types.Landmark{X: 0.5, Y: 0.6}, LeftEar: types.Landmark{X: 0.5, Y: 0.3}},
+			{LeftShoulder: types.Landmark{X: 0.5, Y: 0.6}, LeftEar: types.Landmark{X: 0.7, Y: 0.4}},
+		},
+	}
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 10, PublicID: "r-10", UserID: 1}, nil)
+
+	// Call the service
+	response, err := reportService.AnalyzeKeypointsForUser(usermodel.User{ID: 1}, input)
+
+	// Check the results: the report is saved without calling the AI server
+	assert.NoError(t, err)
+	assert.Empty(t, fakeAnalyzer.Calls())
+	assert.Equal(t, "r-10", response.PublicID)
+
+	saved := mockReportRepository.Calls[0].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, "[1 0]", saved.Predict)
+	assert.Equal(t, 50.0, *saved.NormalRatioValue)
+	assert.Equal(t, 1, *saved.FineCount)
+	assert.Equal(t, 1, *saved.VerySeriousCount)
+	assert.Len(t, saved.Frames, 2)
+	assert.InDelta(t, 45, *saved.Frames[1].Angle, 1e-9)
+	assert.InDelta(t, 10, *saved.Frames[1].Distance, 1e-9)
+	assert.Equal(t, models.DefaultScoringProfileVersion, saved.ScoringProfileVersion)
+}
+
+func TestRecordAlertEventsForUser(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Create a half-hour report that already has one corrected alert
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	correctedAfter := int64(2000)
+	report := models.Report{
+		ID: 1, UserID: 1, AnalysisTime: 1800,
+		Alerts: []models.AlertEvent{{ID: 1, ReportID: 1, Type: "hunched", Severity: "low", OccurredAt: start.Add(10 * time.Minute), CorrectedAfterMs: &correctedAfter}},
+	}
+
+	// Record a corrected alert before it and an uncorrected one after it
+	correctedLater := int64(6000)
+	input := types.RequestAlertEvents{Events: []types.RequestAlertEvent{
+		{Type: "hunched", Severity: "high", OccurredAt: start.Add(20 * time.Minute)},
+		{Type: "too_close", Severity: "medium", OccurredAt: start.Add(5 * time.Minute), CorrectedAfterMs: &correctedLater},
+	}}
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindByIdWithFrames", uint(1)).Return(report, nil)
+	mockReportRepository.On("SaveAlertEvents", mock.AnythingOfType("[]models.AlertEvent")).Return(nil)
+
+	// Call the service
+	response, err := reportService.RecordAlertEventsForUser(1, "1", input)
+
+	// Check the results: the timeline is in order and the metrics cover every alert
+	assert.NoError(t, err)
+	assert.Len(t, response.AlertTimeline, 3)
+	assert.Equal(t, "too_close", response.AlertTimeline[0].Type)
+	assert.Equal(t, uint(1), response.AlertTimeline[1].ID)
+	assert.Equal(t, "high", response.AlertTimeline[2].Severity)
+	assert.Equal(t, types.ResponseAlertMetrics{Count: 3, Corrected: 2, MeanTimeToCorrectMs: 4000, AlertsPerHour: 6}, response.AlertMetrics)
+
+	saved := mockReportRepository.Calls[1].Arguments.Get(0).([]models.AlertEvent)
+	assert.Len(t, saved, 2)
+	assert.Equal(t, uint(1), saved[0].ReportID)
+}
+
+func TestRecordAlertEventsForUser_OtherUser(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindByIdWithFrames", uint(3)).Return(models.Report{ID: 3, UserID: 2}, nil)
+
+	// Call the service
+	_, err := reportService.RecordAlertEventsForUser(1, "3", types.RequestAlertEvents{Events: []types.RequestAlertEvent{{Type: "hunched", Severity: "low", OccurredAt: time.Now()}}})
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrReportNotFound)
+	mockReportRepository.AssertNotCalled(t, "SaveAlertEvents", mock.Anything)
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/repositories"
+	"strconv"
+)
+
+const DefaultRescoreBatchSize = 200
+
+// RescoreOptions selects the profile to rescore with. An empty Version means the active profile.
+// With DryRun set nothing is written; the results only report what would change.
+type RescoreOptions struct {
+	Version   string
+	BatchSize int
+	DryRun    bool
+}
+
+// RescoreResult is the outcome for one report.
+type RescoreResult struct {
+	ReportID    uint
+	FromVersion string
+	FromScore   float64
+	ToScore     float64
+	Delta       float64
+}
+
+// RescoreProgress is reported after every batch.
+type RescoreProgress struct {
+	Processed int
+	Rescored  int
+	Skipped   int
+	LastID    uint
+}
+
+type RescoreService struct {
+	ReportRepository repositories.ReportRepositoryInterface
+	Scoring          ScoringServiceInterface
+	// OnResult and OnProgress, when set, are called for every rescored report and after every batch.
+	OnResult   func(RescoreResult)
+	OnProgress func(RescoreProgress)
+}
+
+func NewRescoreService(reportRepository repositories.ReportRepositoryInterface, scoring ScoringServiceInterface) *RescoreService {
+	return &RescoreService{
+		ReportRepository: reportRepository,
+		Scoring:          scoring,
+	}
+}
+
+// Rescore recalculates the score of every report from its stored frames with the chosen profile.
+// Reports already scored with that profile, and reports without per-frame confidences
+// (those backfilled from the legacy strings), are skipped. The score a report had before its
+// first rescore is kept in OriginalScore.
+func (service *RescoreService) Rescore(options RescoreOptions) (RescoreProgress, string, error) {
+	version := options.Version
+	var err error
+	if version == "" {
+		version, _, err = service.Scoring.ActiveRules()
+		if err != nil {
+			return RescoreProgress{}, "", err
+		}
+	}
+
+	rules, err := service.Scoring.Rules(version)
+	if err != nil {
+		return RescoreProgress{}, version, err
+	}
+
+	batchSize := options.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultRescoreBatchSize
+	}
+
+	var progress RescoreProgress
+	for {
+		reports, err := service.ReportRepository.FindBatchWithFrames(progress.LastID, batchSize)
+		if err != nil {
+			return progress, version, err
+		}
+		if len(reports) == 0 {
+			return progress, version, nil
+		}
+
+		for i := range reports {
+			report := &reports[i]
+			progress.Processed++
+			progress.LastID = report.ID
+
+			result, scores, ok := frameScores(report.Frames)
+			if !ok || report.ScoringProfileVersion == version {
+				progress.Skipped++
+				continue
+			}
+
+			fromVersion, fromScore := report.ScoringProfileVersion, currentScore(*report)
+			score := CalculateScoresWithRules(rules, result, scores)
+			toScore, err := strconv.ParseFloat(score, 64)
+			if err != nil {
+				progress.Skipped++
+				continue
+			}
+
+			if !options.DryRun {
+				if report.OriginalScore == nil {
+					report.OriginalScore = &fromScore
+					report.OriginalScoringProfileVersion = report.ScoringProfileVersion
+				}
+				report.Score = score
+				report.ScoreValue = &toScore
+				report.ScoringProfileVersion = version
+
+				if err := service.ReportRepository.UpdateScore(report); err != nil {
+					return progress, version, err
+				}
+			}
+
+			progress.Rescored++
+			if service.OnResult != nil {
+				service.OnResult(RescoreResult{
+					ReportID:    report.ID,
+					FromVersion: fromVersion,
+					FromScore:   fromScore,
+					ToScore:     toScore,
+					Delta:       toScore - fromScore,
+				})
+			}
+		}
+
+		if service.OnProgress != nil {
+			service.OnProgress(progress)
+		}
+	}
+}
+
+// frameScores rebuilds the classes and confidences CalculateScores works on.
+// It fails when a frame has no class or confidence.
+func frameScores(frames []models.ReportFrame) ([]int, []float64, bool) {
+	if len(frames) == 0 {
+		return nil, nil, false
+	}
+
+	result := make([]int, 0, len(frames))
+	scores := make([]float64, 0, len(frames))
+	for _, frame := range frames {
+		if frame.Class == nil || frame.Confidence == nil {
+			return nil, nil, false
+		}
+		result = append(result, *frame.Class)
+		scores = append(scores, *frame.Confidence)
+	}
+
+	return result, scores, true
+}
+
+func currentScore(report models.Report) float64 {
+	if report.ScoreValue != nil {
+		return *report.ScoreValue
+	}
+
+	score, _ := strconv.ParseFloat(report.Score, 64)
+	return score
+}
@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// rescoreReports returns a report that can be rescored, one backfilled from legacy strings
+// and one already scored with v2.
+func rescoreReports() []models.Report {
+	normal, abnormal := 1, 0
+	high, low := 99.9, 50.0
+	score := 59.0
+
+	return []models.Report{
+		{
+			ID: 1, Score: "59.00", ScoreValue: &score, ScoringProfileVersion: "v1",
+			Frames: []models.ReportFrame{{Class: &normal, Confidence: &high}, {Class: &abnormal, Confidence: &low}},
+		},
+		{
+			ID: 2, Score: "43.00", ScoringProfileVersion: "v1",
+			Frames: []models.ReportFrame{{Class: &abnormal}},
+		},
+		{
+			ID: 3, Score: "50.00", ScoringProfileVersion: "v2",
+			Frames: []models.ReportFrame{{Class: &normal, Confidence: &high}, {Class: &abnormal, Confidence: &low}},
+		},
+	}
+}
+
+func newRescoreService(t *testing.T) (*services.RescoreService, *MockReportRepository) {
+	mockReportRepository := new(MockReportRepository)
+	mockScoringProfileRepository := new(MockScoringProfileRepository)
+	mockScoringProfileRepository.On("FindByVersion", "v2").Return(newProfile(t, "v2", flatRules(), false), nil)
+
+	rescoreService := services.NewRescoreService(mockReportRepository, services.NewScoringService(mockScoringProfileRepository))
+	return rescoreService, mockReportRepository
+}
+
+func TestRescore(t *testing.T) {
+	// Create RescoreService rescoring with v2
+	rescoreService, mockReportRepository := newRescoreService(t)
+
+	var progress []services.RescoreProgress
+	rescoreService.OnProgress = func(p services.RescoreProgress) { progress = append(progress, p) }
+
+	// Set up expectations for the mock repository: one batch, then nothing
+	mockReportRepository.On("FindBatchWithFrames", uint(0), 3).Return(rescoreReports(), nil)
+	mockReportRepository.On("FindBatchWithFrames", uint(3), 3).Return([]models.Report{}, nil)
+	mockReportRepository.On("UpdateScore", mock.AnythingOfType("*models.Report")).Return(nil)
+
+	// Call the method under test
+	summary, version, err := rescoreService.Rescore(services.RescoreOptions{Version: "v2", BatchSize: 3})
+
+	// Check the results
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", version)
+	assert.Equal(t, services.RescoreProgress{Processed: 3, Rescored: 1, Skipped: 2, LastID: 3}, summary)
+	assert.Equal(t, []services.RescoreProgress{summary}, progress)
+
+	mockReportRepository.AssertNumberOfCalls(t, "UpdateScore", 1)
+	updated := mockReportRepository.Calls[1].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, uint(1), updated.ID)
+	assert.Equal(t, "50.00", updated.Score)
+	assert.Equal(t, 50.0, *updated.ScoreValue)
+	assert.Equal(t, "v2", updated.ScoringProfileVersion)
+	assert.Equal(t, 59.0, *updated.OriginalScore)
+	assert.Equal(t, "v1", updated.OriginalScoringProfileVersion)
+}
+
+func TestRescore_KeepsOriginalScore(t *testing.T) {
+	// Create RescoreService rescoring with v2
+	rescoreService, mockReportRepository := newRescoreService(t)
+
+	// Create a report that was already rescored once, from v0
+	reports := rescoreReports()[:1]
+	original := 70.0
+	reports[0].OriginalScore = &original
+	reports[0].OriginalScoringProfileVersion = "v0"
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindBatchWithFrames", uint(0), services.DefaultRescoreBatchSize).Return(reports, nil)
+	mockReportRepository.On("FindBatchWithFrames", uint(1), services.DefaultRescoreBatchSize).Return([]models.Report{}, nil)
+	mockReportRepository.On("UpdateScore", mock.AnythingOfType("*models.Report")).Return(nil)
+
+	// Call the method under test
+	_, _, err := rescoreService.Rescore(services.RescoreOptions{Version: "v2"})
+
+	// Check the results: the first score is kept
+	assert.NoError(t, err)
+	updated := mockReportRepository.Calls[1].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, 70.0, *updated.OriginalScore)
+	assert.Equal(t, "v0", updated.OriginalScoringProfileVersion)
+}
+
+func TestRescore_DryRun(t *testing.T) {
+	// Create RescoreService rescoring with v2
+	rescoreService, mockReportRepository := newRescoreService(t)
+
+	var results []services.RescoreResult
+	rescoreService.OnResult = func(r services.RescoreResult) { results = append(results, r) }
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindBatchWithFrames", uint(0), 10).Return(rescoreReports(), nil)
+	mockReportRepository.On("FindBatchWithFrames", uint(3), 10).Return([]models.Report{}, nil)
+
+	// Call the method under test
+	summary, _, err := rescoreService.Rescore(services.RescoreOptions{Version: "v2", BatchSize: 10, DryRun: true})
+
+	// Check the results: the delta is reported but nothing is written
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.Rescored)
+	mockReportRepository.AssertNotCalled(t, "UpdateScore", mock.Anything)
+	assert.Equal(t, []services.RescoreResult{{ReportID: 1, FromVersion: "v1", FromScore: 59, ToScore: 50, Delta: -9}}, results)
+}
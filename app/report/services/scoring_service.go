@@ -0,0 +1,176 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/repositories"
+	"gdsc/baro/app/report/types"
+
+	"gorm.io/gorm"
+)
+
+var ErrScoringProfileExists = errors.New("scoring profile version already exists")
+
+// DefaultScoringRules are the rules of the default scoring profile, the thresholds reports were
+// scored with before profiles existed.
+func DefaultScoringRules() types.ScoringRules {
+	return types.ScoringRules{
+		Normal: []types.ScoreTier{
+			{MinConfidence: 99.8, Weight: 1.0},
+			{MinConfidence: 99, Weight: 0.97},
+			{MinConfidence: 96, Weight: 0.94},
+			{MinConfidence: 93, Weight: 0.9},
+		},
+		NormalDefault: 0.86,
+		Abnormal: []types.ScoreTier{
+			{MinConfidence: 99.5, Weight: 0.18},
+			{MinConfidence: 98, Weight: 0.23},
+			{MinConfidence: 95, Weight: 0.3},
+			{MinConfidence: 90, Weight: 0.35},
+		},
+		AbnormalDefault: 0.43,
+	}
+}
+
+type ScoringServiceInterface interface {
+	FindScoringProfiles() ([]types.ResponseScoringProfile, error)
+	CreateScoringProfile(input types.RequestScoringProfile) (types.ResponseScoringProfile, error)
+	ActivateScoringProfile(version string) (types.ResponseScoringProfile, error)
+	ActiveRules() (string, types.ScoringRules, error)
+	Rules(version string) (types.ScoringRules, error)
+}
+
+type ScoringService struct {
+	ScoringProfileRepository repositories.ScoringProfileRepositoryInterface
+}
+
+func NewScoringService(scoringProfileRepository repositories.ScoringProfileRepositoryInterface) *ScoringService {
+	return &ScoringService{
+		ScoringProfileRepository: scoringProfileRepository,
+	}
+}
+
+// EnsureDefaultProfile stores the default profile if it is missing and activates it when no profile is active.
+func (service *ScoringService) EnsureDefaultProfile() error {
+	_, err := service.ScoringProfileRepository.FindByVersion(models.DefaultScoringProfileVersion)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		rules, _ := json.Marshal(DefaultScoringRules())
+		_, err = service.ScoringProfileRepository.Create(&models.ScoringProfile{
+			Version:     models.DefaultScoringProfileVersion,
+			Description: "Thresholds used before scoring profiles existed",
+			Rules:       string(rules),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = service.ScoringProfileRepository.FindActive()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		_, err = service.ScoringProfileRepository.Activate(models.DefaultScoringProfileVersion)
+	}
+
+	return err
+}
+
+func (service *ScoringService) FindScoringProfiles() ([]types.ResponseScoringProfile, error) {
+	profiles, err := service.ScoringProfileRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responseProfiles := make([]types.ResponseScoringProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		responseProfile, err := toResponseScoringProfile(profile)
+		if err != nil {
+			return nil, err
+		}
+		responseProfiles = append(responseProfiles, responseProfile)
+	}
+
+	return responseProfiles, nil
+}
+
+// CreateScoringProfile stores a new, inactive profile. Versions are never overwritten,
+// so a score can always be traced back to the rules that produced it.
+func (service *ScoringService) CreateScoringProfile(input types.RequestScoringProfile) (types.ResponseScoringProfile, error) {
+	_, err := service.ScoringProfileRepository.FindByVersion(input.Version)
+	if err == nil {
+		return types.ResponseScoringProfile{}, ErrScoringProfileExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.ResponseScoringProfile{}, err
+	}
+
+	rules, err := json.Marshal(input.Rules)
+	if err != nil {
+		return types.ResponseScoringProfile{}, err
+	}
+
+	profile, err := service.ScoringProfileRepository.Create(&models.ScoringProfile{
+		Version:     input.Version,
+		Description: input.Description,
+		Rules:       string(rules),
+	})
+	if err != nil {
+		return types.ResponseScoringProfile{}, err
+	}
+
+	return toResponseScoringProfile(profile)
+}
+
+func (service *ScoringService) ActivateScoringProfile(version string) (types.ResponseScoringProfile, error) {
+	profile, err := service.ScoringProfileRepository.Activate(version)
+	if err != nil {
+		return types.ResponseScoringProfile{}, err
+	}
+
+	return toResponseScoringProfile(profile)
+}
+
+// ActiveRules returns the version and rules of the active profile.
+func (service *ScoringService) ActiveRules() (string, types.ScoringRules, error) {
+	profile, err := service.ScoringProfileRepository.FindActive()
+	if err != nil {
+		return "", types.ScoringRules{}, err
+	}
+
+	rules, err := decodeRules(profile)
+	if err != nil {
+		return "", types.ScoringRules{}, err
+	}
+
+	return profile.Version, rules, nil
+}
+
+func (service *ScoringService) Rules(version string) (types.ScoringRules, error) {
+	profile, err := service.ScoringProfileRepository.FindByVersion(version)
+	if err != nil {
+		return types.ScoringRules{}, err
+	}
+
+	return decodeRules(profile)
+}
+
+func decodeRules(profile models.ScoringProfile) (types.ScoringRules, error) {
+	var rules types.ScoringRules
+	err := json.Unmarshal([]byte(profile.Rules), &rules)
+	return rules, err
+}
+
+func toResponseScoringProfile(profile models.ScoringProfile) (types.ResponseScoringProfile, error) {
+	rules, err := decodeRules(profile)
+	if err != nil {
+		return types.ResponseScoringProfile{}, err
+	}
+
+	return types.ResponseScoringProfile{
+		Version:     profile.Version,
+		Description: profile.Description,
+		Rules:       rules,
+		Active:      profile.Active,
+		ActivatedAt: profile.ActivatedAt,
+		CreatedAt:   profile.CreatedAt,
+	}, nil
+}
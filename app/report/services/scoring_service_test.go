@@ -0,0 +1,267 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockScoringProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockScoringProfileRepository) Create(profile *models.ScoringProfile) (models.ScoringProfile, error) {
+	args := m.Called(profile)
+	return *profile, args.Error(0)
+}
+
+func (m *MockScoringProfileRepository) FindAll() ([]models.ScoringProfile, error) {
+	args := m.Called()
+	return args.Get(0).([]models.ScoringProfile), args.Error(1)
+}
+
+func (m *MockScoringProfileRepository) FindByVersion(version string) (models.ScoringProfile, error) {
+	args := m.Called(version)
+	return args.Get(0).(models.ScoringProfile), args.Error(1)
+}
+
+func (m *MockScoringProfileRepository) FindActive() (models.ScoringProfile, error) {
+	args := m.Called()
+	return args.Get(0).(models.ScoringProfile), args.Error(1)
+}
+
+func (m *MockScoringProfileRepository) Activate(version string) (models.ScoringProfile, error) {
+	args := m.Called(version)
+	return args.Get(0).(models.ScoringProfile), args.Error(1)
+}
+
+// flatRules weighs every normal frame 1 and every abnormal frame 0.
+func flatRules() types.ScoringRules {
+	return types.ScoringRules{NormalDefault: 1, AbnormalDefault: 0}
+}
+
+func newProfile(t *testing.T, version string, rules types.ScoringRules, active bool) models.ScoringProfile {
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatalf("Error encoding rules: %v", err)
+	}
+	return models.ScoringProfile{Version: version, Rules: string(encoded), Active: active}
+}
+
+func TestCalculateScoresWithRules(t *testing.T) {
+	// Call the method under test with custom rules
+	result := services.CalculateScoresWithRules(flatRules(), []int{1, 1, 0, 0}, []float64{50, 99, 99, 50})
+
+	// Check the result
+	assert.Equal(t, "50.00", result)
+}
+
+func TestCalculateScoresWithRules_KeypointBands(t *testing.T) {
+	// Weights of the default profile a frame classified on the device gets, by neck angle
+	bands := []struct {
+		angle  float64
+		status string
+		score  string
+	}{
+		{5, "Fine", "100.00"},
+		{12, "Fine", "97.00"},
+		{17, "Fine", "94.00"},
+		{23, "Fine", "90.00"},
+		{28, "Danger", "35.00"},
+		{34, "Danger", "30.00"},
+		{38, "Serious", "23.00"},
+		{45, "Very Serious", "18.00"},
+	}
+
+	analyzer := analyzers.NewKeypointAnalyzer()
+	for _, band := range bands {
+		// Create a frame whose ear leans band.angle degrees from the shoulder
+		radians := band.angle * math.Pi / 180
+		frame := types.KeypointFrame{
+			LeftShoulder: types.Landmark{X: 0.5, Y: 0.6},
+			LeftEar:      types.Landmark{X: 0.5 + 0.3*math.Sin(radians), Y: 0.6 - 0.3*math.Cos(radians)},
+		}
+
+		// Call the method under test with the confidence of the frame
+		class, confidence, landmarks := analyzer.AnalyzeFrame(frame)
+		result := services.CalculateScoresWithRules(services.DefaultScoringRules(), []int{class}, []float64{confidence})
+
+		// Check the result: the tier of the frame follows its neck status
+		assert.Equal(t, band.status, analyzers.NeckStatus(landmarks.Angle), band.angle)
+		assert.Equal(t, band.score, result, band.angle)
+	}
+}
+
+func TestExplainScore(t *testing.T) {
+	// Call the method under test with the frames of TestCalculateScores_HalfGood
+	breakdown := services.ExplainScore(services.DefaultScoringRules(), []int{0, 0, 0, 1, 1, 1}, []float64{99.9, 99.2, 96.5, 95.5, 94.9, 92.0})
+
+	// Check the result: every tier is listed, the points add up to the score of 56.17
+	assert.Equal(t, 6, breakdown.TotalFrames)
+	assert.Len(t, breakdown.Bands, 10)
+	assert.Equal(t, types.ScoreBand{Class: "normal", MinConfidence: 93, Weight: 0.9, Frames: 2, Points: 30, Deduction: 3.33}, breakdown.Bands[3])
+	assert.Equal(t, types.ScoreBand{Class: "normal", Weight: 0.86, Frames: 1, Points: 14.33, Deduction: 2.33}, breakdown.Bands[4])
+
+	var points float64
+	for _, band := range breakdown.Bands {
+		points += band.Points
+	}
+	assert.InDelta(t, 56.17, points, 0.02)
+
+	// Check the result: the abnormal frames cost the most
+	assert.Equal(t, []types.ScoreBand{
+		{Class: "abnormal", MinConfidence: 99.5, Weight: 0.18, Frames: 1, Points: 3, Deduction: 13.67},
+		{Class: "abnormal", MinConfidence: 98, Weight: 0.23, Frames: 1, Points: 3.83, Deduction: 12.83},
+		{Class: "abnormal", MinConfidence: 95, Weight: 0.3, Frames: 1, Points: 5, Deduction: 11.67},
+	}, breakdown.TopDeductions)
+}
+
+func TestFindReportV2ByUserID_Breakdown(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil, ScoringProfileRepository
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+	mockScoringProfileRepository := new(MockScoringProfileRepository)
+
+	// Create ReportService explaining with the profile the report was scored with
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.Scoring = services.NewScoringService(mockScoringProfileRepository)
+
+	normal, abnormal, confidence := 1, 0, 99.0
+	report := models.Report{
+		ID: 1, UserID: 1, ScoringProfileVersion: "v2",
+		Frames: []models.ReportFrame{{Class: &normal, Confidence: &confidence}, {Class: &abnormal, Confidence: &confidence}},
+	}
+
+	// Set up expectations for the mock repositories
+	mockReportRepository.On("FindByIdWithFrames", uint(1)).Return(report, nil)
+	mockScoringProfileRepository.On("FindByVersion", "v2").Return(newProfile(t, "v2", flatRules(), false), nil)
+
+	// Call the service
+	response, err := reportService.FindReportV2ByUserID(1, "1")
+
+	// Check the results
+	assert.NoError(t, err)
+	assert.Equal(t, &types.ScoreBreakdown{
+		TotalFrames: 2,
+		Bands: []types.ScoreBand{
+			{Class: "normal", Weight: 1, Frames: 1, Points: 50},
+			{Class: "abnormal", Frames: 1, Deduction: 50},
+		},
+		TopDeductions: []types.ScoreBand{{Class: "abnormal", Frames: 1, Deduction: 50}},
+	}, response.Breakdown)
+}
+
+func TestFindReportV2ByUserID_NoBreakdownForLegacyReport(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository: a report without frame confidences
+	mockReportRepository.On("FindByIdWithFrames", uint(1)).Return(models.Report{ID: 1, UserID: 1, Predict: "[0 1]", Score: "60.00"}, nil)
+
+	// Call the service
+	response, err := reportService.FindReportV2ByUserID(1, "1")
+
+	// Check the results
+	assert.NoError(t, err)
+	assert.Nil(t, response.Breakdown)
+}
+
+func TestEnsureDefaultProfile(t *testing.T) {
+	// Mock ScoringProfileRepository without any profile
+	mockScoringProfileRepository := new(MockScoringProfileRepository)
+	scoringService := services.NewScoringService(mockScoringProfileRepository)
+
+	// Set up expectations for the mock repository
+	mockScoringProfileRepository.On("FindByVersion", models.DefaultScoringProfileVersion).Return(models.ScoringProfile{}, gorm.ErrRecordNotFound)
+	mockScoringProfileRepository.On("Create", mock.AnythingOfType("*models.ScoringProfile")).Return(nil)
+	mockScoringProfileRepository.On("FindActive").Return(models.ScoringProfile{}, gorm.ErrRecordNotFound)
+	mockScoringProfileRepository.On("Activate", models.DefaultScoringProfileVersion).Return(models.ScoringProfile{}, nil)
+
+	// Call the method under test
+	err := scoringService.EnsureDefaultProfile()
+
+	// Check the result: the stored default rules match DefaultScoringRules
+	assert.NoError(t, err)
+	mockScoringProfileRepository.AssertExpectations(t)
+
+	created := mockScoringProfileRepository.Calls[1].Arguments.Get(0).(*models.ScoringProfile)
+	var rules types.ScoringRules
+	assert.NoError(t, json.Unmarshal([]byte(created.Rules), &rules))
+	assert.Equal(t, services.DefaultScoringRules(), rules)
+}
+
+func TestCreateScoringProfile_Exists(t *testing.T) {
+	// Mock ScoringProfileRepository
+	mockScoringProfileRepository := new(MockScoringProfileRepository)
+	scoringService := services.NewScoringService(mockScoringProfileRepository)
+
+	// Set up expectations for the mock repository
+	mockScoringProfileRepository.On("FindByVersion", "v1").Return(newProfile(t, "v1", flatRules(), true), nil)
+
+	// Call the method under test
+	_, err := scoringService.CreateScoringProfile(types.RequestScoringProfile{Version: "v1", Rules: flatRules()})
+
+	// Check the result: existing versions are never overwritten
+	assert.ErrorIs(t, err, services.ErrScoringProfileExists)
+	mockScoringProfileRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRequestScoringProfile_Validate(t *testing.T) {
+	// Create a profile with tiers in the wrong order
+	input := types.RequestScoringProfile{
+		Version: "v2",
+		Rules: types.ScoringRules{
+			Normal: []types.ScoreTier{{MinConfidence: 90, Weight: 0.9}, {MinConfidence: 95, Weight: 1}},
+		},
+	}
+
+	// Call the method under test and check the result
+	assert.Error(t, input.Validate())
+
+	input.Rules.Normal[1].MinConfidence = 80
+	assert.NoError(t, input.Validate())
+
+	input.Rules.AbnormalDefault = 1.5
+	assert.Error(t, input.Validate())
+}
+
+func TestPredict_RecordsScoringProfile(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil, ScoringProfileRepository
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+	mockScoringProfileRepository := new(MockScoringProfileRepository)
+
+	// Create ReportService scoring with an active "v2" profile
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	reportService.Scoring = services.NewScoringService(mockScoringProfileRepository)
+
+	// Set up expectations for the mock repositories
+	mockScoringProfileRepository.On("FindActive").Return(newProfile(t, "v2", flatRules(), true), nil)
+	mockReportRepository.On("Save", mock.AnythingOfType("*models.Report")).Return(&models.Report{ID: 1}, nil)
+
+	// Call the service
+	_, err := reportService.Predict(context.Background(), usermodel.User{ID: 1}, types.RequestAnalysis{VideoURL: "test", Type: services.AnalysisTypeSideSitting})
+	assert.NoError(t, err)
+
+	// Check the results: the report is scored with v2 and says so
+	saved := mockReportRepository.Calls[0].Arguments.Get(0).(*models.Report)
+	assert.Equal(t, "v2", saved.ScoringProfileVersion)
+	assert.Equal(t, "100.00", saved.Score)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/types"
+	"math"
+)
+
+// ReportTimeseries returns one metric of every frame of the report, timed by spreading the frames
+// evenly over the analysis time.
+func ReportTimeseries(report models.Report, metric string) []types.ResponseTimeseriesPoint {
+	series := make([]types.ResponseTimeseriesPoint, 0, len(report.Frames))
+	for _, frame := range report.Frames {
+		value, ok := frameMetric(frame, metric)
+		if !ok {
+			continue
+		}
+
+		series = append(series, types.ResponseTimeseriesPoint{
+			T:     roundScore(float64(frame.FrameIndex) * float64(report.AnalysisTime) / float64(len(report.Frames))),
+			Frame: frame.FrameIndex,
+			Value: value,
+		})
+	}
+
+	return series
+}
+
+func frameMetric(frame models.ReportFrame, metric string) (float64, bool) {
+	switch {
+	case metric == types.TimeseriesMetricAngle && frame.Angle != nil:
+		return *frame.Angle, true
+	case metric == types.TimeseriesMetricDistance && frame.Distance != nil:
+		return *frame.Distance, true
+	case metric == types.TimeseriesMetricClass && frame.Class != nil:
+		return float64(*frame.Class), true
+	default:
+		return 0, false
+	}
+}
+
+// LTTB downsamples series to threshold points with Largest-Triangle-Three-Buckets: the first and last
+// points are kept, and from every bucket in between the point spanning the largest triangle with its
+// neighbours, so peaks survive. Frames are the x axis.
+func LTTB(series []types.ResponseTimeseriesPoint, threshold int) []types.ResponseTimeseriesPoint {
+	if threshold < 3 || len(series) <= threshold {
+		return series
+	}
+
+	sampled := make([]types.ResponseTimeseriesPoint, 0, threshold)
+	sampled = append(sampled, series[0])
+
+	size := float64(len(series)-2) / float64(threshold-2)
+	selected := 0
+	for i := 0; i < threshold-2; i++ {
+		start := int(float64(i)*size) + 1
+		end := int(float64(i+1)*size) + 1
+
+		// The third vertex is the average of the next bucket, or the last point for the last bucket.
+		nextEnd := min(int(float64(i+2)*size)+1, len(series))
+		if i == threshold-3 {
+			end, nextEnd = len(series)-1, len(series)
+		}
+		avgX, avgY := 0.0, 0.0
+		for _, point := range series[end:nextEnd] {
+			avgX += float64(point.Frame)
+			avgY += point.Value
+		}
+		avgX /= float64(nextEnd - end)
+		avgY /= float64(nextEnd - end)
+
+		a := series[selected]
+		maxArea := -1.0
+		for j := start; j < end; j++ {
+			area := math.Abs((float64(a.Frame)-avgX)*(series[j].Value-a.Value) - (float64(a.Frame)-float64(series[j].Frame))*(avgY-a.Value))
+			if area > maxArea {
+				maxArea = area
+				selected = j
+			}
+		}
+		sampled = append(sampled, series[selected])
+	}
+
+	return append(sampled, series[len(series)-1])
+}
+
+// BucketSeries downsamples series to at most buckets points, each averaging a run of consecutive
+// points with their minimum and maximum.
+func BucketSeries(series []types.ResponseTimeseriesPoint, buckets int) []types.ResponseTimeseriesPoint {
+	if buckets < 1 || len(series) == 0 {
+		return []types.ResponseTimeseriesPoint{}
+	}
+	buckets = min(buckets, len(series))
+
+	sampled := make([]types.ResponseTimeseriesPoint, 0, buckets)
+	for i := 0; i < buckets; i++ {
+		bucket := series[i*len(series)/buckets : (i+1)*len(series)/buckets]
+
+		low, high, sum := math.Inf(1), math.Inf(-1), 0.0
+		for _, point := range bucket {
+			low = math.Min(low, point.Value)
+			high = math.Max(high, point.Value)
+			sum += point.Value
+		}
+
+		sampled = append(sampled, types.ResponseTimeseriesPoint{
+			T:     bucket[0].T,
+			Frame: bucket[0].Frame,
+			Value: roundScore(sum / float64(len(bucket))),
+			Min:   floatPtr(low),
+			Max:   floatPtr(high),
+		})
+	}
+
+	return sampled
+}
@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Create a series of count frames with a single peak at frame peak.
+func peakSeries(count int, peak int) []types.ResponseTimeseriesPoint {
+	series := make([]types.ResponseTimeseriesPoint, 0, count)
+	for i := 0; i < count; i++ {
+		value := 10.0
+		if i == peak {
+			value = 80
+		}
+		series = append(series, types.ResponseTimeseriesPoint{T: float64(i), Frame: i, Value: value})
+	}
+
+	return series
+}
+
+func TestLTTB(t *testing.T) {
+	// Set up a long series with one spike
+	series := peakSeries(1000, 537)
+
+	// Call the function under test
+	sampled := services.LTTB(series, 50)
+
+	// Check the results: the size is bounded, the ends are kept and the spike survives
+	assert.Len(t, sampled, 50)
+	assert.Equal(t, 0, sampled[0].Frame)
+	assert.Equal(t, 999, sampled[49].Frame)
+
+	spike := false
+	for i, point := range sampled {
+		spike = spike || point.Frame == 537
+		if i > 0 {
+			assert.Greater(t, point.Frame, sampled[i-1].Frame)
+		}
+	}
+	assert.True(t, spike)
+
+	// Short series are returned as is
+	assert.Len(t, services.LTTB(series[:20], 50), 20)
+}
+
+func TestBucketSeries(t *testing.T) {
+	// Set up ten frames split into two buckets, the spike in the second
+	series := peakSeries(10, 7)
+
+	// Call the function under test
+	sampled := services.BucketSeries(series, 2)
+
+	// Check the results
+	assert.Len(t, sampled, 2)
+	assert.Equal(t, 5, sampled[1].Frame)
+	assert.Equal(t, 10.0, sampled[0].Value)
+	assert.Equal(t, 24.0, sampled[1].Value)
+	assert.Equal(t, 10.0, *sampled[1].Min)
+	assert.Equal(t, 80.0, *sampled[1].Max)
+}
+
+func TestRequestTimeseries_Validate(t *testing.T) {
+	assert.NoError(t, (&types.RequestTimeseries{Metric: "angle"}).Validate())
+	assert.NoError(t, (&types.RequestTimeseries{Metric: "class", Points: 200, Method: "bucket"}).Validate())
+	assert.Error(t, (&types.RequestTimeseries{Metric: "score"}).Validate())
+	assert.Error(t, (&types.RequestTimeseries{Metric: "angle", Points: 2}).Validate())
+	assert.Error(t, (&types.RequestTimeseries{Metric: "angle", Method: "median"}).Validate())
+}
+
+func TestFindTimeseriesByUserID(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Create a 40 second report of four frames, the last one without landmarks
+	normal, abnormal := 1, 0
+	upright, leaning, hunched := 12.0, 30.0, 33.0
+	report := models.Report{ID: 1, UserID: 1, AnalysisTime: 40, Frames: []models.ReportFrame{
+		{FrameIndex: 0, Class: &normal, Angle: &upright},
+		{FrameIndex: 1, Class: &abnormal, Angle: &leaning},
+		{FrameIndex: 2, Class: &abnormal, Angle: &hunched},
+		{FrameIndex: 3, Class: &normal},
+	}}
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindByIdWithFrames", uint(1)).Return(report, nil)
+
+	// Call the service
+	angles, err := reportService.FindTimeseriesByUserID(1, "1", types.RequestTimeseries{Metric: types.TimeseriesMetricAngle})
+	assert.NoError(t, err)
+	classes, err := reportService.FindTimeseriesByUserID(1, "1", types.RequestTimeseries{Metric: types.TimeseriesMetricClass, Points: 3})
+	assert.NoError(t, err)
+
+	// Check the results: angles are not downsampled below 200 points and skip the frame without one
+	assert.Equal(t, types.TimeseriesMethodLTTB, angles.Method)
+	assert.Equal(t, 4, angles.TotalFrames)
+	assert.Equal(t, []types.ResponseTimeseriesPoint{{T: 0, Frame: 0, Value: 12}, {T: 10, Frame: 1, Value: 30}, {T: 20, Frame: 2, Value: 33}}, angles.Points)
+
+	// Classes are bucketed by default
+	assert.Equal(t, types.TimeseriesMethodBucket, classes.Method)
+	assert.Len(t, classes.Points, 3)
+	assert.Equal(t, 0.5, classes.Points[2].Value)
+	assert.Equal(t, 20.0, classes.Points[2].T)
+}
+
+func TestFindTimeseriesByUserID_OtherUser(t *testing.T) {
+	// Mock ReportRepository, AnalysisJobRepository, UserUtil
+	mockReportRepository := new(MockReportRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	// Create ReportService
+	reportService := services.NewReportService(mockReportRepository, mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+
+	// Set up expectations for the mock repository
+	mockReportRepository.On("FindByIdWithFrames", uint(2)).Return(models.Report{ID: 2, UserID: 3}, nil)
+
+	// Call the service
+	_, err := reportService.FindTimeseriesByUserID(1, "2", types.RequestTimeseries{Metric: types.TimeseriesMetricAngle})
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrReportNotFound)
+}
@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/repositories"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"gdsc/baro/global/storage"
+	"gdsc/baro/global/utils"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultMaxUploadSize = 512 << 20
+	DefaultUploadURLTTL  = 15 * time.Minute
+	DefaultReadURLTTL    = time.Hour
+)
+
+var (
+	ErrUploadTooLarge         = errors.New("video is larger than the upload limit")
+	ErrUnsupportedContentType = errors.New("only video uploads are allowed")
+	ErrUploadOffsetMismatch   = errors.New("upload offset does not match the bytes received so far")
+	ErrUploadCompleted        = errors.New("upload is already completed")
+	ErrEmptyChunk             = errors.New("upload chunk is empty")
+	ErrVideoURLNotIssued      = errors.New("video_url was not issued by this server")
+	ErrVideoNotUploaded       = errors.New("video has not been uploaded yet")
+	ErrUploadTokenUsed        = errors.New("upload URL has already been used")
+)
+
+type UploadServiceInterface interface {
+	UploadAnalysis(c *gin.Context, video io.Reader, contentType string, input types.RequestUploadAnalysis) (types.ResponseAnalysisJob, error)
+	UploadAnalysisForUser(ctx context.Context, user *usermodel.User, video io.Reader, contentType string, input types.RequestUploadAnalysis) (types.ResponseAnalysisJob, error)
+	CreateUploadSession(c *gin.Context, input types.RequestUploadSession) (types.ResponseUploadSession, error)
+	FindUploadSession(c *gin.Context, id string) (types.ResponseUploadSession, error)
+	UploadChunk(c *gin.Context, id string, offset int64, chunk io.Reader) (types.ResponseUploadSession, error)
+	IssueUploadURL(c *gin.Context, input types.RequestUploadURL) (types.ResponseUploadURL, error)
+	ReceiveSignedUpload(ctx context.Context, signedToken string, contentType string, video io.Reader) (types.ResponseUploadedVideo, error)
+	VerifyVideoURL(ctx context.Context, userID uint, videoURL string) error
+	ReadURL(videoURL string) (string, error)
+}
+
+// UploadService stores uploaded videos in the BlobStore and queues an analysis of the stored object.
+// It also issues signed upload URLs, so the app can upload without passing the bytes through the API.
+// Stores without their own signed URLs are uploaded to UploadURLBase, verified by ReceiveSignedUpload.
+type UploadService struct {
+	UploadSessionRepository repositories.UploadSessionRepositoryInterface
+	ReportService           ReportServiceInterface
+	BlobStore               storage.BlobStore
+	UserUtil                utils.UserUtilInterface
+	MaxSize                 int64
+	UploadURLBase           string
+	UploadTokenSecret       string
+	UploadURLTTL            time.Duration
+	// ReadURLTTL is how long the AI server may download a stored video with the URL of ReadURL.
+	ReadURLTTL time.Duration
+}
+
+func NewUploadService(uploadSessionRepository repositories.UploadSessionRepositoryInterface, reportService ReportServiceInterface, blobStore storage.BlobStore, userUtil utils.UserUtilInterface) *UploadService {
+	return &UploadService{
+		UploadSessionRepository: uploadSessionRepository,
+		ReportService:           reportService,
+		BlobStore:               blobStore,
+		UserUtil:                userUtil,
+		MaxSize:                 DefaultMaxUploadSize,
+		UploadURLTTL:            DefaultUploadURLTTL,
+		ReadURLTTL:              DefaultReadURLTTL,
+	}
+}
+
+func (service *UploadService) UploadAnalysis(c *gin.Context, video io.Reader, contentType string, input types.RequestUploadAnalysis) (types.ResponseAnalysisJob, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	return service.UploadAnalysisForUser(c, user, video, contentType, input)
+}
+
+// UploadAnalysisForUser stores the whole video from one stream and queues its analysis.
+// The stored video is removed again if the job cannot be queued.
+func (service *UploadService) UploadAnalysisForUser(ctx context.Context, user *usermodel.User, video io.Reader, contentType string, input types.RequestUploadAnalysis) (types.ResponseAnalysisJob, error) {
+	if !isVideo(contentType) {
+		return types.ResponseAnalysisJob{}, ErrUnsupportedContentType
+	}
+	if err := service.ReportService.CheckAnalysisType(input.Type, AnalysisSourceVideo, input.AnalysisTime); err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	key := videoKey(user.ID, contentType)
+	if err := service.BlobStore.Put(ctx, key, &limitedReader{r: video, remaining: service.MaxSize}, contentType); err != nil {
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	job, err := service.submit(user, key, input.AlertCount, input.AnalysisTime, input.Type)
+	if err != nil {
+		service.BlobStore.Delete(ctx, key)
+		return types.ResponseAnalysisJob{}, err
+	}
+
+	return job, nil
+}
+
+func (service *UploadService) CreateUploadSession(c *gin.Context, input types.RequestUploadSession) (types.ResponseUploadSession, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	if !isVideo(input.ContentType) {
+		return types.ResponseUploadSession{}, ErrUnsupportedContentType
+	}
+	if input.Size > service.MaxSize {
+		return types.ResponseUploadSession{}, ErrUploadTooLarge
+	}
+	if err := service.ReportService.CheckAnalysisType(input.Type, AnalysisSourceVideo, input.AnalysisTime); err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	session := models.UploadSession{
+		ID:           uuid.NewString(),
+		UserID:       user.ID,
+		Size:         input.Size,
+		ContentType:  input.ContentType,
+		ObjectKey:    videoKey(user.ID, input.ContentType),
+		AlertCount:   input.AlertCount,
+		AnalysisTime: input.AnalysisTime,
+		Type:         input.Type,
+		Status:       models.UploadStatusPending,
+	}
+
+	savedSession, err := service.UploadSessionRepository.Create(&session)
+	if err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	return toResponseUploadSession(savedSession), nil
+}
+
+func (service *UploadService) FindUploadSession(c *gin.Context, id string) (types.ResponseUploadSession, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	session, err := service.UploadSessionRepository.FindByIdAndUserID(id, user.ID)
+	if err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	return toResponseUploadSession(session), nil
+}
+
+// UploadChunk appends the chunk starting at offset to the session. offset must equal the bytes received so far,
+// so a client that lost a response asks FindUploadSession where to resume.
+// When the last byte arrives the parts are joined and the analysis is queued. If queueing fails,
+// sending an empty chunk at offset Size retries it.
+func (service *UploadService) UploadChunk(c *gin.Context, id string, offset int64, chunk io.Reader) (types.ResponseUploadSession, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	session, err := service.UploadSessionRepository.FindByIdAndUserID(id, user.ID)
+	if err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	if session.Status == models.UploadStatusCompleted {
+		return types.ResponseUploadSession{}, ErrUploadCompleted
+	}
+	if offset != session.Received {
+		return types.ResponseUploadSession{}, ErrUploadOffsetMismatch
+	}
+
+	if session.Received < session.Size {
+		if err := service.storeChunk(c, &session, chunk); err != nil {
+			return types.ResponseUploadSession{}, err
+		}
+	}
+
+	if session.Received < session.Size {
+		return toResponseUploadSession(session), nil
+	}
+
+	if err := service.completeUpload(c, user, &session); err != nil {
+		return types.ResponseUploadSession{}, err
+	}
+
+	return toResponseUploadSession(session), nil
+}
+
+// IssueUploadURL returns a short-lived URL the current user can PUT one video of at most input.Size bytes to.
+func (service *UploadService) IssueUploadURL(c *gin.Context, input types.RequestUploadURL) (types.ResponseUploadURL, error) {
+	user, err := service.UserUtil.FindCurrentUser(c)
+	if err != nil {
+		return types.ResponseUploadURL{}, err
+	}
+
+	if !isVideo(input.ContentType) {
+		return types.ResponseUploadURL{}, ErrUnsupportedContentType
+	}
+	if input.Size > service.MaxSize {
+		return types.ResponseUploadURL{}, ErrUploadTooLarge
+	}
+
+	key := videoKey(user.ID, input.ContentType)
+	expiresAt := time.Now().Add(service.UploadURLTTL)
+	response := types.ResponseUploadURL{
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": input.ContentType},
+		VideoURL:  service.BlobStore.URL(key),
+		ExpiresAt: expiresAt,
+	}
+
+	if signer, ok := service.BlobStore.(storage.SignedUploader); ok {
+		uploadURL, headers, err := signer.SignedUploadURL(key, input.ContentType, input.Size, expiresAt)
+		if err != nil {
+			return types.ResponseUploadURL{}, err
+		}
+
+		response.UploadURL = uploadURL
+		for name := range headers {
+			response.Headers[name] = headers.Get(name)
+		}
+		return response, nil
+	}
+
+	token, err := storage.SignUploadToken(service.UploadTokenSecret, storage.UploadToken{
+		Key:         key,
+		UserID:      user.ID,
+		ContentType: input.ContentType,
+		MaxSize:     input.Size,
+		ExpiresAt:   expiresAt.Unix(),
+	})
+	if err != nil {
+		return types.ResponseUploadURL{}, err
+	}
+
+	response.UploadURL = strings.TrimRight(service.UploadURLBase, "/") + "/" + token
+	return response, nil
+}
+
+// ReceiveSignedUpload stores a video sent to an upload URL issued by IssueUploadURL.
+// The token is the only credential, so everything it allows is checked here, and it uploads only once.
+func (service *UploadService) ReceiveSignedUpload(ctx context.Context, signedToken string, contentType string, video io.Reader) (types.ResponseUploadedVideo, error) {
+	token, err := storage.ParseUploadToken(service.UploadTokenSecret, signedToken, time.Now())
+	if err != nil {
+		return types.ResponseUploadedVideo{}, err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != token.ContentType {
+		return types.ResponseUploadedVideo{}, ErrUnsupportedContentType
+	}
+
+	// The token stays valid until it expires, so the first upload claims it and later ones are rejected.
+	claimed, err := service.UploadSessionRepository.ClaimUploadToken(token.Key, time.Unix(token.ExpiresAt, 0))
+	if err != nil {
+		return types.ResponseUploadedVideo{}, err
+	}
+	if !claimed {
+		return types.ResponseUploadedVideo{}, ErrUploadTokenUsed
+	}
+
+	if err := service.BlobStore.Put(ctx, token.Key, &limitedReader{r: video, remaining: token.MaxSize}, token.ContentType); err != nil {
+		// A failed upload can be retried with the same URL
+		service.UploadSessionRepository.ReleaseUploadToken(token.Key)
+		return types.ResponseUploadedVideo{}, err
+	}
+
+	return types.ResponseUploadedVideo{VideoURL: service.BlobStore.URL(token.Key)}, nil
+}
+
+// VerifyVideoURL accepts only URLs of videos this server issued to the user and that have been uploaded.
+func (service *UploadService) VerifyVideoURL(ctx context.Context, userID uint, videoURL string) error {
+	base := service.BlobStore.URL("")
+	name, ok := strings.CutPrefix(videoURL, service.BlobStore.URL(fmt.Sprintf("videos/%d/", userID)))
+	if !ok || name == "" || strings.ContainsAny(name, "/?#%\\") {
+		return ErrVideoURLNotIssued
+	}
+
+	exists, err := service.BlobStore.Exists(ctx, strings.TrimPrefix(videoURL, base))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrVideoNotUploaded
+	}
+
+	return nil
+}
+
+// ReadURL returns the URL the AI server downloads videoURL from: a signed URL for videos in a store
+// that signs them, or videoURL itself for other videos.
+func (service *UploadService) ReadURL(videoURL string) (string, error) {
+	signer, ok := service.BlobStore.(storage.SignedReader)
+	key, stored := strings.CutPrefix(videoURL, service.BlobStore.URL(""))
+	if !ok || !stored {
+		return videoURL, nil
+	}
+
+	return signer.SignedReadURL(key, time.Now().Add(service.ReadURLTTL))
+}
+
+func (service *UploadService) storeChunk(ctx context.Context, session *models.UploadSession, chunk io.Reader) error {
+	// The random suffix keeps a concurrent upload of the same offset from overwriting the accepted part.
+	partKey := fmt.Sprintf("uploads/%s/%d-%s", session.ID, session.Received, uuid.NewString())
+	reader := &limitedReader{r: chunk, remaining: session.Size - session.Received}
+
+	if err := service.BlobStore.Put(ctx, partKey, reader, session.ContentType); err != nil {
+		return err
+	}
+	if reader.read == 0 {
+		service.BlobStore.Delete(ctx, partKey)
+		return ErrEmptyChunk
+	}
+
+	from := session.Received
+	session.Received += reader.read
+	session.PartKeys = strings.TrimPrefix(session.PartKeys+"\n"+partKey, "\n")
+
+	accepted, err := service.UploadSessionRepository.Advance(session, from)
+	if err != nil || !accepted {
+		service.BlobStore.Delete(ctx, partKey)
+	}
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return ErrUploadOffsetMismatch
+	}
+
+	return nil
+}
+
+// completeUpload joins the parts into the final object, queues the analysis and only then removes the parts,
+// so every step can be retried until the job is queued.
+func (service *UploadService) completeUpload(ctx context.Context, user *usermodel.User, session *models.UploadSession) error {
+	parts := strings.Split(session.PartKeys, "\n")
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(service.copyParts(ctx, writer, parts))
+	}()
+
+	err := service.BlobStore.Put(ctx, session.ObjectKey, reader, session.ContentType)
+	reader.CloseWithError(err)
+	if err != nil {
+		return err
+	}
+
+	job, err := service.submit(user, session.ObjectKey, session.AlertCount, session.AnalysisTime, session.Type)
+	if err != nil {
+		return err
+	}
+
+	session.Status = models.UploadStatusCompleted
+	session.JobID = &job.ID
+	if _, err := service.UploadSessionRepository.Update(session); err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		if err := service.BlobStore.Delete(ctx, part); err != nil {
+			log.Printf("failed to delete upload part %s: %v", part, err)
+		}
+	}
+
+	return nil
+}
+
+func (service *UploadService) copyParts(ctx context.Context, w io.Writer, parts []string) error {
+	for _, part := range parts {
+		r, err := service.BlobStore.Open(ctx, part)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(w, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (service *UploadService) submit(user *usermodel.User, key string, alertCount int, analysisTime int, analysisType string) (types.ResponseAnalysisJob, error) {
+	return service.ReportService.SubmitAnalysis(user, types.RequestAnalysis{
+		VideoURL:     service.BlobStore.URL(key),
+		AlertCount:   alertCount,
+		AnalysisTime: analysisTime,
+		Type:         analysisType,
+	})
+}
+
+func toResponseUploadSession(session models.UploadSession) types.ResponseUploadSession {
+	return types.ResponseUploadSession{
+		ID:        session.ID,
+		Size:      session.Size,
+		Received:  session.Received,
+		Status:    session.Status,
+		JobID:     session.JobID,
+		CreatedAt: session.CreatedAt,
+	}
+}
+
+func isVideo(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && strings.HasPrefix(mediaType, "video/")
+}
+
+// videoKey returns a new unguessable key for a user's video.
+func videoKey(userID uint, contentType string) string {
+	ext := ""
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+
+	return fmt.Sprintf("videos/%d/%s%s", userID, uuid.NewString(), ext)
+}
+
+// limitedReader fails with ErrUploadTooLarge once more than remaining bytes were read.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	read      int64
+}
+
+func (reader *limitedReader) Read(p []byte) (int, error) {
+	n, err := reader.r.Read(p)
+	reader.read += int64(n)
+	reader.remaining -= int64(n)
+	if reader.remaining < 0 {
+		return n, ErrUploadTooLarge
+	}
+
+	return n, err
+}
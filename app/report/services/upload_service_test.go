@@ -0,0 +1,413 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"gdsc/baro/app/report/analyzers"
+	"gdsc/baro/app/report/models"
+	"gdsc/baro/app/report/services"
+	"gdsc/baro/app/report/types"
+	usermodel "gdsc/baro/app/user/models"
+	"gdsc/baro/global/storage"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUploadSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockUploadSessionRepository) Create(session *models.UploadSession) (models.UploadSession, error) {
+	args := m.Called(session)
+	return *session, args.Error(0)
+}
+
+func (m *MockUploadSessionRepository) FindByIdAndUserID(id string, userID uint) (models.UploadSession, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadSessionRepository) Advance(session *models.UploadSession, fromReceived int64) (bool, error) {
+	args := m.Called(session, fromReceived)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUploadSessionRepository) Update(session *models.UploadSession) (models.UploadSession, error) {
+	args := m.Called(session)
+	return *session, args.Error(0)
+}
+
+func (m *MockUploadSessionRepository) ClaimUploadToken(key string, expiresAt time.Time) (bool, error) {
+	args := m.Called(key, expiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUploadSessionRepository) ReleaseUploadToken(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+// newUploadService returns an UploadService storing into a temporary directory,
+// backed by a real ReportService with mocked repositories.
+func newUploadService(t *testing.T) (*services.UploadService, *MockUploadSessionRepository, *MockAnalysisJobRepository, *MockUserUtil, string) {
+	root := t.TempDir()
+	store, err := storage.NewLocalStore(root, "http://localhost:8080/files")
+	if err != nil {
+		t.Fatalf("Error creating local store: %v", err)
+	}
+
+	mockUploadSessionRepository := new(MockUploadSessionRepository)
+	mockAnalysisJobRepository := new(MockAnalysisJobRepository)
+	mockUserUtil := new(MockUserUtil)
+
+	reportService := services.NewReportService(new(MockReportRepository), mockAnalysisJobRepository, analyzers.NewFakeAnalyzer(), mockUserUtil)
+	uploadService := services.NewUploadService(mockUploadSessionRepository, reportService, store, mockUserUtil)
+
+	return uploadService, mockUploadSessionRepository, mockAnalysisJobRepository, mockUserUtil, root
+}
+
+func TestUploadAnalysis(t *testing.T) {
+	// Create UploadService
+	uploadService, _, mockAnalysisJobRepository, mockUserUtil, root := newUploadService(t)
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusQueued).Return(int64(0), nil)
+	mockAnalysisJobRepository.On("Create", mock.AnythingOfType("*models.AnalysisJob")).Return(&models.AnalysisJob{ID: 5, Status: models.JobStatusQueued}, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	input := types.RequestUploadAnalysis{AlertCount: 1, AnalysisTime: 60, Type: services.AnalysisTypeSideSitting}
+	job, err := uploadService.UploadAnalysis(c, strings.NewReader("video-bytes"), "video/mp4", input)
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results: the job points at the stored video
+	assert.Equal(t, uint(5), job.ID)
+	queued := mockAnalysisJobRepository.Calls[1].Arguments.Get(0).(*models.AnalysisJob)
+	assert.True(t, strings.HasPrefix(queued.VideoURL, "http://localhost:8080/files/videos/1/"))
+	assert.Equal(t, services.AnalysisTypeSideSitting, queued.Type)
+
+	key := strings.TrimPrefix(queued.VideoURL, "http://localhost:8080/files/")
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(key)))
+	assert.NoError(t, err)
+	assert.Equal(t, "video-bytes", string(data))
+}
+
+func TestUploadAnalysis_TooLarge(t *testing.T) {
+	// Create UploadService with a tiny limit
+	uploadService, _, mockAnalysisJobRepository, mockUserUtil, root := newUploadService(t)
+	uploadService.MaxSize = 4
+
+	// Set up expectations for the mock util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	input := types.RequestUploadAnalysis{Type: services.AnalysisTypeSideSitting}
+	_, err := uploadService.UploadAnalysis(c, strings.NewReader("video-bytes"), "video/mp4", input)
+
+	// Check the results: nothing is stored or queued
+	assert.ErrorIs(t, err, services.ErrUploadTooLarge)
+	mockAnalysisJobRepository.AssertNotCalled(t, "Create", mock.Anything)
+	entries, _ := os.ReadDir(filepath.Join(root, "videos", "1"))
+	assert.Empty(t, entries)
+}
+
+func TestUploadAnalysis_NotVideo(t *testing.T) {
+	// Create UploadService
+	uploadService, _, _, mockUserUtil, _ := newUploadService(t)
+
+	// Set up expectations for the mock util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	_, err := uploadService.UploadAnalysis(c, strings.NewReader("<html>"), "text/html", types.RequestUploadAnalysis{Type: services.AnalysisTypeSideSitting})
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrUnsupportedContentType)
+}
+
+func TestUploadChunk_Resumable(t *testing.T) {
+	// Create UploadService
+	uploadService, mockUploadSessionRepository, mockAnalysisJobRepository, mockUserUtil, root := newUploadService(t)
+
+	// Set up a session for a 10 byte video
+	session := models.UploadSession{
+		ID:          "abc",
+		UserID:      1,
+		Size:        10,
+		ContentType: "video/mp4",
+		ObjectKey:   "videos/1/abc.mp4",
+		Type:        services.AnalysisTypeSideSitting,
+		Status:      models.UploadStatusPending,
+	}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockUploadSessionRepository.On("FindByIdAndUserID", "abc", uint(1)).Return(session, nil).Once()
+	mockUploadSessionRepository.On("Advance", mock.AnythingOfType("*models.UploadSession"), int64(0)).Return(true, nil)
+	mockAnalysisJobRepository.On("CountByStatus", models.JobStatusQueued).Return(int64(0), nil)
+	mockAnalysisJobRepository.On("Create", mock.AnythingOfType("*models.AnalysisJob")).Return(&models.AnalysisJob{ID: 9, Status: models.JobStatusQueued}, nil)
+	mockUploadSessionRepository.On("Update", mock.AnythingOfType("*models.UploadSession")).Return(nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Upload the first chunk
+	first, err := uploadService.UploadChunk(c, "abc", 0, strings.NewReader("video"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), first.Received)
+	assert.Equal(t, models.UploadStatusPending, first.Status)
+
+	// Upload the second chunk on top of the stored state
+	advanced := mockUploadSessionRepository.Calls[1].Arguments.Get(0).(*models.UploadSession)
+	mockUploadSessionRepository.On("FindByIdAndUserID", "abc", uint(1)).Return(*advanced, nil).Once()
+	mockUploadSessionRepository.On("Advance", mock.AnythingOfType("*models.UploadSession"), int64(5)).Return(true, nil)
+
+	second, err := uploadService.UploadChunk(c, "abc", 5, strings.NewReader("-data"))
+	assert.NoError(t, err)
+
+	// Assert that the expectations were met
+	mockUploadSessionRepository.AssertExpectations(t)
+	mockAnalysisJobRepository.AssertExpectations(t)
+
+	// Check the results: the parts were joined, queued and removed
+	assert.Equal(t, models.UploadStatusCompleted, second.Status)
+	assert.Equal(t, uint(9), *second.JobID)
+
+	data, err := os.ReadFile(filepath.Join(root, "videos", "1", "abc.mp4"))
+	assert.NoError(t, err)
+	assert.Equal(t, "video-data", string(data))
+
+	parts, _ := os.ReadDir(filepath.Join(root, "uploads", "abc"))
+	assert.Empty(t, parts)
+
+	queued := mockAnalysisJobRepository.Calls[1].Arguments.Get(0).(*models.AnalysisJob)
+	assert.Equal(t, "http://localhost:8080/files/videos/1/abc.mp4", queued.VideoURL)
+}
+
+func TestUploadChunk_OffsetMismatch(t *testing.T) {
+	// Create UploadService
+	uploadService, mockUploadSessionRepository, _, mockUserUtil, _ := newUploadService(t)
+
+	// Set up a session that already received 5 bytes
+	session := models.UploadSession{ID: "abc", UserID: 1, Size: 10, Received: 5, ContentType: "video/mp4", Status: models.UploadStatusPending}
+
+	// Set up expectations for the mock repository and util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockUploadSessionRepository.On("FindByIdAndUserID", "abc", uint(1)).Return(session, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service with a stale offset
+	_, err := uploadService.UploadChunk(c, "abc", 0, bytes.NewReader([]byte("video")))
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrUploadOffsetMismatch)
+	mockUploadSessionRepository.AssertNotCalled(t, "Advance", mock.Anything, mock.Anything)
+}
+
+func TestUploadChunk_LostRace(t *testing.T) {
+	// Create UploadService
+	uploadService, mockUploadSessionRepository, _, mockUserUtil, root := newUploadService(t)
+
+	// Set up a fresh session
+	session := models.UploadSession{ID: "abc", UserID: 1, Size: 10, ContentType: "video/mp4", Status: models.UploadStatusPending}
+
+	// Set up expectations: another request stored the same offset first
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockUploadSessionRepository.On("FindByIdAndUserID", "abc", uint(1)).Return(session, nil)
+	mockUploadSessionRepository.On("Advance", mock.AnythingOfType("*models.UploadSession"), int64(0)).Return(false, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	_, err := uploadService.UploadChunk(c, "abc", 0, strings.NewReader("video"))
+
+	// Check the results: the rejected part is removed
+	assert.ErrorIs(t, err, services.ErrUploadOffsetMismatch)
+	parts, _ := os.ReadDir(filepath.Join(root, "uploads", "abc"))
+	assert.Empty(t, parts)
+}
+
+func TestCreateUploadSession_TooLarge(t *testing.T) {
+	// Create UploadService
+	uploadService, mockUploadSessionRepository, _, mockUserUtil, _ := newUploadService(t)
+
+	// Set up expectations for the mock util
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	input := types.RequestUploadSession{Size: services.DefaultMaxUploadSize + 1, ContentType: "video/mp4", Type: services.AnalysisTypeSideSitting}
+	_, err := uploadService.CreateUploadSession(c, input)
+
+	// Check the results
+	assert.ErrorIs(t, err, services.ErrUploadTooLarge)
+	mockUploadSessionRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestIssueUploadURL_ReceiveSignedUpload(t *testing.T) {
+	// Create UploadService
+	uploadService, mockUploadSessionRepository, _, mockUserUtil, root := newUploadService(t)
+	uploadService.UploadURLBase = "http://localhost:8080/uploads"
+	uploadService.UploadTokenSecret = "secret"
+
+	// Set up expectations for the mock repository and util: the upload URL is claimed by its first upload
+	mockUserUtil.On("FindCurrentUser", mock.Anything).Return(&usermodel.User{ID: 1}, nil)
+	mockUploadSessionRepository.On("ClaimUploadToken", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	mockUploadSessionRepository.On("ClaimUploadToken", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	// Create a test context
+	c, _ := gin.CreateTestContext(nil)
+
+	// Call the service
+	issued, err := uploadService.IssueUploadURL(c, types.RequestUploadURL{ContentType: "video/mp4", Size: 11})
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT", issued.Method)
+	assert.Equal(t, "video/mp4", issued.Headers["Content-Type"])
+	assert.True(t, strings.HasPrefix(issued.VideoURL, "http://localhost:8080/files/videos/1/"))
+
+	// The video is not usable until it has been uploaded
+	assert.ErrorIs(t, uploadService.VerifyVideoURL(c, 1, issued.VideoURL), services.ErrVideoNotUploaded)
+
+	token := strings.TrimPrefix(issued.UploadURL, "http://localhost:8080/uploads/")
+	uploaded, err := uploadService.ReceiveSignedUpload(c, token, "video/mp4", strings.NewReader("video-bytes"))
+
+	// Check the results
+	assert.NoError(t, err)
+	assert.Equal(t, issued.VideoURL, uploaded.VideoURL)
+	key := strings.TrimPrefix(issued.VideoURL, "http://localhost:8080/files/")
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(key)))
+	assert.NoError(t, err)
+	assert.Equal(t, "video-bytes", string(data))
+	assert.NoError(t, uploadService.VerifyVideoURL(c, 1, issued.VideoURL))
+	assert.ErrorIs(t, uploadService.VerifyVideoURL(c, 2, issued.VideoURL), services.ErrVideoURLNotIssued)
+	mockUploadSessionRepository.AssertCalled(t, "ClaimUploadToken", key, mock.AnythingOfType("time.Time"))
+
+	// The upload URL cannot be used again, and the uploaded video is kept
+	_, err = uploadService.ReceiveSignedUpload(c, token, "video/mp4", strings.NewReader("other-bytes"))
+	assert.ErrorIs(t, err, services.ErrUploadTokenUsed)
+	data, err = os.ReadFile(filepath.Join(root, filepath.FromSlash(key)))
+	assert.NoError(t, err)
+	assert.Equal(t, "video-bytes", string(data))
+}
+
+func TestReceiveSignedUpload_Rejected(t *testing.T) {
+	// Create UploadService
+	uploadService, mockUploadSessionRepository, _, _, _ := newUploadService(t)
+	uploadService.UploadTokenSecret = "secret"
+
+	// Set up expectations for the mock repository: a failed upload gives its claim back
+	mockUploadSessionRepository.On("ClaimUploadToken", "videos/1/a.mp4", mock.AnythingOfType("time.Time")).Return(true, nil)
+	mockUploadSessionRepository.On("ReleaseUploadToken", "videos/1/a.mp4").Return(nil)
+
+	// Create tokens for the cases under test
+	sign := func(token storage.UploadToken) string {
+		signed, err := storage.SignUploadToken("secret", token)
+		assert.NoError(t, err)
+		return signed
+	}
+	valid := storage.UploadToken{Key: "videos/1/a.mp4", UserID: 1, ContentType: "video/mp4", MaxSize: 4, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	expired := valid
+	expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+
+	// Call the service and check the results
+	_, err := uploadService.ReceiveSignedUpload(context.Background(), sign(expired), "video/mp4", strings.NewReader("vid"))
+	assert.ErrorIs(t, err, storage.ErrUploadTokenExpired)
+
+	_, err = uploadService.ReceiveSignedUpload(context.Background(), sign(valid), "video/webm", strings.NewReader("vid"))
+	assert.ErrorIs(t, err, services.ErrUnsupportedContentType)
+
+	_, err = uploadService.ReceiveSignedUpload(context.Background(), sign(valid), "video/mp4", strings.NewReader("video-bytes"))
+	assert.ErrorIs(t, err, services.ErrUploadTooLarge)
+
+	exists, err := uploadService.BlobStore.Exists(context.Background(), valid.Key)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	mockUploadSessionRepository.AssertNumberOfCalls(t, "ClaimUploadToken", 1)
+	mockUploadSessionRepository.AssertCalled(t, "ReleaseUploadToken", valid.Key)
+}
+
+func TestReadURL(t *testing.T) {
+	// Create UploadService and let the ReportService sign video URLs with it
+	uploadService, _, mockAnalysisJobRepository, mockUserUtil, _ := newUploadService(t)
+	uploadService.BlobStore.(*storage.LocalStore).ReadSecret = "secret"
+	reportService := uploadService.ReportService.(*services.ReportService)
+	reportService.VideoReader = uploadService
+	reportService.CallbackURL = "https://api.example.com/internal/analysis/callback"
+
+	// Call the service with a stored video and an external one
+	stored, err := uploadService.ReadURL("http://localhost:8080/files/videos/1/a.mp4")
+	assert.NoError(t, err)
+	external, err := uploadService.ReadURL("https://example.com/video.mp4")
+	assert.NoError(t, err)
+
+	// Check the results: only stored videos are signed
+	assert.True(t, strings.HasPrefix(stored, "http://localhost:8080/files/videos/1/a.mp4?"))
+	assert.Contains(t, stored, "signature=")
+	assert.Equal(t, "https://example.com/video.mp4", external)
+
+	// The AI server is sent the signed URL, while the job keeps the unsigned one
+	job := &models.AnalysisJob{ID: 4, UserID: 1, VideoURL: "http://localhost:8080/files/videos/1/a.mp4", Status: models.JobStatusRunning}
+	mockUserUtil.On("FindUserByID", uint(1)).Return(&usermodel.User{ID: 1}, nil)
+	mockAnalysisJobRepository.On("TransitionAttempt", job.ID, 0, models.JobStatusRunning, models.JobStatusSubmitted).Return(true, nil)
+	assert.NoError(t, reportService.RunJob(context.Background(), job))
+
+	submitted := reportService.Analyzer.(*analyzers.FakeAnalyzer).Submitted()
+	assert.Len(t, submitted, 1)
+	assert.Contains(t, submitted[0].VideoURL, "signature=")
+	assert.Equal(t, "http://localhost:8080/files/videos/1/a.mp4", job.VideoURL)
+}
+
+func TestVerifyVideoURL_Foreign(t *testing.T) {
+	// Create UploadService
+	uploadService, _, _, _, _ := newUploadService(t)
+
+	// Call the service with URLs this server never issued
+	for _, videoURL := range []string{
+		"https://example.com/video.mp4",
+		"http://localhost:8080/files/videos/1/",
+		"http://localhost:8080/files/videos/1/../2/a.mp4",
+		"http://localhost:8080/files/videos/1/a.mp4?x=1",
+		"http://localhost:8080/files/videos/10/a.mp4",
+	} {
+		// Check the result
+		assert.ErrorIs(t, uploadService.VerifyVideoURL(context.Background(), 1, videoURL), services.ErrVideoURLNotIssued, videoURL)
+	}
+}
+
+func TestSubmitAnalysis_RejectsUnissuedVideoURL(t *testing.T) {
+	// Create UploadService and let the ReportService verify URLs with it
+	uploadService, _, mockAnalysisJobRepository, _, _ := newUploadService(t)
+	reportService := uploadService.ReportService.(*services.ReportService)
+	reportService.VideoURLs = uploadService
+
+	// Call the method under test
+	_, err := reportService.SubmitAnalysis(&usermodel.User{ID: 1}, types.RequestAnalysis{VideoURL: "https://example.com/video.mp4", Type: services.AnalysisTypeSideSitting})
+
+	// Check the result: nothing is queued
+	assert.ErrorIs(t, err, services.ErrVideoURLNotIssued)
+	mockAnalysisJobRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -1,6 +1,13 @@
 package types
 
-import "github.com/go-playground/validator/v10"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
 
 var validate *validator.Validate
 
@@ -16,7 +23,19 @@ type RequestAnalysis struct {
 }
 
 type RequestAnalysisToAi struct {
-	VideoURL string `json:"video_url"`
+	VideoURL    string `json:"video_url"`
+	JobID       uint   `json:"job_id,omitempty"`
+	Attempt     int    `json:"attempt,omitempty"`
+	CallbackURL string `json:"callback_url,omitempty"`
+}
+
+// RequestAnalysisCallback is posted by the AI server to /internal/analysis/callback.
+// JobID and Attempt echo the submitted request; either Result or Error is set.
+type RequestAnalysisCallback struct {
+	JobID   uint              `json:"job_id"`
+	Attempt int               `json:"attempt"`
+	Result  *ResponseAnalysis `json:"result"`
+	Error   string            `json:"error"`
 }
 
 type RequestReportSummary struct {
@@ -26,3 +45,164 @@ type RequestReportSummary struct {
 func (r *RequestAnalysis) Validate() error {
 	return validate.Struct(r)
 }
+
+// RequestUploadAnalysis holds the form fields sent with a multipart video upload.
+type RequestUploadAnalysis struct {
+	AlertCount   int    `form:"alert_count" json:"alert_count" validate:"min=0"`
+	AnalysisTime int    `form:"analysis_time" json:"analysis_time" validate:"min=0"`
+	Type         string `form:"type" json:"type" validate:"required"`
+}
+
+func (r *RequestUploadAnalysis) Validate() error {
+	return validate.Struct(r)
+}
+
+type RequestUploadSession struct {
+	Size         int64  `json:"size" validate:"min=1"`
+	ContentType  string `json:"content_type" validate:"required"`
+	AlertCount   int    `json:"alert_count" validate:"min=0"`
+	AnalysisTime int    `json:"analysis_time" validate:"min=0"`
+	Type         string `json:"type" validate:"required"`
+}
+
+func (r *RequestUploadSession) Validate() error {
+	return validate.Struct(r)
+}
+
+type RequestUploadURL struct {
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"min=1"`
+}
+
+func (r *RequestUploadURL) Validate() error {
+	return validate.Struct(r)
+}
+
+// ScoreTier weighs a frame whose confidence is at least MinConfidence.
+type ScoreTier struct {
+	MinConfidence float64 `json:"min_confidence"`
+	Weight        float64 `json:"weight" validate:"min=0,max=1"`
+}
+
+// ScoringRules weighs every frame by its class and confidence: the first tier the confidence reaches applies,
+// otherwise the default weight. A report scores the mean weight of its frames times 100.
+type ScoringRules struct {
+	Normal          []ScoreTier `json:"normal" validate:"dive"`
+	NormalDefault   float64     `json:"normal_default" validate:"min=0,max=1"`
+	Abnormal        []ScoreTier `json:"abnormal" validate:"dive"`
+	AbnormalDefault float64     `json:"abnormal_default" validate:"min=0,max=1"`
+}
+
+type RequestScoringProfile struct {
+	Version     string       `json:"version" validate:"required,max=32,excludesall=/?#"`
+	Description string       `json:"description"`
+	Rules       ScoringRules `json:"rules"`
+}
+
+func (r *RequestScoringProfile) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	for _, tiers := range [][]ScoreTier{r.Rules.Normal, r.Rules.Abnormal} {
+		for i := 1; i < len(tiers); i++ {
+			if tiers[i].MinConfidence >= tiers[i-1].MinConfidence {
+				return errors.New("score tiers must be ordered by descending min_confidence")
+			}
+		}
+	}
+
+	return nil
+}
+
+// MaxKeypointFrames bounds a keypoint analysis, about ten hours at one frame per second.
+const MaxKeypointFrames = 36000
+
+// KeypointFrame holds the landmarks the app estimated for one frame, normalized to the frame size.
+type KeypointFrame struct {
+	LeftShoulder Landmark `json:"left_shoulder"`
+	LeftEar      Landmark `json:"left_ear"`
+}
+
+// RequestKeypointAnalysis is analyzed on the server from landmarks instead of a video.
+type RequestKeypointAnalysis struct {
+	AlertCount   int             `json:"alert_count" validate:"min=0"`
+	AnalysisTime int             `json:"analysis_time" validate:"min=0"`
+	Type         string          `json:"type" validate:"required"`
+	Frames       []KeypointFrame `json:"frames"`
+}
+
+func (r *RequestKeypointAnalysis) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.Frames) == 0 {
+		return errors.New("frames must not be empty")
+	}
+	if len(r.Frames) > MaxKeypointFrames {
+		return fmt.Errorf("frames must not exceed %d", MaxKeypointFrames)
+	}
+
+	for i, frame := range r.Frames {
+		if err := frame.Validate(); err != nil {
+			return fmt.Errorf("frames[%d] %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (f KeypointFrame) Validate() error {
+	for _, value := range []float64{f.LeftShoulder.X, f.LeftShoulder.Y, f.LeftEar.X, f.LeftEar.Y} {
+		if math.IsNaN(value) || value < 0 || value > 1 {
+			return errors.New("must have coordinates between 0 and 1")
+		}
+	}
+
+	return nil
+}
+
+// MaxAlertEvents bounds how many alert events one request may record.
+const MaxAlertEvents = 1000
+
+type RequestAlertEvent struct {
+	Type             string    `json:"type" validate:"required,max=32"`
+	Severity         string    `json:"severity" validate:"required,oneof=low medium high"`
+	OccurredAt       time.Time `json:"occurred_at" validate:"required"`
+	CorrectedAfterMs *int64    `json:"corrected_after_ms" validate:"omitempty,min=0"`
+}
+
+// RequestAlertEvents records the alerts raised during the session a report covers.
+type RequestAlertEvents struct {
+	Events []RequestAlertEvent `json:"events" validate:"required,min=1,max=1000,dive"`
+}
+
+func (r *RequestAlertEvents) Validate() error {
+	return validate.Struct(r)
+}
+
+// Metrics and downsampling methods of a report time series.
+const (
+	TimeseriesMetricAngle    = "angle"
+	TimeseriesMetricDistance = "distance"
+	TimeseriesMetricClass    = "class"
+
+	TimeseriesMethodLTTB   = "lttb"
+	TimeseriesMethodBucket = "bucket"
+
+	DefaultTimeseriesPoints = 200
+	MaxTimeseriesPoints     = 2000
+)
+
+// RequestTimeseries selects one per-frame metric of a report, downsampled to at most Points points.
+// Points defaults to DefaultTimeseriesPoints; Method defaults to bucket for class and to lttb otherwise.
+type RequestTimeseries struct {
+	Metric string `form:"metric" json:"metric" validate:"required,oneof=angle distance class"`
+	Points int    `form:"points" json:"points" validate:"omitempty,min=3,max=2000"`
+	Method string `form:"method" json:"method" validate:"omitempty,oneof=lttb bucket"`
+}
+
+func (r *RequestTimeseries) Validate() error {
+	return validate.Struct(r)
+}
@@ -15,7 +15,8 @@ type LandmarkInfo struct {
 }
 
 type ResponseReportSummary struct {
-	ID        uint      `json:"id"`
+	ID        uint      `json:"id"` // Deprecated: use public_id; numeric IDs are only accepted until the sunset date
+	PublicID  string    `json:"public_id"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -29,7 +30,8 @@ type ResponseAnalysis struct {
 }
 
 type ResponseReport struct {
-	ID                uint      `json:"id"`
+	ID                uint      `json:"id"` // Deprecated: use public_id; numeric IDs are only accepted until the sunset date
+	PublicID          string    `json:"public_id"`
 	UserID            uint      `json:"user_id"`
 	AlertCount        int       `json:"alert_count"`
 	AnalysisTime      int       `json:"analysis_time"`
@@ -43,6 +45,62 @@ type ResponseReport struct {
 	CreatedAt         time.Time `json:"created_at"`
 }
 
+// ResponseReportV2 is the /v2 form of ResponseReport with typed values instead of formatted strings.
+// Reports are only identified by their public ID.
+type ResponseReportV2 struct {
+	PublicID          string               `json:"public_id"`
+	UserID            uint                 `json:"user_id"`
+	AlertCount        int                  `json:"alert_count"`
+	AnalysisTime      int                  `json:"analysis_time"`
+	Type              string               `json:"type"`
+	Predict           []int                `json:"predict"`
+	Score             float64              `json:"score"`
+	NormalRatio       float64              `json:"normal_ratio"`
+	NeckAngles        []float64            `json:"neck_angles"`
+	Distances         []float64            `json:"distances"`
+	StatusFrequencies map[string]int       `json:"status_frequencies"`
+	ScoringProfile    string               `json:"scoring_profile"`
+	Breakdown         *ScoreBreakdown      `json:"breakdown,omitempty"`
+	AlertTimeline     []ResponseAlertEvent `json:"alert_timeline"`
+	AlertMetrics      ResponseAlertMetrics `json:"alert_metrics"`
+	CreatedAt         time.Time            `json:"created_at"`
+}
+
+type ResponseAlertEvent struct {
+	ID               uint      `json:"id"`
+	Type             string    `json:"type"`
+	Severity         string    `json:"severity"`
+	OccurredAt       time.Time `json:"occurred_at"`
+	CorrectedAfterMs *int64    `json:"corrected_after_ms"`
+}
+
+// ResponseAlertMetrics summarizes the alert timeline of a report. MeanTimeToCorrectMs only counts
+// alerts the user corrected; AlertsPerHour is 0 when the report has no analysis time.
+type ResponseAlertMetrics struct {
+	Count               int     `json:"count"`
+	Corrected           int     `json:"corrected"`
+	MeanTimeToCorrectMs float64 `json:"mean_time_to_correct_ms"`
+	AlertsPerHour       float64 `json:"alerts_per_hour"`
+}
+
+// ScoreBand is one confidence tier of the scoring rules and what the frames in it added to the score.
+// Deduction is what the band cost compared to full marks for its frames.
+type ScoreBand struct {
+	Class         string  `json:"class"`          // normal, abnormal
+	MinConfidence float64 `json:"min_confidence"` // 0 for the default band
+	Weight        float64 `json:"weight"`
+	Frames        int     `json:"frames"`
+	Points        float64 `json:"points"`
+	Deduction     float64 `json:"deduction"`
+}
+
+// ScoreBreakdown explains a score. Bands lists every tier in rule order, TopDeductions the bands that cost the most.
+type ScoreBreakdown struct {
+	TotalFrames   int         `json:"total_frames"`
+	Bands         []ScoreBand `json:"bands"`
+	TopDeductions []ScoreBand `json:"top_deductions"`
+}
+
 type ResponseRank struct {
 	UserID          uint   `json:"user_id"`
 	Nickname        string `json:"nickname"`
@@ -52,3 +110,117 @@ type ResponseRank struct {
 	AverageScore    string `json:"average_score"`
 	AllAverageScore string `json:"all_average_score"`
 }
+
+type ResponseAnalysisJob struct {
+	ID             uint      `json:"id"`
+	Status         string    `json:"status"`
+	ReportID       *uint     `json:"report_id"` // Deprecated: use report_public_id
+	ReportPublicID string    `json:"report_public_id"`
+	ErrorMessage   string    `json:"error_message"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ResponseDeadLetter struct {
+	ID           uint       `json:"id"`
+	JobID        uint       `json:"job_id"`
+	UserID       uint       `json:"user_id"`
+	VideoURL     string     `json:"video_url"`
+	Type         string     `json:"type"`
+	LastError    string     `json:"last_error"`
+	RedriveJobID *uint      `json:"redrive_job_id"`
+	RedrivenAt   *time.Time `json:"redriven_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type ResponseAnalyzerEndpoint struct {
+	URL           string     `json:"url"`
+	Healthy       bool       `json:"healthy"`
+	CircuitState  string     `json:"circuit_state"`
+	Failures      int        `json:"failures"`
+	InFlight      int64      `json:"in_flight"`
+	LastError     string     `json:"last_error"`
+	LastCheckedAt *time.Time `json:"last_checked_at"`
+}
+
+type ResponseAnalysisQueue struct {
+	Queued    int64 `json:"queued"`
+	Running   int64 `json:"running"`
+	InFlight  int64 `json:"in_flight"`
+	Workers   int   `json:"workers"`
+	MaxQueued int   `json:"max_queued"`
+}
+
+// ResponseAnalysisRejections counts the AI server responses this server refused to score since it started.
+type ResponseAnalysisRejections struct {
+	Total   int64                     `json:"total"`
+	Reasons []ResponseRejectionReason `json:"reasons"`
+}
+
+type ResponseRejectionReason struct {
+	Reason string `json:"reason"`
+	Count  int64  `json:"count"`
+}
+
+type ResponseUploadSession struct {
+	ID        string    `json:"id"`
+	Size      int64     `json:"size"`
+	Received  int64     `json:"received"`
+	Status    string    `json:"status"`
+	JobID     *uint     `json:"job_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ResponseUploadURL tells the app where to PUT the video. Once the upload succeeded,
+// VideoURL is passed as video_url of POST /analysis.
+type ResponseUploadURL struct {
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	VideoURL  string            `json:"video_url"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type ResponseUploadedVideo struct {
+	VideoURL string `json:"video_url"`
+}
+
+type ResponseScoringProfile struct {
+	Version     string       `json:"version"`
+	Description string       `json:"description"`
+	Rules       ScoringRules `json:"rules"`
+	Active      bool         `json:"active"`
+	ActivatedAt *time.Time   `json:"activated_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// ResponseAnalysisType describes a type clients may send as the type of an analysis.
+// MaxAnalysisTime is 0 when the analysis time is not limited, ScoringProfile is empty when reports of
+// the type are scored with the active profile.
+type ResponseAnalysisType struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Sources         []string `json:"sources"`
+	MaxAnalysisTime int      `json:"max_analysis_time"`
+	ScoringProfile  string   `json:"scoring_profile"`
+}
+
+// ResponseTimeseriesPoint is one point of a time series. T is the time since the analysis started
+// in seconds. Bucketed points average their frames, with Min and Max set; other points are frames.
+type ResponseTimeseriesPoint struct {
+	T     float64  `json:"t"`
+	Frame int      `json:"frame"`
+	Value float64  `json:"value"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+// ResponseTimeseries is a downsampled per-frame metric of a report. Frames without the metric,
+// such as those of backfilled legacy reports, are left out.
+type ResponseTimeseries struct {
+	ReportPublicID string                    `json:"report_public_id"`
+	Metric         string                    `json:"metric"`
+	Method         string                    `json:"method"`
+	TotalFrames    int                       `json:"total_frames"`
+	Points         []ResponseTimeseriesPoint `json:"points"`
+}
@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"gdsc/baro/app/user/services"
 	"gdsc/baro/app/user/types"
 	"gdsc/baro/global"
+	"gdsc/baro/global/auth"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,12 +22,13 @@ func NewUserController(userService services.UserServiceInterface) *UserControlle
 
 // @Tags Users
 // @Summary 로그인 (첫 로그인 시 회원가입)
-// @Description 토큰을 반환합니다. (첫 로그인 시 회원가입이 진행 후 토큰을 반환합니다.)
+// @Description Google 또는 Firebase ID 토큰을 검증하고 토큰을 반환합니다. 이름과 이메일은 ID 토큰에서 가져옵니다. (첫 로그인 시 회원가입이 진행 후 토큰을 반환합니다.)
 // @Accept  json
 // @Produce  json
 // @Param   user    body    types.RequestCreateUser   true    "사용자 정보"
 // @Success 200 {object} global.Response
 // @Failure 400 {object} global.Response
+// @Failure 401 {object} global.Response
 // @Router /login [post]
 func (controller *UserController) LoginOrRegisterUser(c *gin.Context) {
 	var input types.RequestCreateUser
@@ -46,6 +49,64 @@ func (controller *UserController) LoginOrRegisterUser(c *gin.Context) {
 	}
 
 	token, err := controller.UserService.Login(input)
+	if errors.Is(err, auth.ErrInvalidIDToken) {
+		c.JSON(401, global.Response{
+			Status:  401,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    token,
+	})
+}
+
+// @Tags Users
+// @Summary 토큰 재발급
+// @Description 리프레시 토큰으로 새 액세스 토큰과 리프레시 토큰을 발급합니다. 사용한 리프레시 토큰은 다시 쓸 수 없으며, 이미 사용한 토큰이 다시 오면 해당 로그인 세션의 토큰이 모두 폐기됩니다.
+// @Accept  json
+// @Produce  json
+// @Param   refresh_token    body    types.RequestRefreshToken   true    "리프레시 토큰"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 401 {object} global.Response
+// @Router /auth/refresh [post]
+func (controller *UserController) RefreshToken(c *gin.Context) {
+	var input types.RequestRefreshToken
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	token, err := controller.UserService.RefreshToken(input)
+	if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+		c.JSON(401, global.Response{
+			Status:  401,
+			Message: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(400, global.Response{
 			Status:  400,
@@ -61,6 +122,57 @@ func (controller *UserController) LoginOrRegisterUser(c *gin.Context) {
 	})
 }
 
+// @Tags Users
+// @Summary 로그아웃
+// @Description 리프레시 토큰이 속한 로그인 세션의 토큰을 모두 폐기합니다. 이미 발급된 액세스 토큰은 만료될 때까지 유효합니다.
+// @Accept  json
+// @Produce  json
+// @Param   refresh_token    body    types.RequestRefreshToken   true    "리프레시 토큰"
+// @Success 200 {object} global.Response
+// @Failure 400 {object} global.Response
+// @Failure 401 {object} global.Response
+// @Router /auth/logout [post]
+func (controller *UserController) Logout(c *gin.Context) {
+	var input types.RequestRefreshToken
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	err := controller.UserService.Logout(input)
+	if errors.Is(err, services.ErrInvalidRefreshToken) {
+		c.JSON(401, global.Response{
+			Status:  401,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(400, global.Response{
+			Status:  400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, global.Response{
+		Status:  200,
+		Message: "success",
+		Data:    "OK",
+	})
+}
+
 // @Tags Users
 // @Summary FCM 토큰 업데이트
 // @Description FCM 토큰을 업데이트합니다.
@@ -170,7 +282,7 @@ func (controller *UserController) UpdateUserInfo(c *gin.Context) {
 
 // @Tags Users
 // @Summary 내 정보 삭제 (회원 탈퇴)
-// @Description 현재 로그인한 사용자의 정보를 삭제합니다.
+// @Description 현재 로그인한 사용자의 정보를 삭제하고, 모든 리프레시 토큰을 폐기합니다.
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} global.Response
@@ -3,6 +3,7 @@ package controllers_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -14,7 +15,9 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"gdsc/baro/app/user/controllers"
+	"gdsc/baro/app/user/services"
 	"gdsc/baro/app/user/types"
+	"gdsc/baro/global/auth"
 )
 
 // MockUserService is a mock implementation of UserServiceInterface
@@ -27,6 +30,16 @@ func (m *MockUserService) Login(input types.RequestCreateUser) (types.ResponseTo
 	return args.Get(0).(types.ResponseToken), args.Error(1)
 }
 
+func (m *MockUserService) RefreshToken(input types.RequestRefreshToken) (types.ResponseToken, error) {
+	args := m.Called(input)
+	return args.Get(0).(types.ResponseToken), args.Error(1)
+}
+
+func (m *MockUserService) Logout(input types.RequestRefreshToken) error {
+	args := m.Called(input)
+	return args.Error(0)
+}
+
 func (m *MockUserService) UpdateFcmToken(c *gin.Context, input types.RequestUpdateFcmToken) error {
 	args := m.Called(c, input)
 	return args.Error(0)
@@ -73,8 +86,7 @@ func TestUserController_LoginOrRegisterUser(t *testing.T) {
 
 	// Create a sample request for the test
 	input := types.RequestCreateUser{
-		Name:     "test",
-		Email:    "test@gmail.com",
+		IDToken:  "id_token",
 		Age:      20,
 		Gender:   "male",
 		FcmToken: "test_token",
@@ -110,6 +122,33 @@ func TestUserController_LoginOrRegisterUser(t *testing.T) {
 	assert.Equal(t, types.ResponseToken{}, response.Data)
 }
 
+func TestUserController_LoginOrRegisterUser_InvalidIDToken(t *testing.T) {
+	// Mock UserService
+	mockService := new(MockUserService)
+
+	// Create UserController with the mock service
+	userController := controllers.NewUserController(mockService)
+
+	// Create a sample request for the test
+	input := types.RequestCreateUser{IDToken: "forged"}
+
+	// Set up expectations for the mock service
+	mockService.On("Login", input).Return(types.ResponseToken{}, fmt.Errorf("%w: token is malformed", auth.ErrInvalidIDToken))
+
+	// Create a test context using httptest
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/login", nil)
+	req.Body = io.NopCloser(mockRequestBody(input))
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Call the method under test
+	userController.LoginOrRegisterUser(c)
+
+	// Validate the response
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
 func TestUserController_LoginOrRegisterUser_InvalidJson(t *testing.T) {
 	// Mock UserService
 	mockService := new(MockUserService)
@@ -144,7 +183,7 @@ func TestUserController_LoginOrRegisterUser_InvalidJson(t *testing.T) {
 	assert.Equal(t, types.ResponseToken{}, response.Data)
 }
 
-func TestUserController_LoginOrRegisterUser_InvalidInput_Email(t *testing.T) {
+func TestUserController_LoginOrRegisterUser_InvalidInput_IDToken(t *testing.T) {
 	// Mock UserService
 	mockService := new(MockUserService)
 
@@ -153,8 +192,6 @@ func TestUserController_LoginOrRegisterUser_InvalidInput_Email(t *testing.T) {
 
 	// Create a sample request for the test
 	input := types.RequestCreateUser{
-		Name:     "test",
-		Email:    "test",
 		Age:      20,
 		Gender:   "male",
 		FcmToken: "test_token",
@@ -180,10 +217,103 @@ func TestUserController_LoginOrRegisterUser_InvalidInput_Email(t *testing.T) {
 
 	// Check the results
 	assert.Equal(t, http.StatusBadRequest, response.Status)
-	assert.Equal(t, "Key: 'RequestCreateUser.Email' Error:Field validation for 'Email' failed on the 'email' tag", response.Message)
+	assert.Equal(t, "Key: 'RequestCreateUser.IDToken' Error:Field validation for 'IDToken' failed on the 'required' tag", response.Message)
 	assert.Equal(t, types.ResponseToken{}, response.Data)
 }
 
+func TestUserController_RefreshToken(t *testing.T) {
+	// Mock UserService
+	mockService := new(MockUserService)
+
+	// Create UserController with the mock service
+	userController := controllers.NewUserController(mockService)
+
+	// Create a sample request for the test
+	input := types.RequestRefreshToken{RefreshToken: "refresh_token"}
+	expected := types.ResponseToken{Token: "access_token", RefreshToken: "next_refresh_token", ExpiresIn: 900}
+
+	// Set up expectations for the mock service
+	mockService.On("RefreshToken", input).Return(expected, nil)
+
+	// Create a test context using httptest
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/auth/refresh", nil)
+	req.Body = io.NopCloser(mockRequestBody(input))
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Call the method under test
+	userController.RefreshToken(c)
+
+	// Validate the response
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Parse the response body
+	var response ResponseToken
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Nil(t, err)
+
+	// Check the results
+	assert.Equal(t, expected, response.Data)
+}
+
+func TestUserController_RefreshToken_Reused(t *testing.T) {
+	// Mock UserService
+	mockService := new(MockUserService)
+
+	// Create UserController with the mock service
+	userController := controllers.NewUserController(mockService)
+
+	// Create a sample request for the test
+	input := types.RequestRefreshToken{RefreshToken: "used_refresh_token"}
+
+	// Set up expectations for the mock service
+	mockService.On("RefreshToken", input).Return(types.ResponseToken{}, services.ErrRefreshTokenReused)
+
+	// Create a test context using httptest
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/auth/refresh", nil)
+	req.Body = io.NopCloser(mockRequestBody(input))
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Call the method under test
+	userController.RefreshToken(c)
+
+	// Validate the response
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestUserController_Logout(t *testing.T) {
+	// Mock UserService
+	mockService := new(MockUserService)
+
+	// Create UserController with the mock service
+	userController := controllers.NewUserController(mockService)
+
+	// Create a sample request for the test
+	input := types.RequestRefreshToken{RefreshToken: "refresh_token"}
+
+	// Set up expectations for the mock service
+	mockService.On("Logout", input).Return(nil)
+
+	// Create a test context using httptest
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/auth/logout", nil)
+	req.Body = io.NopCloser(mockRequestBody(input))
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Call the method under test
+	userController.Logout(c)
+
+	// Assert that the expectations were met
+	mockService.AssertExpectations(t)
+
+	// Validate the response
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestUserController_FcmTokenUpdate(t *testing.T) {
 	// Mock UserService
 	mockService := new(MockUserService)
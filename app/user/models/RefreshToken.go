@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// RefreshToken is the server-side record of a refresh token; only the SHA-256 hash of the token is stored.
+// Every refresh replaces the token with a new one of the same family, so a used token that comes back
+// was copied, and its whole family is revoked.
+type RefreshToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index"`
+	FamilyID  string `gorm:"size:36;index"`
+	TokenHash string `gorm:"size:64;uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -10,5 +10,7 @@ type User struct {
 	Age      int
 	Gender   string
 	FcmToken string
-	Deleted  gorm.DeletedAt
+	// Role is one of the auth.Role* roles, carried in the user's token.
+	Role    string `gorm:"size:20;not null;default:user"`
+	Deleted gorm.DeletedAt
 }
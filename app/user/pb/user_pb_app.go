@@ -2,36 +2,51 @@ package pb
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"gdsc/baro/app/user/models"
 	"gdsc/baro/app/user/repositories"
+	"gdsc/baro/app/user/services"
+	"gdsc/baro/app/user/types"
 	"gdsc/baro/global/auth"
 	"gdsc/baro/global/utils"
 
 	userpb "gdsc/baro/protos/user"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UserPbApp struct {
 	UserRepository repositories.UserRepositoryInterface
 	UserUtil       utils.UserUtilInterface
+	IDTokens       auth.IDTokenVerifierInterface
+	Tokens         services.TokenServiceInterface
 	userpb.UnimplementedUserServiceServer
 }
 
-func NewUserPbApp(userRepository repositories.UserRepositoryInterface, userUtil utils.UserUtilInterface) *UserPbApp {
+func NewUserPbApp(userRepository repositories.UserRepositoryInterface, userUtil utils.UserUtilInterface, idTokens auth.IDTokenVerifierInterface, tokens services.TokenServiceInterface) *UserPbApp {
 	return &UserPbApp{
 		UserRepository: userRepository,
 		UserUtil:       userUtil,
+		IDTokens:       idTokens,
+		Tokens:         tokens,
 	}
 }
 
 func (app *UserPbApp) Login(c context.Context, req *userpb.RequestCreateUser) (*userpb.ResponseToken, error) {
+	identity, err := app.IDTokens.Verify(req.IdToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
 	user := models.User{
-		Name:     req.Name,
-		Nickname: req.Name,
+		Name:     identity.Name,
+		Nickname: identity.Name,
 		FcmToken: req.FcmToken,
-		Email:    req.Email,
+		Email:    identity.Email,
 		Age:      int(req.Age),
 		Gender:   req.Gender,
+		Role:     auth.RoleUser,
 	}
 
 	foundUser, err := app.UserRepository.FindOrCreateByEmail(&user)
@@ -39,12 +54,45 @@ func (app *UserPbApp) Login(c context.Context, req *userpb.RequestCreateUser) (*
 		return nil, err
 	}
 
-	token, err := auth.GenerateToken(auth.NewClaim(fmt.Sprint(foundUser.ID)))
+	token, err := app.Tokens.Issue(*foundUser)
 	if err != nil {
 		return nil, err
 	}
 
-	return &userpb.ResponseToken{Token: token}, nil
+	return toPbToken(token), nil
+}
+
+func (app *UserPbApp) Refresh(c context.Context, req *userpb.RequestRefreshToken) (*userpb.ResponseToken, error) {
+	token, err := app.Tokens.Refresh(req.RefreshToken)
+	if err != nil {
+		return nil, toPbTokenError(err)
+	}
+
+	return toPbToken(token), nil
+}
+
+func (app *UserPbApp) Logout(c context.Context, req *userpb.RequestRefreshToken) (*userpb.Empty, error) {
+	if err := app.Tokens.Revoke(req.RefreshToken); err != nil {
+		return nil, toPbTokenError(err)
+	}
+
+	return &userpb.Empty{}, nil
+}
+
+func toPbToken(token types.ResponseToken) *userpb.ResponseToken {
+	return &userpb.ResponseToken{
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    token.ExpiresIn,
+	}
+}
+
+func toPbTokenError(err error) error {
+	if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return err
 }
 
 func (app *UserPbApp) GetUserInfo(c context.Context, req *userpb.Empty) (*userpb.ResponseUser, error) {
@@ -62,6 +110,7 @@ func (app *UserPbApp) GetUserInfo(c context.Context, req *userpb.Empty) (*userpb
 		Email:    user.Email,
 		Age:      int32(user.Age),
 		Gender:   user.Gender,
+		Role:     user.Role,
 	}, nil
 }
 
@@ -105,6 +154,7 @@ func (app *UserPbApp) UpdateUserInfo(c context.Context, req *userpb.RequestUpdat
 		Email:    user.Email,
 		Age:      int32(user.Age),
 		Gender:   user.Gender,
+		Role:     user.Role,
 	}, nil
 }
 
@@ -130,6 +180,11 @@ func (app *UserPbApp) DeleteUser(c context.Context, req *userpb.Empty) (*userpb.
 		return nil, err
 	}
 
+	err = app.Tokens.RevokeAll(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	err = app.UserRepository.Delete(&user)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"gdsc/baro/app/user/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryInterface interface {
+	Create(token *models.RefreshToken) (models.RefreshToken, error)
+	FindByHash(tokenHash string) (models.RefreshToken, error)
+	Rotate(id uint, usedAt time.Time, next *models.RefreshToken) (bool, error)
+	RevokeFamily(familyID string, revokedAt time.Time) error
+	RevokeAllByUserID(userID uint, revokedAt time.Time) error
+}
+
+type RefreshTokenRepository struct {
+	DB *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		DB: db,
+	}
+}
+
+func (repo *RefreshTokenRepository) Create(token *models.RefreshToken) (models.RefreshToken, error) {
+	if err := repo.DB.Create(token).Error; err != nil {
+		return models.RefreshToken{}, err
+	}
+	return *token, nil
+}
+
+func (repo *RefreshTokenRepository) FindByHash(tokenHash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	result := repo.DB.Where("token_hash = ?", tokenHash).First(&token)
+	return token, result.Error
+}
+
+// Rotate marks a token used and creates next, the token that replaces it, in one transaction. The token
+// is only marked if it was not used yet, so when another request used it first this reports false and
+// next is not created; if creating next fails the token stays unused.
+func (repo *RefreshTokenRepository) Rotate(id uint, usedAt time.Time, next *models.RefreshToken) (bool, error) {
+	rotated := false
+
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", usedAt)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		rotated = true
+		return nil
+	})
+
+	return rotated && err == nil, err
+}
+
+func (repo *RefreshTokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	return repo.DB.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (repo *RefreshTokenRepository) RevokeAllByUserID(userID uint, revokedAt time.Time) error {
+	return repo.DB.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
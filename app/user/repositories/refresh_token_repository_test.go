@@ -0,0 +1,114 @@
+package repositories_test
+
+import (
+	"errors"
+	"gdsc/baro/app/user/models"
+	"gdsc/baro/app/user/repositories"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestRefreshTokenRepository_Rotate(t *testing.T) {
+	// Create mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB: %v", err)
+	}
+	defer db.Close()
+
+	// Set up expectations for the mock DB (ex: SELECT VERSION())
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION"}).AddRow("8.0.0"))
+
+	// Create gorm.DB
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error creating gorm.DB: %v", err)
+	}
+
+	// Create RefreshTokenRepository
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(gormDB)
+	usedAt := time.Now()
+
+	// Set up expectations for the mock DB: the first call rotates the token, the second finds it used
+	// and the third cannot save the next token, so the token is left unused
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `used_at`=\\? WHERE id = \\? AND used_at IS NULL").
+		WithArgs(usedAt, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `refresh_tokens`").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `used_at`=\\? WHERE id = \\? AND used_at IS NULL").
+		WithArgs(usedAt, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `used_at`=\\? WHERE id = \\? AND used_at IS NULL").
+		WithArgs(usedAt, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `refresh_tokens`").
+		WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	// Call the method under test
+	next := &models.RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "next", ExpiresAt: usedAt}
+	first, err := refreshTokenRepository.Rotate(1, usedAt, next)
+	assert.NoError(t, err)
+	second, err := refreshTokenRepository.Rotate(1, usedAt, &models.RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "other", ExpiresAt: usedAt})
+	assert.NoError(t, err)
+	failed, err := refreshTokenRepository.Rotate(3, usedAt, &models.RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "failed", ExpiresAt: usedAt})
+	assert.Error(t, err)
+
+	// Check that the expectations were met
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Check the result
+	assert.True(t, first)
+	assert.Equal(t, uint(2), next.ID)
+	assert.False(t, second)
+	assert.False(t, failed)
+}
+
+func TestRefreshTokenRepository_RevokeAllByUserID(t *testing.T) {
+	// Create mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB: %v", err)
+	}
+	defer db.Close()
+
+	// Set up expectations for the mock DB (ex: SELECT VERSION())
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION"}).AddRow("8.0.0"))
+
+	// Create gorm.DB
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error creating gorm.DB: %v", err)
+	}
+
+	// Create RefreshTokenRepository
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(gormDB)
+	revokedAt := time.Now()
+
+	// Set up expectations for the mock DB to revoke every token of the user that is not revoked yet
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `revoked_at`=\\? WHERE user_id = \\? AND revoked_at IS NULL").
+		WithArgs(revokedAt, 1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	// Call the method under test
+	err = refreshTokenRepository.RevokeAllByUserID(1, revokedAt)
+
+	// Check that the expectations were met
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
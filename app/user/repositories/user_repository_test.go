@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"gdsc/baro/app/user/models"
 	"gdsc/baro/app/user/repositories"
+	"gdsc/baro/global/auth"
 	"strconv"
 	"testing"
 	"time"
@@ -49,7 +50,7 @@ func TestUserRepository_Create(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample user
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `users`").
-		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, user.Deleted).
+		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, auth.RoleUser, user.Deleted).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -107,7 +108,7 @@ func TestUserRepository_FindByID(t *testing.T) {
 	// Set up expectations for the mock DB to return the sample user
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `users`").
-		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, user.Deleted).
+		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, auth.RoleUser, user.Deleted).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -184,7 +185,7 @@ func TestUserRepository_FindOrCreateByEmail_First_Login(t *testing.T) {
 	mock.ExpectBegin()
 
 	mock.ExpectExec("INSERT INTO `users`").
-		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, user.Deleted).
+		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, auth.RoleUser, user.Deleted).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectCommit()
@@ -300,7 +301,7 @@ func TestUserRepository_Update(t *testing.T) {
 	// Set up expectations for the mock DB to update the user within a transaction
 	mock.ExpectBegin()
 	mock.ExpectExec("UPDATE `users`").
-		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, user.Deleted, user.ID).
+		WithArgs(user.Name, user.Nickname, user.Email, user.Age, user.Gender, user.FcmToken, user.Role, user.Deleted, user.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
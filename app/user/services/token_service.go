@@ -0,0 +1,162 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gdsc/baro/app/user/models"
+	"gdsc/baro/app/user/repositories"
+	"gdsc/baro/app/user/types"
+	"gdsc/baro/global/auth"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRefreshTokenTTL is how long a refresh token can be used; every refresh starts it over.
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, the session is revoked")
+)
+
+type TokenServiceInterface interface {
+	Issue(user models.User) (types.ResponseToken, error)
+	Refresh(refreshToken string) (types.ResponseToken, error)
+	Revoke(refreshToken string) error
+	RevokeAll(userID uint) error
+}
+
+// TokenService issues access tokens with a rotating refresh token. A login starts a family of refresh
+// tokens; each refresh uses up the token and issues the next one of the family.
+type TokenService struct {
+	RefreshTokenRepository repositories.RefreshTokenRepositoryInterface
+	UserRepository         repositories.UserRepositoryInterface
+	Keys                   *auth.KeyRing
+	AccessTokenTTL         time.Duration
+	RefreshTokenTTL        time.Duration
+}
+
+func NewTokenService(refreshTokenRepository repositories.RefreshTokenRepositoryInterface, userRepository repositories.UserRepositoryInterface, keys *auth.KeyRing) *TokenService {
+	return &TokenService{
+		RefreshTokenRepository: refreshTokenRepository,
+		UserRepository:         userRepository,
+		Keys:                   keys,
+		AccessTokenTTL:         auth.DefaultAccessTokenTTL,
+		RefreshTokenTTL:        DefaultRefreshTokenTTL,
+	}
+}
+
+// Issue starts a new refresh token family for a user who just logged in.
+func (service *TokenService) Issue(user models.User) (types.ResponseToken, error) {
+	response, next, err := service.newTokens(user, uuid.NewString())
+	if err != nil {
+		return types.ResponseToken{}, err
+	}
+
+	if _, err := service.RefreshTokenRepository.Create(next); err != nil {
+		return types.ResponseToken{}, err
+	}
+
+	return response, nil
+}
+
+// Refresh trades a refresh token for a new access token and refresh token. The token is used up in the
+// same transaction that saves the next one, so a refresh that fails can be retried with it. A token that
+// was already used revokes its whole family, since either the user or whoever copied it holds a newer one.
+func (service *TokenService) Refresh(refreshToken string) (types.ResponseToken, error) {
+	stored, err := service.RefreshTokenRepository.FindByHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		return types.ResponseToken{}, ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil || now.After(stored.ExpiresAt) {
+		return types.ResponseToken{}, ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil {
+		return types.ResponseToken{}, service.revokeReused(stored.FamilyID, now)
+	}
+
+	user, err := service.UserRepository.FindByID(fmt.Sprint(stored.UserID))
+	if err != nil {
+		return types.ResponseToken{}, ErrInvalidRefreshToken
+	}
+
+	response, next, err := service.newTokens(user, stored.FamilyID)
+	if err != nil {
+		return types.ResponseToken{}, err
+	}
+
+	rotated, err := service.RefreshTokenRepository.Rotate(stored.ID, now, next)
+	if err != nil {
+		return types.ResponseToken{}, err
+	}
+	if !rotated {
+		return types.ResponseToken{}, service.revokeReused(stored.FamilyID, now)
+	}
+
+	return response, nil
+}
+
+// revokeReused revokes the family of a refresh token that was used twice.
+func (service *TokenService) revokeReused(familyID string, now time.Time) error {
+	if err := service.RefreshTokenRepository.RevokeFamily(familyID, now); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// Revoke logs out the session of a refresh token by revoking its family.
+func (service *TokenService) Revoke(refreshToken string) error {
+	stored, err := service.RefreshTokenRepository.FindByHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		return ErrInvalidRefreshToken
+	}
+
+	return service.RefreshTokenRepository.RevokeFamily(stored.FamilyID, time.Now())
+}
+
+// RevokeAll logs out every session of a user.
+func (service *TokenService) RevokeAll(userID uint) error {
+	return service.RefreshTokenRepository.RevokeAllByUserID(userID, time.Now())
+}
+
+// newTokens signs an access token and generates a refresh token of the family for user. The refresh
+// token is returned with the record to save for it.
+func (service *TokenService) newTokens(user models.User, familyID string) (types.ResponseToken, *models.RefreshToken, error) {
+	accessToken, err := auth.GenerateToken(service.Keys, auth.NewClaim(fmt.Sprint(user.ID), user.Role, service.AccessTokenTTL))
+	if err != nil {
+		return types.ResponseToken{}, nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return types.ResponseToken{}, nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(secret)
+
+	next := &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: time.Now().Add(service.RefreshTokenTTL),
+	}
+
+	return types.ResponseToken{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(service.AccessTokenTTL.Seconds()),
+	}, next, nil
+}
+
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
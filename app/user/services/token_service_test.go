@@ -0,0 +1,163 @@
+package services_test
+
+import (
+	"errors"
+	"gdsc/baro/app/user/models"
+	"gdsc/baro/app/user/services"
+	"gdsc/baro/global/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// Create a key ring to sign access tokens with.
+func newKeyRing(t *testing.T) *auth.KeyRing {
+	key, err := auth.GenerateSigningKey("test", auth.AlgorithmEdDSA)
+	assert.NoError(t, err)
+
+	keys, err := auth.NewKeyRing(key)
+	assert.NoError(t, err)
+	return keys
+}
+
+// Issue a token for user 1 and return it with the record that was saved for it.
+func issueToken(t *testing.T, tokenService *services.TokenService, mockTokenRepo *MockRefreshTokenRepository) (string, models.RefreshToken) {
+	mockTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(models.RefreshToken{}, nil)
+
+	token, err := tokenService.Issue(models.User{ID: 1, Role: "user"})
+	assert.NoError(t, err)
+
+	calls := len(mockTokenRepo.Calls)
+	saved := mockTokenRepo.Calls[calls-1].Arguments.Get(0).(*models.RefreshToken)
+	return token.RefreshToken, *saved
+}
+
+func TestTokenService_Refresh(t *testing.T) {
+	// Mock UserRepository, RefreshTokenRepository
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+
+	// Create TokenService and log in
+	tokenService := services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t))
+	refreshToken, saved := issueToken(t, tokenService, mockTokenRepo)
+	saved.ID = 7
+
+	// Set up expectations for the mock repositories
+	mockTokenRepo.On("FindByHash", saved.TokenHash).Return(saved, nil)
+	mockTokenRepo.On("Rotate", uint(7), mock.AnythingOfType("time.Time"), mock.AnythingOfType("*models.RefreshToken")).Return(true, nil)
+	mockRepo.On("FindByID", "1").Return(&models.User{ID: 1, Role: "admin"}, nil)
+
+	// Call the service
+	refreshed, err := tokenService.Refresh(refreshToken)
+
+	// Check the results: the token is rotated within the same family
+	assert.NoError(t, err)
+	assert.NotEmpty(t, refreshed.Token)
+	assert.NotEqual(t, refreshToken, refreshed.RefreshToken)
+
+	rotated := mockTokenRepo.Calls[len(mockTokenRepo.Calls)-1].Arguments.Get(2).(*models.RefreshToken)
+	assert.Equal(t, saved.FamilyID, rotated.FamilyID)
+	assert.NotEqual(t, saved.TokenHash, rotated.TokenHash)
+	mockTokenRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestTokenService_Refresh_RotateFails(t *testing.T) {
+	// Mock UserRepository, RefreshTokenRepository
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+
+	// Create TokenService and log in
+	tokenService := services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t))
+	refreshToken, saved := issueToken(t, tokenService, mockTokenRepo)
+	saved.ID = 7
+
+	// Set up expectations for the mock repositories: the first rotation fails and leaves the token unused
+	mockTokenRepo.On("FindByHash", saved.TokenHash).Return(saved, nil)
+	mockTokenRepo.On("Rotate", uint(7), mock.AnythingOfType("time.Time"), mock.AnythingOfType("*models.RefreshToken")).Return(false, errors.New("insert failed")).Once()
+	mockTokenRepo.On("Rotate", uint(7), mock.AnythingOfType("time.Time"), mock.AnythingOfType("*models.RefreshToken")).Return(true, nil).Once()
+	mockRepo.On("FindByID", "1").Return(&models.User{ID: 1, Role: "user"}, nil)
+
+	// Call the service, then retry with the same token
+	_, failedErr := tokenService.Refresh(refreshToken)
+	refreshed, retryErr := tokenService.Refresh(refreshToken)
+
+	// Check the results: the retry is not taken for reuse and the family stays valid
+	assert.Error(t, failedErr)
+	assert.NotErrorIs(t, failedErr, services.ErrRefreshTokenReused)
+	assert.NoError(t, retryErr)
+	assert.NotEmpty(t, refreshed.RefreshToken)
+	mockTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+}
+
+func TestTokenService_Refresh_Reused(t *testing.T) {
+	// Mock UserRepository, RefreshTokenRepository
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+
+	// Create TokenService and log in, then use the token once
+	tokenService := services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t))
+	refreshToken, saved := issueToken(t, tokenService, mockTokenRepo)
+	usedAt := time.Now().Add(-time.Minute)
+	saved.UsedAt = &usedAt
+
+	// Set up expectations for the mock repository
+	mockTokenRepo.On("FindByHash", saved.TokenHash).Return(saved, nil)
+	mockTokenRepo.On("RevokeFamily", saved.FamilyID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Call the service with the used token
+	_, err := tokenService.Refresh(refreshToken)
+
+	// Check the results: the whole family is revoked and no token is issued
+	assert.ErrorIs(t, err, services.ErrRefreshTokenReused)
+	mockTokenRepo.AssertCalled(t, "RevokeFamily", saved.FamilyID, mock.AnythingOfType("time.Time"))
+	mockTokenRepo.AssertNumberOfCalls(t, "Create", 1)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+}
+
+func TestTokenService_Refresh_Invalid(t *testing.T) {
+	// Mock UserRepository, RefreshTokenRepository
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+
+	// Create TokenService and log in; the token has expired since
+	tokenService := services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t))
+	refreshToken, saved := issueToken(t, tokenService, mockTokenRepo)
+	saved.ExpiresAt = time.Now().Add(-time.Minute)
+
+	// Set up expectations for the mock repository
+	mockTokenRepo.On("FindByHash", saved.TokenHash).Return(saved, nil)
+	mockTokenRepo.On("FindByHash", mock.Anything).Return(models.RefreshToken{}, gorm.ErrRecordNotFound)
+
+	// Call the service with the expired token and an unknown one
+	_, expiredErr := tokenService.Refresh(refreshToken)
+	_, unknownErr := tokenService.Refresh("unknown")
+
+	// Check the results
+	assert.ErrorIs(t, expiredErr, services.ErrInvalidRefreshToken)
+	assert.ErrorIs(t, unknownErr, services.ErrInvalidRefreshToken)
+	mockTokenRepo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTokenService_Revoke(t *testing.T) {
+	// Mock UserRepository, RefreshTokenRepository
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+
+	// Create TokenService and log in
+	tokenService := services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t))
+	refreshToken, saved := issueToken(t, tokenService, mockTokenRepo)
+
+	// Set up expectations for the mock repository
+	mockTokenRepo.On("FindByHash", saved.TokenHash).Return(saved, nil)
+	mockTokenRepo.On("RevokeFamily", saved.FamilyID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Call the service
+	err := tokenService.Revoke(refreshToken)
+
+	// Check the results
+	assert.NoError(t, err)
+	mockTokenRepo.AssertExpectations(t)
+}
@@ -1,7 +1,6 @@
 package services
 
 import (
-	"fmt"
 	"gdsc/baro/app/user/models"
 	"gdsc/baro/app/user/repositories"
 	"gdsc/baro/app/user/types"
@@ -13,6 +12,8 @@ import (
 
 type UserServiceInterface interface {
 	Login(input types.RequestCreateUser) (types.ResponseToken, error)
+	RefreshToken(input types.RequestRefreshToken) (types.ResponseToken, error)
+	Logout(input types.RequestRefreshToken) error
 	UpdateFcmToken(c *gin.Context, input types.RequestUpdateFcmToken) error
 	GetUserInfo(c *gin.Context) (types.ResponseUser, error)
 	UpdateUserInfo(c *gin.Context, input types.RequestUpdateUser) (types.ResponseUser, error)
@@ -22,38 +23,55 @@ type UserServiceInterface interface {
 type UserService struct {
 	UserRepository repositories.UserRepositoryInterface
 	UserUtil       utils.UserUtilInterface
+	IDTokens       auth.IDTokenVerifierInterface
+	Tokens         TokenServiceInterface
 }
 
-func NewUserService(userRepository repositories.UserRepositoryInterface, userUtil utils.UserUtilInterface) *UserService {
+func NewUserService(userRepository repositories.UserRepositoryInterface, userUtil utils.UserUtilInterface, idTokens auth.IDTokenVerifierInterface, tokens TokenServiceInterface) *UserService {
 	return &UserService{
 		UserRepository: userRepository,
 		UserUtil:       userUtil,
+		IDTokens:       idTokens,
+		Tokens:         tokens,
 	}
 }
 
-func (service *UserService) generateToken(userID uint) (string, error) {
-	tokenClaim := auth.NewClaim(fmt.Sprint(userID))
-	return auth.GenerateToken(tokenClaim)
-}
-
 func (service *UserService) Login(input types.RequestCreateUser) (types.ResponseToken, error) {
+	identity, err := service.IDTokens.Verify(input.IDToken)
+	if err != nil {
+		return types.ResponseToken{}, err
+	}
+
 	requestCreateUser := models.User{
-		Name:     input.Name,
-		Nickname: input.Name,
-		Email:    input.Email,
+		Name:     identity.Name,
+		Nickname: identity.Name,
+		Email:    identity.Email,
 		Age:      input.Age,
 		Gender:   input.Gender,
 		FcmToken: input.FcmToken,
+		Role:     auth.RoleUser,
 	}
 
-	user, _ := service.UserRepository.FindOrCreateByEmail(&requestCreateUser)
+	user, err := service.UserRepository.FindOrCreateByEmail(&requestCreateUser)
+	if err != nil {
+		return types.ResponseToken{}, err
+	}
 
 	user.FcmToken = input.FcmToken
-	updatedUser, _ := service.UserRepository.Update(user)
+	updatedUser, err := service.UserRepository.Update(user)
+	if err != nil {
+		return types.ResponseToken{}, err
+	}
+
+	return service.Tokens.Issue(updatedUser)
+}
 
-	token, _ := service.generateToken(updatedUser.ID)
+func (service *UserService) RefreshToken(input types.RequestRefreshToken) (types.ResponseToken, error) {
+	return service.Tokens.Refresh(input.RefreshToken)
+}
 
-	return types.ResponseToken{Token: token}, nil
+func (service *UserService) Logout(input types.RequestRefreshToken) error {
+	return service.Tokens.Revoke(input.RefreshToken)
 }
 
 func (service *UserService) UpdateFcmToken(c *gin.Context, input types.RequestUpdateFcmToken) error {
@@ -84,6 +102,7 @@ func (service *UserService) GetUserInfo(c *gin.Context) (types.ResponseUser, err
 		Email:    user.Email,
 		Age:      user.Age,
 		Gender:   user.Gender,
+		Role:     user.Role,
 	}
 	return responseUser, nil
 }
@@ -108,6 +127,7 @@ func (service *UserService) UpdateUserInfo(c *gin.Context, input types.RequestUp
 		Email:    updatedUser.Email,
 		Age:      updatedUser.Age,
 		Gender:   updatedUser.Gender,
+		Role:     updatedUser.Role,
 	}
 
 	return responseUser, nil
@@ -133,5 +153,10 @@ func (service *UserService) DeleteUser(c *gin.Context) error {
 		return err
 	}
 
+	// Sessions are revoked first, so a failure leaves the account to delete again rather than live tokens
+	if err := service.Tokens.RevokeAll(user.ID); err != nil {
+		return err
+	}
+
 	return service.UserRepository.Delete(user)
 }
@@ -7,6 +7,7 @@ import (
 	"gdsc/baro/app/user/types"
 	"gdsc/baro/global/auth"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -56,14 +57,53 @@ func (m *MockUserUtil) FindCurrentUser(c *gin.Context) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserUtil) FindUserByID(id uint) (*models.User, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(token *models.RefreshToken) (models.RefreshToken, error) {
+	args := m.Called(token)
+	return args.Get(0).(models.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) FindByHash(tokenHash string) (models.RefreshToken, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(models.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Rotate(id uint, usedAt time.Time, next *models.RefreshToken) (bool, error) {
+	args := m.Called(id, usedAt, next)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	args := m.Called(familyID, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllByUserID(userID uint, revokedAt time.Time) error {
+	args := m.Called(userID, revokedAt)
+	return args.Error(0)
+}
+
 func TestUserService_Login(t *testing.T) {
 	// Mock UserRepository
 	mockRepo := new(MockUserRepository)
 
+	// Create a fake Google issuer and an ID token for the test
+	issuer, err := auth.NewFakeIDTokenIssuer("baro-test")
+	assert.Nil(t, err)
+	idToken, err := issuer.Sign("test@gmail.com", "test")
+	assert.Nil(t, err)
+
 	// Set up sample user for the test
 	input := types.RequestCreateUser{
-		Name:     "test",
-		Email:    "test@gmail.com",
+		IDToken:  idToken,
 		Age:      25,
 		Gender:   "male",
 		FcmToken: "test_token",
@@ -71,30 +111,96 @@ func TestUserService_Login(t *testing.T) {
 
 	expectedUser := &models.User{
 		ID:       1,
-		Name:     input.Name,
-		Nickname: input.Name,
-		Email:    input.Email,
+		Name:     "test",
+		Nickname: "test",
+		Email:    "test@gmail.com",
 		Age:      input.Age,
 		Gender:   input.Gender,
 		FcmToken: input.FcmToken,
 	}
 
-	// Set up expectations for the mock repository
+	// Set up expectations for the mock repositories
 	mockRepo.On("FindOrCreateByEmail", mock.AnythingOfType("*models.User")).Return(expectedUser, nil)
 	mockRepo.On("Update", mock.AnythingOfType("*models.User")).Return(*expectedUser, nil)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(models.RefreshToken{}, nil)
 
-	// Create UserService with the mock repository
-	userService := services.NewUserService(mockRepo, nil)
+	// Create UserService with the mock repositories
+	userService := services.NewUserService(mockRepo, nil, issuer.Verifier(), services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t)))
 
 	// Call the method under test
 	responseToken, err := userService.Login(input)
 
 	// Assert that the expectations were met
 	mockRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
 
-	// Check the results
+	// Check the results: the name and email come from the ID token
 	assert.Nil(t, err)
 	assert.NotEmpty(t, responseToken.Token)
+	assert.NotEmpty(t, responseToken.RefreshToken)
+	assert.Equal(t, int64(900), responseToken.ExpiresIn)
+
+	created := mockRepo.Calls[0].Arguments.Get(0).(*models.User)
+	assert.Equal(t, "test@gmail.com", created.Email)
+	assert.Equal(t, "test", created.Name)
+}
+
+func TestUserService_Login_InvalidIDToken(t *testing.T) {
+	// Mock UserRepository
+	mockRepo := new(MockUserRepository)
+
+	// Create a fake Google issuer and a token signed by another one
+	issuer, err := auth.NewFakeIDTokenIssuer("baro-test")
+	assert.Nil(t, err)
+	other, err := auth.NewFakeIDTokenIssuer("baro-test")
+	assert.Nil(t, err)
+	idToken, err := other.Sign("test@gmail.com", "test")
+	assert.Nil(t, err)
+
+	// Create UserService with the mock repository
+	userService := services.NewUserService(mockRepo, nil, issuer.Verifier(), nil)
+
+	// Call the method under test
+	_, err = userService.Login(types.RequestCreateUser{IDToken: idToken})
+
+	// Check the results: nobody is logged in
+	assert.ErrorIs(t, err, auth.ErrInvalidIDToken)
+	mockRepo.AssertNotCalled(t, "FindOrCreateByEmail", mock.Anything)
+}
+
+func TestUserService_Login_RepositoryError(t *testing.T) {
+	// Create a fake Google issuer and an ID token it signed
+	issuer, err := auth.NewFakeIDTokenIssuer("baro-test")
+	assert.Nil(t, err)
+	idToken, err := issuer.Sign("test@gmail.com", "test")
+	assert.Nil(t, err)
+
+	for name, setUp := range map[string]func(mockRepo *MockUserRepository){
+		"find or create": func(mockRepo *MockUserRepository) {
+			mockRepo.On("FindOrCreateByEmail", mock.AnythingOfType("*models.User")).Return((*models.User)(nil), errors.New("database is down"))
+		},
+		"update": func(mockRepo *MockUserRepository) {
+			mockRepo.On("FindOrCreateByEmail", mock.AnythingOfType("*models.User")).Return(&models.User{ID: 1, Email: "test@gmail.com"}, nil)
+			mockRepo.On("Update", mock.AnythingOfType("*models.User")).Return(models.User{}, errors.New("database is down"))
+		},
+	} {
+		// Mock UserRepository, RefreshTokenRepository
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockRefreshTokenRepository)
+		setUp(mockRepo)
+
+		// Create UserService with the mock repositories
+		userService := services.NewUserService(mockRepo, nil, issuer.Verifier(), services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t)))
+
+		// Call the method under test
+		responseToken, err := userService.Login(types.RequestCreateUser{IDToken: idToken})
+
+		// Check the results: no token is issued for a user that was not saved
+		assert.EqualError(t, err, "database is down", name)
+		assert.Empty(t, responseToken.Token, name)
+		mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+	}
 }
 
 func TestUserService_UpdateFcmToken(t *testing.T) {
@@ -135,7 +241,7 @@ func TestUserService_UpdateFcmToken(t *testing.T) {
 	mockRepo.On("Update", mock.AnythingOfType("*models.User")).Return(*updatedUser, nil)
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -160,7 +266,7 @@ func TestUserService_UpdateFcmToken_NoUser(t *testing.T) {
 	mockUtil.On("FindCurrentUser", mock.AnythingOfType("*gin.Context")).Return((*models.User)(nil), errors.New("user not found"))
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -198,7 +304,7 @@ func TestUserService_UpdateFcmToken_Error(t *testing.T) {
 	mockRepo.On("Update", mock.AnythingOfType("*models.User")).Return(models.User{}, errors.New("record not found"))
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -232,7 +338,7 @@ func TestUserService_GetUserInfo(t *testing.T) {
 	mockUtil.On("FindCurrentUser", mock.AnythingOfType("*gin.Context")).Return(expectedUser, nil)
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -261,7 +367,7 @@ func TestUserService_GetUserInfo_Error(t *testing.T) {
 	mockUtil.On("FindCurrentUser", mock.AnythingOfType("*gin.Context")).Return((*models.User)(nil), errors.New("user not found"))
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -313,7 +419,7 @@ func TestUserService_UpdateUserInfo(t *testing.T) {
 	mockRepo.On("Update", mock.AnythingOfType("*models.User")).Return(*updatedUser, nil)
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -360,7 +466,7 @@ func TestUserService_UpdateUserInfo_Error(t *testing.T) {
 	mockUtil.On("FindCurrentUser", mock.AnythingOfType("*gin.Context")).Return(currentUser, errors.New("user not found"))
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -394,9 +500,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 	// Set up expectations for the mock repository and util
 	mockUtil.On("FindCurrentUser", mock.AnythingOfType("*gin.Context")).Return(currentUser, nil)
 	mockRepo.On("Delete", currentUser).Return(nil)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRepo.On("RevokeAllByUserID", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
 
-	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	// Create UserService with the mock repositories and util
+	userService := services.NewUserService(mockRepo, mockUtil, nil, services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t)))
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
@@ -404,14 +512,42 @@ func TestUserService_DeleteUser(t *testing.T) {
 	// Call the method under test
 	err := userService.DeleteUser(ctx)
 
-	// Assert that the expectations were met
+	// Assert that the expectations were met, every session of the user is logged out
 	mockUtil.AssertExpectations(t)
 	mockRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
 
 	// Check the results
 	assert.Nil(t, err)
 }
 
+func TestUserService_DeleteUser_RevokeError(t *testing.T) {
+	// Mock UserRepository
+	mockRepo := new(MockUserRepository)
+	mockUtil := new(MockUserUtil)
+
+	// Set up sample user for the test
+	currentUser := &models.User{ID: 1, Name: "test", Email: "test@gmail.com"}
+
+	// Set up expectations for the mock repositories and util
+	mockUtil.On("FindCurrentUser", mock.AnythingOfType("*gin.Context")).Return(currentUser, nil)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRepo.On("RevokeAllByUserID", uint(1), mock.AnythingOfType("time.Time")).Return(errors.New("connection lost"))
+
+	// Create UserService with the mock repositories and util
+	userService := services.NewUserService(mockRepo, mockUtil, nil, services.NewTokenService(mockTokenRepo, mockRepo, newKeyRing(t)))
+
+	// Create a test context
+	ctx, _ := gin.CreateTestContext(nil)
+
+	// Call the method under test
+	err := userService.DeleteUser(ctx)
+
+	// Check the results: the user is kept while their sessions could not be revoked
+	assert.EqualError(t, err, "connection lost")
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
 func TestUserService_DeleteUser_Error(t *testing.T) {
 	// Mock UserRepository
 	mockRepo := new(MockUserRepository)
@@ -431,7 +567,7 @@ func TestUserService_DeleteUser_Error(t *testing.T) {
 	mockUtil.On("FindCurrentUser", mock.AnythingOfType("*gin.Context")).Return(currentUser, errors.New("user not found"))
 
 	// Create UserService with the mock repository and util
-	userService := services.NewUserService(mockRepo, mockUtil)
+	userService := services.NewUserService(mockRepo, mockUtil, nil, nil)
 
 	// Create a test context
 	ctx, _ := gin.CreateTestContext(nil)
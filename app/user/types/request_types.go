@@ -8,9 +8,9 @@ func init() {
 	validate = validator.New()
 }
 
+// RequestCreateUser logs in with a Google or Firebase ID token; the name and email are taken from it.
 type RequestCreateUser struct {
-	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
+	IDToken  string `json:"id_token" validate:"required"`
 	Age      int    `json:"age"`
 	Gender   string `json:"gender"`
 	FcmToken string `json:"fcm_token"`
@@ -20,6 +20,14 @@ func (r *RequestCreateUser) Validate() error {
 	return validate.Struct(r)
 }
 
+type RequestRefreshToken struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (r *RequestRefreshToken) Validate() error {
+	return validate.Struct(r)
+}
+
 type RequestUpdateFcmToken struct {
 	FcmToken string `json:"fcm_token" validate:"required"`
 }
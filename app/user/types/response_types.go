@@ -1,7 +1,11 @@
 package types
 
+// ResponseToken holds a short-lived access token, and the refresh token to get the next one with.
 type ResponseToken struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of Token in seconds.
+	ExpiresIn int64 `json:"expires_in"`
 }
 
 type ResponseUser struct {
@@ -11,4 +15,5 @@ type ResponseUser struct {
 	Email    string `json:"email"`
 	Age      int    `json:"age"`
 	Gender   string `json:"gender"`
+	Role     string `json:"role"`
 }
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	reportRepository "gdsc/baro/app/report/repositories"
+	"gdsc/baro/global/config"
+	"log"
+	"os"
+)
+
+// runBackfill implements "backfill": it fills the numeric columns, report_frames and public IDs of
+// reports saved before they existed. It is run once after deploying them rather than on every start;
+// running it again only picks up the reports still missing them.
+//
+//	baro backfill [-batch 500]
+func runBackfill(args []string) {
+	flags := flag.NewFlagSet("backfill", flag.ExitOnError)
+	batchSize := flags.Int("batch", reportRepository.DefaultBackfillBatchSize, "reports to load per batch")
+	flags.Parse(args)
+
+	DB, connectionErr := config.ConnectDatabase()
+	if connectionErr != nil {
+		log.Fatal(connectionErr)
+	}
+
+	reports := reportRepository.NewReportRepository(DB)
+
+	// Reports saved before the numeric columns existed only have the formatted strings.
+	backfilled, failed, err := reports.BackfillLegacyReports(*batchSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Reports saved before public IDs existed can only be found by their numeric ID.
+	assigned, err := reports.AssignPublicIDs(*batchSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Backfilled %d reports (%d could not be parsed), assigned public IDs to %d reports\n", backfilled, failed, assigned)
+}
@@ -15,14 +15,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/analysis": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "액세스 토큰을 검증할 수 있는 공개키 목록(JWKS)을 반환합니다. 토큰 헤더의 kid로 키를 찾습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "토큰 검증 공개키 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/analysis/all": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "로그인한 사용자의 자세 추정 결과를 조회합니다.",
+                "description": "모든 사용자의 자세 추정 결과를 조회합니다. 관리자 권한이 없으면 403을 반환합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -32,7 +52,7 @@ const docTemplate = `{
                 "tags": [
                     "Reports"
                 ],
-                "summary": "자세 추정 결과 조회",
+                "summary": "자세 추정 결과 전체 조회 (관리자)",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -45,16 +65,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/analysis/dead-letters": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "자세를 추정합니다. (동영상 URL을 입력받아 자세를 추정합니다.)",
+                "description": "재시도 후에도 실패하여 dead-letter로 옮겨진 자세 추정 요청 목록을 조회합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,18 +92,7 @@ const docTemplate = `{
                 "tags": [
                     "Reports"
                 ],
-                "summary": "자세 추정 요청",
-                "parameters": [
-                    {
-                        "description": "URL, 알림 횟수 등",
-                        "name": "video_url",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.RequestAnalysis"
-                        }
-                    }
-                ],
+                "summary": "실패한 자세 추정 요청 목록 조회 (운영자용)",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -88,18 +105,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
         },
-        "/analysis/all": {
-            "get": {
+        "/admin/analysis/dead-letters/{id}/redrive": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "자세 추정 결과를 조회합니다.",
+                "description": "dead-letter에 있는 자세 추정 요청을 새 작업으로 다시 등록합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -109,7 +132,16 @@ const docTemplate = `{
                 "tags": [
                     "Reports"
                 ],
-                "summary": "자세 추정 결과 전체 조회 (테스트용)",
+                "summary": "실패한 자세 추정 요청 재처리 (운영자용)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "dead-letter id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -122,18 +154,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
         },
-        "/analysis/rank": {
+        "/admin/analysis/endpoints": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "로그인한 사용자의 자세 추정 결과를 통해 해당 사용자가 상위 몇 %인지 조회합니다.",
+                "description": "AI 서버 엔드포인트별 헬스 체크 결과와 서킷 브레이커 상태를 조회합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -143,7 +181,7 @@ const docTemplate = `{
                 "tags": [
                     "Reports"
                 ],
-                "summary": "내가 상위 몇 %인지 조회 (나이대 및 성별에 따른)",
+                "summary": "AI 서버 엔드포인트 상태 조회 (운영자용)",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -151,8 +189,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/global.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
@@ -160,14 +198,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/analysis/summary": {
+        "/admin/analysis/queue": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "로그인한 사용자의 자세 추정 결과를 월별로 요약하여 조회합니다. (캘린더 점 찍는 용도로 사용)",
+                "description": "대기 중인 작업 수, 실행 중인 작업 수, 이 서버에서 처리 중인 작업 수를 조회합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -177,16 +215,7 @@ const docTemplate = `{
                 "tags": [
                     "Reports"
                 ],
-                "summary": "자세 추정 결과 월별 요약 조회",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "조회할 년월 (YYYYMM) 예시: 202401 (2024년 1월)",
-                        "name": "ym",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
+                "summary": "자세 추정 작업 큐 상태 조회 (운영자용)",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -199,18 +228,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
         },
-        "/analysis/{id}": {
+        "/admin/analysis/rejections": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "보고서 id로 자세 추정 결과를 조회합니다. (요약으로 먼저 보고서 id 조회하고 사용자가 그걸 누르면 이걸 사용하기)",
+                "description": "검증에 실패해 점수를 매기지 않은 AI 서버 응답 수를 사유별로 조회합니다. 서버가 시작된 이후의 수치입니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -220,16 +255,7 @@ const docTemplate = `{
                 "tags": [
                     "Reports"
                 ],
-                "summary": "자세 추정 결과 id로 조회",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "자세 추정 결과 id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "AI 서버 응답 거부 통계 조회 (운영자용)",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -237,8 +263,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/global.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
@@ -246,9 +272,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/admin/scoring-profiles": {
             "get": {
-                "description": "서버가 정상 작동 중인지 확인합니다.",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "저장된 점수 산정 프로필과 현재 활성화된 프로필을 조회합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -256,22 +287,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "HealthCheck"
+                    "Scoring"
                 ],
-                "summary": "서버 상태 확인",
+                "summary": "점수 산정 프로필 목록 조회 (운영자용)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
-            }
-        },
-        "/login": {
+            },
             "post": {
-                "description": "토큰을 반환합니다. (첫 로그인 시 회원가입이 진행 후 토큰을 반환합니다.)",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "새 버전의 점수 산정 프로필을 저장합니다. 이미 있는 버전은 덮어쓸 수 없으며, 저장한 프로필은 활성화해야 적용됩니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,17 +325,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Scoring"
                 ],
-                "summary": "로그인 (첫 로그인 시 회원가입)",
+                "summary": "점수 산정 프로필 추가 (운영자용)",
                 "parameters": [
                     {
-                        "description": "사용자 정보",
-                        "name": "user",
+                        "description": "버전, 설명, 점수 구간",
+                        "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.RequestCreateUser"
+                            "$ref": "#/definitions/types.RequestScoringProfile"
                         }
                     }
                 ],
@@ -305,18 +351,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
         },
-        "/users/fcm-token": {
-            "put": {
+        "/admin/scoring-profiles/{version}/activate": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "FCM 토큰을 업데이트합니다.",
+                "description": "이후 생성되는 보고서에 적용할 점수 산정 프로필을 활성화합니다. 이미 생성된 보고서의 점수는 바뀌지 않습니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -324,18 +382,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Scoring"
                 ],
-                "summary": "FCM 토큰 업데이트",
+                "summary": "점수 산정 프로필 활성화 (운영자용)",
                 "parameters": [
                     {
-                        "description": "FCM 토큰",
-                        "name": "fcm_token",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.RequestUpdateFcmToken"
-                        }
+                        "type": "string",
+                        "description": "프로필 버전",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -350,18 +406,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
         },
-        "/users/me": {
+        "/analysis": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "현재 로그인한 사용자의 정보를 조회합니다.",
+                "description": "로그인한 사용자의 자세 추정 결과를 조회합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -369,9 +437,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Reports"
                 ],
-                "summary": "내 정보 조회",
+                "summary": "자세 추정 결과 조회",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -387,13 +455,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "현재 로그인한 사용자의 정보를 수정합니다.",
+                "description": "자세 추정 작업을 등록합니다. (동영상 URL을 입력받아 작업을 등록하고, 작업 상태는 /analysis/jobs/{id}로 조회합니다. 허용되지 않은 scheme, host나 내부 주소를 가리키는 URL은 400을 반환합니다. type은 /analysis/types에 등록된 분석 유형이어야 하며, 이전 버전의 type(예: Study)은 대응하는 분석 유형으로 처리됩니다.)",
                 "consumes": [
                     "application/json"
                 ],
@@ -401,17 +469,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Reports"
                 ],
-                "summary": "내 정보 수정",
+                "summary": "자세 추정 요청",
                 "parameters": [
                     {
-                        "description": "수정할 사용자 정보",
-                        "name": "user",
+                        "description": "URL, 알림 횟수 등",
+                        "name": "video_url",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.RequestUpdateUser"
+                            "$ref": "#/definitions/types.RequestAnalysis"
                         }
                     }
                 ],
@@ -427,16 +495,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/analysis/jobs/{id}": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "현재 로그인한 사용자의 정보를 삭제합니다.",
+                "description": "자세 추정 작업의 상태(queued, running, succeeded, failed)를 조회합니다. 완료되면 보고서 id가 함께 반환됩니다. 없거나 다른 사용자의 작업은 404를 반환합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,9 +520,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Reports"
+                ],
+                "summary": "자세 추정 작업 상태 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "자세 추정 작업 id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "내 정보 삭제 (회원 탈퇴)",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -459,13 +544,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
         },
-        "/videos": {
-            "get": {
-                "description": "전체 유튜브 영상 목록을 조회합니다.",
+        "/analysis/keypoints": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "앱에서 추정한 프레임별 어깨, 귀 좌표로 서버에서 바로 점수를 계산해 보고서를 저장합니다. 동영상을 업로드하지 않으며 AI 서버를 호출하지 않습니다. (좌표는 프레임 크기 기준 0~1, 최대 36000 프레임)",
                 "consumes": [
                     "application/json"
                 ],
@@ -473,22 +569,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Videos"
+                    "Reports"
+                ],
+                "summary": "키포인트 자세 분석",
+                "parameters": [
+                    {
+                        "description": "프레임별 좌표, 알림 횟수 등",
+                        "name": "frames",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestKeypointAnalysis"
+                        }
+                    }
                 ],
-                "summary": "유튜브 영상 전체 목록 조회",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
         },
-        "/videos/category": {
+        "/analysis/rank": {
             "get": {
-                "description": "설정한 키워드에 맞는 유튜브 영상 목록을 조회합니다.",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "로그인한 사용자의 자세 추정 결과를 통해 해당 사용자가 상위 몇 %인지 조회합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -496,30 +614,1197 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Videos"
-                ],
-                "summary": "키워드 검색 유튜브 영상 목록 조회",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "검색할 키워드",
-                        "name": "keyword",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Reports"
                 ],
+                "summary": "내가 상위 몇 %인지 조회 (나이대 및 성별에 따른)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/global.Response"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
                     }
                 }
             }
-        }
-    },
+        },
+        "/analysis/summary": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "로그인한 사용자의 자세 추정 결과를 월별로 요약하여 조회합니다. (캘린더 점 찍는 용도로 사용)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "자세 추정 결과 월별 요약 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "조회할 년월 (YYYYMM) 예시: 202401 (2024년 1월)",
+                        "name": "ym",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/analysis/types": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "분석 요청의 type으로 보낼 수 있는 분석 유형과 각 유형이 허용하는 요청 방식(video, keypoints, session), 최대 분석 시간(초, 0이면 제한 없음), 채점 프로필을 조회합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "분석 유형 목록 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/analysis/upload": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "동영상을 직접 업로드하고 자세 추정 작업을 등록합니다. (multipart/form-data, video 파일은 다른 필드보다 뒤에 보내야 합니다.)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "동영상 업로드 후 자세 추정 요청",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "알림 횟수",
+                        "name": "alert_count",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "측정 시간 (초)",
+                        "name": "analysis_time",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "측정 유형",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "동영상 파일",
+                        "name": "video",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/analysis/upload-url": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "짧은 시간 동안만 유효한 동영상 업로드 URL을 발급합니다. upload_url에 method와 headers로 동영상을 올린 뒤, video_url로 자세 추정을 요청합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "동영상 업로드 URL 발급",
+                "parameters": [
+                    {
+                        "description": "Content-Type, 파일 크기",
+                        "name": "upload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestUploadURL"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/analysis/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "큰 동영상을 나눠서 올리기 위한 업로드 세션을 만듭니다. 마지막 조각이 올라오면 자세 추정 작업이 등록됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "이어 올리기 업로드 세션 생성",
+                "parameters": [
+                    {
+                        "description": "파일 크기, Content-Type, 알림 횟수 등",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestUploadSession"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/analysis/uploads/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "지금까지 받은 바이트 수를 조회합니다. 끊긴 업로드는 received부터 다시 올리면 됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "업로드 세션 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "업로드 세션 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Upload-Offset 헤더의 위치부터 요청 본문을 이어 붙입니다. 오프셋이 받은 바이트 수와 다르면 409를 반환합니다.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "동영상 조각 업로드",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "업로드 세션 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "이 조각의 시작 위치",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/analysis/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "보고서 id로 자세 추정 결과를 조회합니다. (요약으로 먼저 보고서 id 조회하고 사용자가 그걸 누르면 이걸 사용하기) 로그인한 사용자의 보고서만 조회할 수 있으며, 없거나 다른 사용자의 보고서는 404를 반환합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "자세 추정 결과 id로 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/analysis/{id}/timeseries": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "보고서의 프레임별 목 각도(angle), 거리(distance), 자세 분류(class)를 시간순으로 조회합니다. 차트에 필요한 만큼만 points개 이하로 줄여서 반환합니다. lttb는 모양을 살리는 프레임을 고르고, bucket은 구간별 평균과 최소, 최대를 반환합니다. (method 기본값: class는 bucket, 나머지는 lttb)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "자세 추정 결과 시계열 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "angle, distance, class 중 하나",
+                        "name": "metric",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "최대 점 개수 (3~2000, 기본값 200)",
+                        "name": "points",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "lttb 또는 bucket",
+                        "name": "method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "리프레시 토큰이 속한 로그인 세션의 토큰을 모두 폐기합니다. 이미 발급된 액세스 토큰은 만료될 때까지 유효합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "로그아웃",
+                "parameters": [
+                    {
+                        "description": "리프레시 토큰",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestRefreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "리프레시 토큰으로 새 액세스 토큰과 리프레시 토큰을 발급합니다. 사용한 리프레시 토큰은 다시 쓸 수 없으며, 이미 사용한 토큰이 다시 오면 해당 로그인 세션의 토큰이 모두 폐기됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "토큰 재발급",
+                "parameters": [
+                    {
+                        "description": "리프레시 토큰",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestRefreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "서버가 정상 작동 중인지 확인합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "HealthCheck"
+                ],
+                "summary": "서버 상태 확인",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/analysis/callback": {
+            "post": {
+                "description": "AI 서버가 분석 결과를 전달합니다. X-Baro-Timestamp, X-Baro-Signature 헤더의 HMAC 서명을 검증한 뒤 대기 중인 작업을 완료합니다. 요청받은 job_id와 attempt를 그대로 돌려보내야 하며, 재시도로 대체된 이전 시도의 콜백은 409로 거부됩니다. 본문은 8MiB까지 받습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "AI 서버 분석 결과 콜백 (내부용)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "전송 시각 (unix time)",
+                        "name": "X-Baro-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sha256=\u003cHMAC-SHA256(timestamp.body)\u003e",
+                        "name": "X-Baro-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "작업 ID, 시도 번호, 분석 결과 또는 오류",
+                        "name": "callback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestAnalysisCallback"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Google 또는 Firebase ID 토큰을 검증하고 토큰을 반환합니다. 이름과 이메일은 ID 토큰에서 가져옵니다. (첫 로그인 시 회원가입이 진행 후 토큰을 반환합니다.)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "로그인 (첫 로그인 시 회원가입)",
+                "parameters": [
+                    {
+                        "description": "사용자 정보",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestCreateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{token}": {
+            "put": {
+                "description": "/analysis/upload-url에서 발급받은 URL로 동영상을 올립니다. 토큰이 만료되었거나 크기, Content-Type이 다르면 거부합니다. URL은 한 번만 사용할 수 있으며, 이미 업로드된 URL은 409를 반환합니다.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "서명된 URL로 동영상 업로드",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "업로드 토큰",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/fcm-token": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "FCM 토큰을 업데이트합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "FCM 토큰 업데이트",
+                "parameters": [
+                    {
+                        "description": "FCM 토큰",
+                        "name": "fcm_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestUpdateFcmToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "현재 로그인한 사용자의 정보를 조회합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "내 정보 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "현재 로그인한 사용자의 정보를 수정합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "내 정보 수정",
+                "parameters": [
+                    {
+                        "description": "수정할 사용자 정보",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestUpdateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "현재 로그인한 사용자의 정보를 삭제하고, 모든 리프레시 토큰을 폐기합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "내 정보 삭제 (회원 탈퇴)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/analysis": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "로그인한 사용자의 자세 추정 결과를 조회합니다. v1과 달리 predict, neck_angles, distances는 배열, score와 normal_ratio는 숫자, status_frequencies는 상태 이름을 키로 하는 객체로 반환합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "자세 추정 결과 조회 (v2)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/analysis/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "보고서 id로 로그인한 사용자의 자세 추정 결과를 조회합니다. 값의 형식은 /v2/analysis와 같습니다. 없거나 다른 사용자의 보고서는 404를 반환합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "자세 추정 결과 id로 조회 (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/analysis/{id}/alerts": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "보고서가 측정한 동안 발생한 자세 알림을 기록합니다. 알림 종류, 심각도(low, medium, high), 발생 시각, 자세를 바로잡기까지 걸린 시간(ms)을 받으며, 알림 타임라인과 지표가 포함된 보고서를 반환합니다. (한 번에 최대 1000개)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "자세 알림 기록 (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "자세 추정 결과 public_id (숫자 id는 이전 버전과의 호환을 위해 2027-04-01까지만 허용)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "알림 목록",
+                        "name": "events",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestAlertEvents"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/videos": {
+            "get": {
+                "description": "전체 유튜브 영상 목록을 조회합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "유튜브 영상 전체 목록 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/videos/category": {
+            "get": {
+                "description": "설정한 키워드에 맞는 유튜브 영상 목록을 조회합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "키워드 검색 유튜브 영상 목록 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "검색할 키워드",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/global.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
+        "auth.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/auth.JWK"
+                    }
+                }
+            }
+        },
         "global.Response": {
             "type": "object",
             "properties": {
@@ -532,6 +1817,90 @@ const docTemplate = `{
                 }
             }
         },
+        "types.KeypointFrame": {
+            "type": "object",
+            "properties": {
+                "left_ear": {
+                    "$ref": "#/definitions/types.Landmark"
+                },
+                "left_shoulder": {
+                    "$ref": "#/definitions/types.Landmark"
+                }
+            }
+        },
+        "types.Landmark": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.LandmarkInfo": {
+            "type": "object",
+            "properties": {
+                "angle": {
+                    "type": "number"
+                },
+                "left_ear": {
+                    "$ref": "#/definitions/types.Landmark"
+                },
+                "left_shoulder": {
+                    "$ref": "#/definitions/types.Landmark"
+                },
+                "vertical_distance_cm": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.RequestAlertEvent": {
+            "type": "object",
+            "required": [
+                "occurred_at",
+                "severity",
+                "type"
+            ],
+            "properties": {
+                "corrected_after_ms": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "types.RequestAlertEvents": {
+            "type": "object",
+            "required": [
+                "events"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/types.RequestAlertEvent"
+                    }
+                }
+            }
+        },
         "types.RequestAnalysis": {
             "type": "object",
             "required": [
@@ -555,27 +1924,94 @@ const docTemplate = `{
                 }
             }
         },
+        "types.RequestAnalysisCallback": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "integer"
+                },
+                "result": {
+                    "$ref": "#/definitions/types.ResponseAnalysis"
+                }
+            }
+        },
         "types.RequestCreateUser": {
             "type": "object",
             "required": [
-                "email",
-                "name"
+                "id_token"
             ],
             "properties": {
                 "age": {
                     "type": "integer"
                 },
-                "email": {
-                    "type": "string"
-                },
                 "fcm_token": {
                     "type": "string"
                 },
                 "gender": {
                     "type": "string"
                 },
-                "name": {
+                "id_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RequestKeypointAnalysis": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "alert_count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "analysis_time": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "frames": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.KeypointFrame"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RequestRefreshToken": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RequestScoringProfile": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
+                },
+                "rules": {
+                    "$ref": "#/definitions/types.ScoringRules"
+                },
+                "version": {
+                    "type": "string",
+                    "maxLength": 32
                 }
             }
         },
@@ -603,6 +2039,123 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "types.RequestUploadSession": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "type"
+            ],
+            "properties": {
+                "alert_count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "analysis_time": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RequestUploadURL": {
+            "type": "object",
+            "required": [
+                "content_type"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "types.ResponseAnalysis": {
+            "type": "object",
+            "properties": {
+                "hunched_ratio": {
+                    "type": "number"
+                },
+                "landmarks_info": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LandmarkInfo"
+                    }
+                },
+                "normal_ratio": {
+                    "type": "number"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "status_frequencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "types.ScoreTier": {
+            "type": "object",
+            "properties": {
+                "min_confidence": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
+        },
+        "types.ScoringRules": {
+            "type": "object",
+            "properties": {
+                "abnormal": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ScoreTier"
+                    }
+                },
+                "abnormal_default": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "normal": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ScoreTier"
+                    }
+                },
+                "normal_default": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"gdsc/baro/global"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -18,11 +17,11 @@ type ContextKey string
 const UserIDKey ContextKey = "user_id"
 
 type authenticationMiddleware struct {
-	secret string
+	keys *KeyRing
 }
 
-func NewAuthentication(secret string) *authenticationMiddleware {
-	return &authenticationMiddleware{secret: secret}
+func NewAuthentication(keys *KeyRing) *authenticationMiddleware {
+	return &authenticationMiddleware{keys: keys}
 }
 
 func (a *authenticationMiddleware) StripTokenMiddleware() gin.HandlerFunc {
@@ -37,7 +36,7 @@ func (a *authenticationMiddleware) StripTokenMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		claim, err := ValidateToken(token, a.secret)
+		claim, err := ValidateToken(token, a.keys)
 		if err != nil {
 			c.JSON(400, global.Response{
 				Status:  400,
@@ -48,6 +47,7 @@ func (a *authenticationMiddleware) StripTokenMiddleware() gin.HandlerFunc {
 		}
 
 		c.Set(string(UserIDKey), claim["sub"])
+		c.Set(string(RoleKey), RoleFromClaim(claim))
 		c.Next()
 	}
 }
@@ -66,13 +66,34 @@ func getTokenFromRequest(r *http.Request) (string, error) {
 	return strings.TrimSpace(splitToken[1]), nil
 }
 
-func UnaryAuthInterceptor(c context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+func (a *authenticationMiddleware) UnaryAuthInterceptor(c context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	if info.FullMethod == "/video.VideoService/GetVideos" ||
 		info.FullMethod == "/video.VideoService/GetVideosByCategory" ||
-		info.FullMethod == "/user.UserService/Login" {
+		info.FullMethod == "/user.UserService/Login" ||
+		info.FullMethod == "/user.UserService/Refresh" ||
+		info.FullMethod == "/user.UserService/Logout" {
 		return handler(c, req)
 	}
 
+	c, err := a.authenticate(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(c, req)
+}
+
+// StreamAuthInterceptor authenticates streaming RPCs the same way as UnaryAuthInterceptor.
+func (a *authenticationMiddleware) StreamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	c, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: c})
+}
+
+func (a *authenticationMiddleware) authenticate(c context.Context) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(c)
 	if !ok {
 		return nil, errors.New("missing metadata")
@@ -84,12 +105,20 @@ func UnaryAuthInterceptor(c context.Context, req interface{}, info *grpc.UnarySe
 	}
 
 	token := strings.TrimPrefix(authHeader[0], "Bearer ")
-	claim, err := ValidateToken(token, os.Getenv("JWT_SECRET"))
+	claim, err := ValidateToken(token, a.keys)
 	if err != nil {
 		return nil, errors.New("invalid token")
 	}
 
 	c = context.WithValue(c, UserIDKey, claim["sub"])
+	return context.WithValue(c, RoleKey, RoleFromClaim(claim)), nil
+}
 
-	return handler(c, req)
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
 }
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Issuers of Google sign-in and Firebase Authentication ID tokens, and where their signing keys are published.
+const (
+	GoogleJWKSURL      = "https://www.googleapis.com/oauth2/v3/certs"
+	FirebaseIssuerBase = "https://securetoken.google.com/"
+	FirebaseJWKSURL    = "https://www.googleapis.com/service_accounts/v1/jwk/securetoken@system.gserviceaccount.com"
+)
+
+var GoogleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+// Identity is what a verified ID token says about its user.
+type Identity struct {
+	Subject string
+	Email   string
+	Name    string
+}
+
+type IDTokenVerifierInterface interface {
+	Verify(token string) (Identity, error)
+}
+
+// KeySet looks up the key a token was signed with by its kid, like a *keyfunc.JWKS.
+type KeySet interface {
+	Keyfunc(token *jwtv4.Token) (interface{}, error)
+}
+
+// IDTokenVerifier checks ID tokens against a key set, and that they were issued by one of Issuers
+// for one of Audiences (OAuth client IDs or Firebase project IDs) to a verified email.
+type IDTokenVerifier struct {
+	Keys      KeySet
+	Issuers   []string
+	Audiences []string
+	// Leeway tolerates clock skew when checking exp and iat.
+	Leeway time.Duration
+}
+
+func NewIDTokenVerifier(keys KeySet, issuers []string, audiences []string) *IDTokenVerifier {
+	return &IDTokenVerifier{
+		Keys:      keys,
+		Issuers:   issuers,
+		Audiences: audiences,
+		Leeway:    time.Minute,
+	}
+}
+
+// NewRemoteKeySet fetches the key sets at urls and keeps them cached, refreshing them hourly and when
+// a token is signed with a kid that is not cached yet.
+func NewRemoteKeySet(urls []string, onError func(err error)) (KeySet, error) {
+	sets := keySets{}
+	for _, url := range urls {
+		jwks, err := keyfunc.Get(url, keyfunc.Options{
+			RefreshInterval:     time.Hour,
+			RefreshRateLimit:    5 * time.Minute,
+			RefreshTimeout:      10 * time.Second,
+			RefreshUnknownKID:   true,
+			RefreshErrorHandler: onError,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetching keys from %s: %w", url, err)
+		}
+		sets = append(sets, jwks)
+	}
+
+	return sets, nil
+}
+
+// keySets looks a key up in each of its key sets in turn.
+type keySets []KeySet
+
+func (sets keySets) Keyfunc(token *jwtv4.Token) (interface{}, error) {
+	err := errors.New("no key set is configured")
+	for _, set := range sets {
+		var key interface{}
+		if key, err = set.Keyfunc(token); err == nil {
+			return key, nil
+		}
+	}
+
+	return nil, err
+}
+
+// NewStaticKeySet reads a key set from JSON, for example a fake one to log in offline.
+func NewStaticKeySet(jwksJSON []byte) (KeySet, error) {
+	return keyfunc.NewJSON(jwksJSON)
+}
+
+type idTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// Verify returns the identity of a valid ID token, or an error wrapping ErrInvalidIDToken.
+func (verifier *IDTokenVerifier) Verify(token string) (Identity, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return verifier.Keys.Keyfunc(&jwtv4.Token{Header: t.Header})
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithExpirationRequired(), jwt.WithIssuedAt(), jwt.WithLeeway(verifier.Leeway))
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if !contains(verifier.Issuers, claims.Issuer) {
+		return Identity{}, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	}
+	if !containsAny(verifier.Audiences, claims.Audience) {
+		return Identity{}, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		return Identity{}, fmt.Errorf("%w: email is not verified", ErrInvalidIDToken)
+	}
+
+	return Identity{
+		Subject: claims.Subject,
+		Email:   claims.Email,
+		Name:    claims.Name,
+	}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(values []string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if contains(values, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+package auth_test
+
+import (
+	"gdsc/baro/global/auth"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDTokenVerifier_Verify(t *testing.T) {
+	// Create a fake Google issuer
+	issuer, err := auth.NewFakeIDTokenIssuer("baro-test")
+	assert.NoError(t, err)
+	verifier := issuer.Verifier()
+
+	// Call the method under test with a valid token
+	idToken, err := issuer.Sign("test@gmail.com", "test")
+	assert.NoError(t, err)
+	identity, err := verifier.Verify(idToken)
+
+	// Check the results
+	assert.NoError(t, err)
+	assert.Equal(t, auth.Identity{Subject: "test@gmail.com", Email: "test@gmail.com", Name: "test"}, identity)
+}
+
+func TestIDTokenVerifier_Verify_Invalid(t *testing.T) {
+	// Create a fake Google issuer
+	issuer, err := auth.NewFakeIDTokenIssuer("baro-test")
+	assert.NoError(t, err)
+	verifier := issuer.Verifier()
+
+	now := time.Now()
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		claims := jwt.MapClaims{
+			"iss":            issuer.Issuer,
+			"aud":            issuer.Audience,
+			"sub":            "1",
+			"email":          "test@gmail.com",
+			"email_verified": true,
+			"iat":            now.Unix(),
+			"exp":            now.Add(time.Hour).Unix(),
+		}
+		for name, value := range overrides {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	// Check the results: every one of these tokens is rejected
+	for name, overrides := range map[string]jwt.MapClaims{
+		"expired":            {"exp": now.Add(-time.Hour).Unix()},
+		"other audience":     {"aud": "other-app"},
+		"other issuer":       {"iss": "https://example.com"},
+		"unverified email":   {"email_verified": false},
+		"missing email":      {"email": ""},
+		"missing expiration": {"exp": nil},
+	} {
+		idToken, err := issuer.SignClaims(claims(overrides))
+		assert.NoError(t, err)
+
+		_, err = verifier.Verify(idToken)
+		assert.ErrorIs(t, err, auth.ErrInvalidIDToken, name)
+	}
+
+	// Tokens signed with the server's own keys are not ID tokens
+	key, err := auth.GenerateSigningKey("server", auth.AlgorithmRS256)
+	assert.NoError(t, err)
+	keys, err := auth.NewKeyRing(key)
+	assert.NoError(t, err)
+	token, err := auth.GenerateToken(keys, auth.NewClaim("1", auth.RoleUser, auth.DefaultAccessTokenTTL))
+	assert.NoError(t, err)
+	_, err = verifier.Verify(token)
+	assert.ErrorIs(t, err, auth.ErrInvalidIDToken)
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSController struct {
+	Keys *KeyRing
+}
+
+// @Tags Auth
+// @Summary 토큰 검증 공개키 조회
+// @Description 액세스 토큰을 검증할 수 있는 공개키 목록(JWKS)을 반환합니다. 토큰 헤더의 kid로 키를 찾습니다.
+// @Produce  json
+// @Success 200 {object} JWKSet
+// @Router /.well-known/jwks.json [get]
+func (controller JWKSController) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(200, controller.Keys.JWKS())
+}
@@ -2,41 +2,37 @@ package auth
 
 import (
 	"errors"
-	"fmt"
-	"os"
+	"log"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func NewClaim(userID string) jwt.MapClaims {
+// DefaultAccessTokenTTL is how long access tokens are valid; clients get new ones with their refresh token.
+const DefaultAccessTokenTTL = 15 * time.Minute
+
+func NewClaim(userID string, role string, ttl time.Duration) jwt.MapClaims {
 	now := time.Now()
 	claim := jwt.MapClaims{
-		"sub": userID,
-		"iat": now.Unix(),
-		"exp": now.Add(time.Hour * 24).Unix(),
+		"sub":  userID,
+		"role": role,
+		"iat":  now.Unix(),
+		"exp":  now.Add(ttl).Unix(),
 	}
 
 	return claim
 }
 
-func GenerateToken(claim jwt.MapClaims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
-	secret := os.Getenv("JWT_SECRET")
-
-	return token.SignedString([]byte(secret))
+// GenerateToken signs claim with the active key of keys.
+func GenerateToken(keys *KeyRing, claim jwt.MapClaims) (string, error) {
+	return keys.Sign(claim)
 }
 
-func ValidateToken(token string, secret string) (jwt.MapClaims, error) {
-	parsedToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
-		_, ok := t.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-
-		return []byte(secret), nil
-	})
-
+// ValidateToken verifies token with the key its kid names in keys. Tokens must expire. A legacy token
+// signed with the shared secret is only accepted within the limits of LegacySecretKey; its role claim
+// is not trusted, so it is always a user.
+func ValidateToken(token string, keys *KeyRing) (jwt.MapClaims, error) {
+	parsedToken, err := jwt.Parse(token, keys.Keyfunc, jwt.WithValidMethods(keys.Methods()), jwt.WithExpirationRequired())
 	if err != nil {
 		return nil, err
 	}
@@ -46,5 +42,14 @@ func ValidateToken(token string, secret string) (jwt.MapClaims, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	if kid, _ := parsedToken.Header["kid"].(string); kid == "" {
+		if err := keys.checkLegacy(mapClaims, time.Now()); err != nil {
+			return nil, err
+		}
+
+		log.Printf("accepted legacy HS256 token of user %v", mapClaims["sub"])
+		mapClaims["role"] = RoleUser
+	}
+
 	return mapClaims, nil
 }
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"gdsc/baro/global"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const RoleKey ContextKey = "role"
+
+// Roles a user can have, carried in the "role" claim of their token.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permission is what a route needs beyond an authenticated user.
+type Permission string
+
+const (
+	PermissionReadAllReports Permission = "reports:read_all"
+	PermissionManageAnalysis Permission = "analysis:manage"
+	PermissionManageScoring  Permission = "scoring:manage"
+)
+
+// rolePermissions lists the permissions of every role. New roles are added here.
+var rolePermissions = map[string][]Permission{
+	RoleUser:  {},
+	RoleAdmin: {PermissionReadAllReports, PermissionManageAnalysis, PermissionManageScoring},
+}
+
+// methodPermissions lists the RPCs that need a permission, by full method name. Admin operations are
+// served over REST under /admin; GetAllReports is the only one with an RPC, and any admin RPC added
+// later must be listed here.
+var methodPermissions = map[string]Permission{
+	"/report.ReportService/GetAllReports": PermissionReadAllReports,
+}
+
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission reports whether users of role are granted permission.
+func HasPermission(role string, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RoleFromClaim returns the role of a token. Tokens issued before roles existed are users.
+func RoleFromClaim(claim jwt.MapClaims) string {
+	role, _ := claim["role"].(string)
+	if role == "" {
+		return RoleUser
+	}
+
+	return role
+}
+
+// RequirePermission rejects requests whose token role lacks permission. It runs after StripTokenMiddleware.
+func RequirePermission(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c.GetString(string(RoleKey)), permission) {
+			c.AbortWithStatusJSON(403, global.Response{
+				Status:  403,
+				Message: "permission denied",
+				Data:    "failed",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// UnaryPermissionInterceptor rejects calls to RPCs in methodPermissions whose token role lacks the
+// permission. It runs after UnaryAuthInterceptor.
+func UnaryPermissionInterceptor(c context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := authorize(c, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(c, req)
+}
+
+// StreamPermissionInterceptor checks streaming RPCs the same way as UnaryPermissionInterceptor.
+func StreamPermissionInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+func authorize(c context.Context, fullMethod string) error {
+	permission, ok := methodPermissions[fullMethod]
+	if !ok {
+		return nil
+	}
+
+	role, _ := c.Value(RoleKey).(string)
+	if !HasPermission(role, permission) {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package auth_test
+
+import (
+	"context"
+	"gdsc/baro/global/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRoleFromClaim(t *testing.T) {
+	assert.Equal(t, auth.RoleAdmin, auth.RoleFromClaim(auth.NewClaim("1", auth.RoleAdmin, auth.DefaultAccessTokenTTL)))
+
+	// Tokens issued before roles existed are users
+	assert.Equal(t, auth.RoleUser, auth.RoleFromClaim(jwt.MapClaims{"sub": "1"}))
+}
+
+func TestRequirePermission(t *testing.T) {
+	// Create a router where the role is set the way StripTokenMiddleware sets it
+	newRouter := func(role string) *gin.Engine {
+		router := gin.New()
+		router.GET("/admin/analysis/all", func(c *gin.Context) {
+			c.Set(string(auth.RoleKey), role)
+		}, auth.RequirePermission(auth.PermissionReadAllReports), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	// Call the route as a user and as an admin
+	user := httptest.NewRecorder()
+	newRouter(auth.RoleUser).ServeHTTP(user, httptest.NewRequest(http.MethodGet, "/admin/analysis/all", nil))
+	admin := httptest.NewRecorder()
+	newRouter(auth.RoleAdmin).ServeHTTP(admin, httptest.NewRequest(http.MethodGet, "/admin/analysis/all", nil))
+
+	// Check the results
+	assert.Equal(t, http.StatusForbidden, user.Code)
+	assert.Equal(t, http.StatusOK, admin.Code)
+}
+
+func TestUnaryPermissionInterceptor(t *testing.T) {
+	handler := func(c context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(role string, method string) error {
+		c := context.WithValue(context.Background(), auth.RoleKey, role)
+		_, err := auth.UnaryPermissionInterceptor(c, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	// Only admins can list every report
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(auth.RoleUser, "/report.ReportService/GetAllReports")))
+	assert.NoError(t, call(auth.RoleAdmin, "/report.ReportService/GetAllReports"))
+
+	// Other RPCs only need a token
+	assert.NoError(t, call(auth.RoleUser, "/report.ReportService/GetReports"))
+}
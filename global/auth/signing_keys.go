@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algorithms tokens can be signed with.
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// LegacyTokenMaxAge is how long after it was issued a legacy HS256 token is accepted, the lifetime
+// tokens had when they were signed with JWT_SECRET.
+const LegacyTokenMaxAge = 24 * time.Hour
+
+var (
+	ErrUnknownKey         = errors.New("token is signed with an unknown key")
+	ErrLegacyTokenExpired = errors.New("legacy token is no longer accepted")
+)
+
+// SigningKey is a key tokens are signed or verified with, named by the kid header of the tokens.
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Private signs tokens; it is nil for keys that only verify them.
+	Private crypto.Signer
+	// Public is an *rsa.PublicKey, an ed25519.PublicKey or, for legacy HS256 tokens, the shared secret.
+	Public interface{}
+	// IssuedBefore is set on legacy secret keys, which only verify tokens issued before it.
+	IssuedBefore time.Time
+}
+
+// GenerateSigningKey returns a new key for alg, RS256 or EdDSA.
+func GenerateSigningKey(kid string, alg string) (SigningKey, error) {
+	switch alg {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Private: key, Public: &key.PublicKey}, nil
+	case AlgorithmEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: private, Public: public}, nil
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+}
+
+// ParseSigningKeyPEM reads an RSA or Ed25519 key from PEM: a private key (PKCS #8, or PKCS #1 for RSA)
+// signs and verifies, a public key (PKIX) only verifies.
+func ParseSigningKeyPEM(kid string, pemBytes []byte) (SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("key %s is not PEM encoded", kid)
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("key %s has unsupported PEM type %q", kid, block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("key %s: %w", kid, err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Private: key, Public: &key.PublicKey}, nil
+	case *rsa.PublicKey:
+		return SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Public: key}, nil
+	case ed25519.PrivateKey:
+		return SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: key, Public: key.Public()}, nil
+	case ed25519.PublicKey:
+		return SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Public: key}, nil
+	default:
+		return SigningKey{}, fmt.Errorf("key %s is neither an RSA nor an Ed25519 key", kid)
+	}
+}
+
+// LegacySecretKey verifies HS256 tokens signed with the shared JWT_SECRET before keys had IDs.
+// Anyone who knows the secret can sign such a token, so only those issued before issuedBefore, the time
+// the server stopped signing with the secret, and at most LegacyTokenMaxAge ago are accepted.
+func LegacySecretKey(secret string, issuedBefore time.Time) SigningKey {
+	return SigningKey{Method: jwt.SigningMethodHS256, Public: []byte(secret), IssuedBefore: issuedBefore}
+}
+
+// checkLegacy rejects a legacy token unless it was issued before the legacy key's cutoff and is
+// still within LegacyTokenMaxAge at now.
+func (ring *KeyRing) checkLegacy(claim jwt.MapClaims, now time.Time) error {
+	issuedAt, err := claim.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return fmt.Errorf("%w: no issue time", ErrLegacyTokenExpired)
+	}
+	if !issuedAt.Before(ring.keys[""].IssuedBefore) {
+		return fmt.Errorf("%w: issued at %s, after the legacy secret was retired", ErrLegacyTokenExpired, issuedAt)
+	}
+	if now.Sub(issuedAt.Time) > LegacyTokenMaxAge {
+		return fmt.Errorf("%w: issued at %s", ErrLegacyTokenExpired, issuedAt)
+	}
+
+	return nil
+}
+
+// KeyRing signs tokens with its active key and verifies them with whichever of its keys the kid names.
+// To rotate, add the next key as a verification key so it is published, then make it the active key,
+// and drop the previous key once the tokens it signed have expired.
+type KeyRing struct {
+	active SigningKey
+	keys   map[string]SigningKey
+	order  []string
+}
+
+func NewKeyRing(active SigningKey, verificationKeys ...SigningKey) (*KeyRing, error) {
+	if active.Private == nil {
+		return nil, fmt.Errorf("active key %s has no private key", active.ID)
+	}
+
+	ring := &KeyRing{active: active, keys: map[string]SigningKey{}}
+	for _, key := range append([]SigningKey{active}, verificationKeys...) {
+		if _, ok := ring.keys[key.ID]; ok {
+			return nil, fmt.Errorf("key %q is configured twice", key.ID)
+		}
+		ring.keys[key.ID] = key
+		ring.order = append(ring.order, key.ID)
+	}
+
+	return ring, nil
+}
+
+// Sign returns a token of claim signed with the active key.
+func (ring *KeyRing) Sign(claim jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(ring.active.Method, claim)
+	token.Header["kid"] = ring.active.ID
+
+	return token.SignedString(ring.active.Private)
+}
+
+// Keyfunc returns the key the kid of token names, as long as the token uses that key's algorithm.
+// Tokens without a kid are only accepted by a legacy secret key.
+func (ring *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ring.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+
+	return key.Public, nil
+}
+
+// Methods returns the algorithms of the keys in the ring.
+func (ring *KeyRing) Methods() []string {
+	var methods []string
+	seen := map[string]bool{}
+	for _, kid := range ring.order {
+		alg := ring.keys[kid].Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+
+	return methods
+}
+
+// JWK is the public part of a signing key in JSON Web Key form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the ring for other services to verify tokens with. Legacy secret
+// keys are never published.
+func (ring *KeyRing) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, kid := range ring.order {
+		switch public := ring.keys[kid].Public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				Alg: AlgorithmRS256,
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: kid,
+				Use: "sig",
+				Alg: AlgorithmEdDSA,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+
+	return set
+}
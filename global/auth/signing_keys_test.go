@@ -0,0 +1,181 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"gdsc/baro/global/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// Create a signing key, failing the test if it cannot be generated.
+func newSigningKey(t *testing.T, kid string, alg string) auth.SigningKey {
+	key, err := auth.GenerateSigningKey(kid, alg)
+	assert.NoError(t, err)
+	return key
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	// Create the current key and the key it is rotated to
+	current := newSigningKey(t, "2024-01", auth.AlgorithmRS256)
+	next := newSigningKey(t, "2024-02", auth.AlgorithmEdDSA)
+
+	before, err := auth.NewKeyRing(current, next)
+	assert.NoError(t, err)
+	after, err := auth.NewKeyRing(next, current)
+	assert.NoError(t, err)
+
+	// Sign a token before and after the rotation
+	oldToken, err := auth.GenerateToken(before, auth.NewClaim("1", auth.RoleUser, auth.DefaultAccessTokenTTL))
+	assert.NoError(t, err)
+	newToken, err := auth.GenerateToken(after, auth.NewClaim("2", auth.RoleUser, auth.DefaultAccessTokenTTL))
+	assert.NoError(t, err)
+
+	// Check the results: both tokens verify after the rotation
+	claim, err := auth.ValidateToken(oldToken, after)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", claim["sub"])
+
+	claim, err = auth.ValidateToken(newToken, after)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", claim["sub"])
+
+	// Once the old key is dropped its tokens are rejected, even next to another RSA key
+	dropped, err := auth.NewKeyRing(next, newSigningKey(t, "2024-03", auth.AlgorithmRS256))
+	assert.NoError(t, err)
+	_, err = auth.ValidateToken(oldToken, dropped)
+	assert.ErrorIs(t, err, auth.ErrUnknownKey)
+}
+
+func TestKeyRing_LegacySecret(t *testing.T) {
+	// Sign a token the way tokens were signed before keys had IDs
+	legacyToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.NewClaim("1", auth.RoleUser, auth.DefaultAccessTokenTTL)).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	active := newSigningKey(t, "current", auth.AlgorithmRS256)
+	withSecret, err := auth.NewKeyRing(active, auth.LegacySecretKey("secret", time.Now().Add(time.Minute)))
+	assert.NoError(t, err)
+	withoutSecret, err := auth.NewKeyRing(active)
+	assert.NoError(t, err)
+
+	// Check the results: HS256 is only accepted while the legacy secret is configured
+	_, err = auth.ValidateToken(legacyToken, withSecret)
+	assert.NoError(t, err)
+	_, err = auth.ValidateToken(legacyToken, withoutSecret)
+	assert.Error(t, err)
+
+	// A token naming an asymmetric key cannot be signed with HMAC instead
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.NewClaim("1", auth.RoleAdmin, auth.DefaultAccessTokenTTL))
+	forged.Header["kid"] = "current"
+	forgedToken, err := forged.SignedString([]byte("secret"))
+	assert.NoError(t, err)
+	_, err = auth.ValidateToken(forgedToken, withSecret)
+	assert.Error(t, err)
+}
+
+func TestKeyRing_LegacySecretLimits(t *testing.T) {
+	// Create a ring whose legacy secret was retired a minute ago
+	issuedBefore := time.Now().Add(-time.Minute)
+	keys, err := auth.NewKeyRing(newSigningKey(t, "current", auth.AlgorithmRS256), auth.LegacySecretKey("secret", issuedBefore))
+	assert.NoError(t, err)
+
+	sign := func(claim jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claim).SignedString([]byte("secret"))
+		assert.NoError(t, err)
+		return token
+	}
+
+	// A token issued before the secret was retired is accepted, but never as an admin
+	claim, err := auth.ValidateToken(sign(jwt.MapClaims{
+		"sub":  "1",
+		"role": auth.RoleAdmin,
+		"iat":  issuedBefore.Add(-time.Hour).Unix(),
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}), keys)
+	assert.NoError(t, err)
+	assert.Equal(t, auth.RoleUser, auth.RoleFromClaim(claim))
+
+	// Tokens minted with the secret afterwards, too long ago or without an expiry are rejected
+	for name, claim := range map[string]jwt.MapClaims{
+		"issued after retirement": {"sub": "1", "iat": time.Now().Unix(), "exp": time.Now().Add(time.Hour).Unix()},
+		"too old":                 {"sub": "1", "iat": time.Now().Add(-auth.LegacyTokenMaxAge - time.Hour).Unix(), "exp": time.Now().Add(time.Hour).Unix()},
+		"no issue time":           {"sub": "1", "exp": time.Now().Add(time.Hour).Unix()},
+		"no expiry":               {"sub": "1", "iat": issuedBefore.Add(-time.Hour).Unix()},
+	} {
+		_, err := auth.ValidateToken(sign(claim), keys)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestParseSigningKeyPEM(t *testing.T) {
+	// Encode an Ed25519 key pair as PEM
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	assert.NoError(t, err)
+
+	// Call the method under test
+	signing, err := auth.ParseSigningKeyPEM("signing", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	assert.NoError(t, err)
+	verifying, err := auth.ParseSigningKeyPEM("signing", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	assert.NoError(t, err)
+
+	// Check the results: a public key verifies what the private key signs, but cannot sign itself
+	_, err = auth.NewKeyRing(verifying)
+	assert.Error(t, err)
+
+	signer, err := auth.NewKeyRing(signing)
+	assert.NoError(t, err)
+	token, err := auth.GenerateToken(signer, auth.NewClaim("1", auth.RoleUser, auth.DefaultAccessTokenTTL))
+	assert.NoError(t, err)
+
+	other := newSigningKey(t, "other", auth.AlgorithmEdDSA)
+	verifier, err := auth.NewKeyRing(other, verifying)
+	assert.NoError(t, err)
+	_, err = auth.ValidateToken(token, verifier)
+	assert.NoError(t, err)
+
+	_, err = auth.ParseSigningKeyPEM("broken", []byte("not a key"))
+	assert.Error(t, err)
+}
+
+func TestJWKSController_JWKS(t *testing.T) {
+	// Create a ring with an RSA key, an Ed25519 key and the legacy secret
+	keys, err := auth.NewKeyRing(
+		newSigningKey(t, "rsa", auth.AlgorithmRS256),
+		newSigningKey(t, "ed", auth.AlgorithmEdDSA),
+		auth.LegacySecretKey("secret", time.Now()),
+	)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/.well-known/jwks.json", auth.JWKSController{Keys: keys}.JWKS)
+
+	// Call the endpoint
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	// Check the results: only the public keys are published
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"d"`)
+
+	var set auth.JWKSet
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &set))
+	assert.Len(t, set.Keys, 2)
+	assert.Equal(t, "RSA", set.Keys[0].Kty)
+	assert.Equal(t, "rsa", set.Keys[0].Kid)
+	assert.Equal(t, "AQAB", set.Keys[0].E)
+	assert.Equal(t, "OKP", set.Keys[1].Kty)
+	assert.Equal(t, "Ed25519", set.Keys[1].Crv)
+}
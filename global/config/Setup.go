@@ -35,11 +35,59 @@ func ConnectDatabase() (*gorm.DB, error) {
 		return nil, userErr
 	}
 
+	refreshTokenErr := database.AutoMigrate(&userModel.RefreshToken{})
+	if refreshTokenErr != nil {
+		return nil, refreshTokenErr
+	}
+
 	reportErr := database.AutoMigrate(&reportModel.Report{})
 	if reportErr != nil {
 		return nil, reportErr
 	}
 
+	reportFrameErr := database.AutoMigrate(&reportModel.ReportFrame{})
+	if reportFrameErr != nil {
+		return nil, reportFrameErr
+	}
+
+	alertEventErr := database.AutoMigrate(&reportModel.AlertEvent{})
+	if alertEventErr != nil {
+		return nil, alertEventErr
+	}
+
+	scoringProfileErr := database.AutoMigrate(&reportModel.ScoringProfile{})
+	if scoringProfileErr != nil {
+		return nil, scoringProfileErr
+	}
+
+	// Reports saved before scoring profiles existed were scored with the default profile.
+	scoringVersionErr := database.Model(&reportModel.Report{}).
+		Where("scoring_profile_version = ?", "").
+		Update("scoring_profile_version", reportModel.DefaultScoringProfileVersion).Error
+	if scoringVersionErr != nil {
+		return nil, scoringVersionErr
+	}
+
+	analysisJobErr := database.AutoMigrate(&reportModel.AnalysisJob{})
+	if analysisJobErr != nil {
+		return nil, analysisJobErr
+	}
+
+	deadLetterErr := database.AutoMigrate(&reportModel.AnalysisDeadLetter{})
+	if deadLetterErr != nil {
+		return nil, deadLetterErr
+	}
+
+	uploadSessionErr := database.AutoMigrate(&reportModel.UploadSession{})
+	if uploadSessionErr != nil {
+		return nil, uploadSessionErr
+	}
+
+	usedUploadTokenErr := database.AutoMigrate(&reportModel.UsedUploadToken{})
+	if usedUploadTokenErr != nil {
+		return nil, usedUploadTokenErr
+	}
+
 	videoErr := database.AutoMigrate(&videoModel.Video{})
 	if videoErr != nil {
 		return nil, videoErr
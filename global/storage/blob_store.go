@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore keeps uploaded files such as videos.
+// Keys are slash-separated paths like "videos/1/abc.mp4".
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	// URL returns the address the AI server can download the object from.
+	URL(key string) string
+}
+
+// SignedUploader is implemented by stores that can let a client upload an object directly.
+// The client sends a PUT to the returned URL with the returned headers.
+type SignedUploader interface {
+	SignedUploadURL(key string, contentType string, maxSize int64, expiresAt time.Time) (string, http.Header, error)
+}
+
+// SignedReader is implemented by stores that can let the AI server download a private object
+// for a while. The returned URL works without any other credential until expiresAt.
+type SignedReader interface {
+	SignedReadURL(key string, expiresAt time.Time) (string, error)
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	gcs "cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// GCSStore keeps blobs in a Google Cloud Storage bucket.
+type GCSStore struct {
+	Bucket string
+	Client *gcs.Client
+}
+
+// NewGCSStore connects with the given service account key, or with the
+// default credentials of the environment when credentialsJSON is empty.
+func NewGCSStore(ctx context.Context, bucket string, credentialsJSON string) (*GCSStore, error) {
+	if bucket == "" {
+		return nil, errors.New("gcs bucket is required")
+	}
+
+	var opts []option.ClientOption
+	if credentialsJSON != "" {
+		opts = append(opts, option.WithCredentialsJSON([]byte(credentialsJSON)))
+	}
+
+	client, err := gcs.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GCSStore{
+		Bucket: bucket,
+		Client: client,
+	}, nil
+}
+
+func (store *GCSStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	// Cancelling the writer's context aborts the upload, so a failed copy never creates the object.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := store.Client.Bucket(store.Bucket).Object(key).NewWriter(ctx)
+	writer.ContentType = contentType
+
+	if _, err := io.Copy(writer, r); err != nil {
+		cancel()
+		writer.Close()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (store *GCSStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := store.Client.Bucket(store.Bucket).Object(key).NewReader(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return reader, err
+}
+
+func (store *GCSStore) Delete(ctx context.Context, key string) error {
+	err := store.Client.Bucket(store.Bucket).Object(key).Delete(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (store *GCSStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := store.Client.Bucket(store.Bucket).Object(key).Attrs(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SignedUploadURL returns a V4 signed PUT URL. GCS itself enforces the content type
+// and, through x-goog-content-length-range, the size limit.
+func (store *GCSStore) SignedUploadURL(key string, contentType string, maxSize int64, expiresAt time.Time) (string, http.Header, error) {
+	lengthRange := fmt.Sprintf("0,%d", maxSize)
+
+	signedURL, err := store.Client.Bucket(store.Bucket).SignedURL(key, &gcs.SignedURLOptions{
+		Scheme:      gcs.SigningSchemeV4,
+		Method:      http.MethodPut,
+		ContentType: contentType,
+		Headers:     []string{"x-goog-content-length-range:" + lengthRange},
+		Expires:     expiresAt,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	headers.Set("x-goog-content-length-range", lengthRange)
+
+	return signedURL, headers, nil
+}
+
+// SignedReadURL returns a V4 signed GET URL, so the bucket itself can stay private.
+func (store *GCSStore) SignedReadURL(key string, expiresAt time.Time) (string, error) {
+	return store.Client.Bucket(store.Bucket).SignedURL(key, &gcs.SignedURLOptions{
+		Scheme:  gcs.SigningSchemeV4,
+		Method:  http.MethodGet,
+		Expires: expiresAt,
+	})
+}
+
+func (store *GCSStore) URL(key string) string {
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", store.Bucket, (&url.URL{Path: key}).EscapedPath())
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidReadURL = errors.New("invalid read url")
+	ErrReadURLExpired = errors.New("read url has expired")
+)
+
+// LocalStore keeps blobs under Root on the local filesystem.
+// BaseURL is where ServeSigned serves Root over HTTP, so the AI server can fetch the files
+// with the URLs of SignedReadURL, signed with ReadSecret.
+type LocalStore struct {
+	Root       string
+	BaseURL    string
+	ReadSecret string
+}
+
+func NewLocalStore(root string, baseURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStore{
+		Root:    root,
+		BaseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+func (store *LocalStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failed upload never leaves a partial blob behind.
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, readerWithContext(ctx, r)); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (store *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return file, err
+}
+
+func (store *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *LocalStore) Exists(ctx context.Context, key string) (bool, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return info.Mode().IsRegular(), nil
+}
+
+func (store *LocalStore) URL(key string) string {
+	return store.BaseURL + "/" + key
+}
+
+// SignedReadURL returns the URL of key with its expiry and an HMAC-SHA256 signature of both.
+func (store *LocalStore) SignedReadURL(key string, expiresAt time.Time) (string, error) {
+	if store.ReadSecret == "" {
+		return "", errors.New("read url secret is required")
+	}
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", signUploadPayload(store.ReadSecret, key+"\n"+expires))
+
+	return store.URL(key) + "?" + query.Encode(), nil
+}
+
+// ServeSigned serves the object key to a request carrying a valid signature from SignedReadURL.
+func (store *LocalStore) ServeSigned(w http.ResponseWriter, r *http.Request, key string) {
+	if err := store.verifyRead(key, r.URL.Query(), time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	path, err := store.path(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+func (store *LocalStore) verifyRead(key string, query url.Values, now time.Time) error {
+	expires := query.Get("expires")
+	signature := query.Get("signature")
+	if store.ReadSecret == "" || !hmac.Equal([]byte(signature), []byte(signUploadPayload(store.ReadSecret, key+"\n"+expires))) {
+		return ErrInvalidReadURL
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidReadURL
+	}
+	if now.Unix() >= expiresAt {
+		return ErrReadURLExpired
+	}
+
+	return nil
+}
+
+func (store *LocalStore) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(store.Root, filepath.FromSlash(key)), nil
+}
+
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// readerWithContext stops a long copy once ctx is cancelled.
+func readerWithContext(ctx context.Context, r io.Reader) io.Reader {
+	return &contextReader{ctx: ctx, r: r}
+}
+
+func (reader *contextReader) Read(p []byte) (int, error) {
+	if err := reader.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return reader.r.Read(p)
+}
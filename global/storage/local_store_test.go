@@ -0,0 +1,130 @@
+package storage_test
+
+import (
+	"context"
+	"gdsc/baro/global/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore_PutOpenDelete(t *testing.T) {
+	// Create LocalStore in a temporary directory
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files/")
+	assert.NoError(t, err)
+
+	// Call the methods under test
+	err = store.Put(context.Background(), "videos/1/a.mp4", strings.NewReader("video"), "video/mp4")
+	assert.NoError(t, err)
+
+	reader, err := store.Open(context.Background(), "videos/1/a.mp4")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+
+	// Check the result
+	assert.Equal(t, "video", string(data))
+	assert.Equal(t, "http://localhost:8080/files/videos/1/a.mp4", store.URL("videos/1/a.mp4"))
+
+	// Delete it and check that it is gone
+	assert.NoError(t, store.Delete(context.Background(), "videos/1/a.mp4"))
+	_, err = store.Open(context.Background(), "videos/1/a.mp4")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.NoError(t, store.Delete(context.Background(), "videos/1/a.mp4"))
+}
+
+func TestLocalStore_Exists(t *testing.T) {
+	// Create LocalStore in a temporary directory
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files")
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put(context.Background(), "videos/1/a.mp4", strings.NewReader("video"), "video/mp4"))
+
+	// Call the method under test
+	exists, err := store.Exists(context.Background(), "videos/1/a.mp4")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.Exists(context.Background(), "videos/1/b.mp4")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Directories are not objects
+	exists, err = store.Exists(context.Background(), "videos/1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	// Create LocalStore in a temporary directory
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files")
+	assert.NoError(t, err)
+
+	// Call the method under test with keys that escape the root
+	for _, key := range []string{"", "../escape.mp4", "/etc/passwd", "videos/../../escape.mp4"} {
+		err := store.Put(context.Background(), key, strings.NewReader("video"), "video/mp4")
+		assert.Error(t, err, key)
+	}
+}
+
+func TestLocalStore_FailedPutLeavesNothing(t *testing.T) {
+	// Create LocalStore in a temporary directory
+	root := t.TempDir()
+	store, err := storage.NewLocalStore(root, "http://localhost:8080/files")
+	assert.NoError(t, err)
+
+	// Call the method under test with a reader that fails half way
+	reader := io.MultiReader(strings.NewReader("partial"), &failingReader{})
+	err = store.Put(context.Background(), "videos/1/a.mp4", reader, "video/mp4")
+
+	// Check the result
+	assert.Error(t, err)
+	entries, _ := os.ReadDir(filepath.Join(root, "videos", "1"))
+	assert.Empty(t, entries)
+}
+
+type failingReader struct{}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func TestLocalStore_ServeSigned(t *testing.T) {
+	// Create LocalStore with a stored video
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files")
+	assert.NoError(t, err)
+	store.ReadSecret = "secret"
+	assert.NoError(t, store.Put(context.Background(), "videos/1/a.mp4", strings.NewReader("video"), "video/mp4"))
+
+	// Serve a URL the way the /files route does
+	serve := func(rawURL string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, rawURL, nil)
+		store.ServeSigned(w, r, strings.TrimPrefix(r.URL.Path, "/files/"))
+		return w
+	}
+
+	// Call the methods under test
+	signed, err := store.SignedReadURL("videos/1/a.mp4", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	expired, err := store.SignedReadURL("videos/1/a.mp4", time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	otherKey, err := store.SignedReadURL("videos/1/b.mp4", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	// Check the results: only the signed, unexpired URL of the object is served
+	ok := serve(signed)
+	assert.Equal(t, http.StatusOK, ok.Code)
+	assert.Equal(t, "video", ok.Body.String())
+
+	assert.Equal(t, http.StatusForbidden, serve(store.URL("videos/1/a.mp4")).Code)
+	assert.Equal(t, http.StatusForbidden, serve(expired).Code)
+	assert.Equal(t, http.StatusForbidden, serve(strings.Replace(otherKey, "b.mp4", "a.mp4", 1)).Code)
+	assert.Equal(t, http.StatusNotFound, serve(otherKey).Code)
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidUploadToken = errors.New("invalid upload token")
+	ErrUploadTokenExpired = errors.New("upload token has expired")
+)
+
+// UploadToken allows one client to put one object of at most MaxSize bytes
+// with the given content type until ExpiresAt (unix seconds).
+type UploadToken struct {
+	Key         string `json:"key"`
+	UserID      uint   `json:"uid"`
+	ContentType string `json:"ct"`
+	MaxSize     int64  `json:"max"`
+	ExpiresAt   int64  `json:"exp"`
+}
+
+// SignUploadToken encodes the token as "<payload>.<HMAC-SHA256 of payload>", both base64url.
+func SignUploadToken(secret string, token UploadToken) (string, error) {
+	if secret == "" {
+		return "", errors.New("upload token secret is required")
+	}
+
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + signUploadPayload(secret, encoded), nil
+}
+
+func ParseUploadToken(secret string, signed string, now time.Time) (UploadToken, error) {
+	encoded, signature, ok := strings.Cut(signed, ".")
+	if secret == "" || !ok || !hmac.Equal([]byte(signature), []byte(signUploadPayload(secret, encoded))) {
+		return UploadToken{}, ErrInvalidUploadToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return UploadToken{}, ErrInvalidUploadToken
+	}
+
+	var token UploadToken
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return UploadToken{}, ErrInvalidUploadToken
+	}
+
+	if now.Unix() >= token.ExpiresAt {
+		return UploadToken{}, ErrUploadTokenExpired
+	}
+
+	return token, nil
+}
+
+func signUploadPayload(secret string, encoded string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
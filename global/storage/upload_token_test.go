@@ -0,0 +1,46 @@
+package storage_test
+
+import (
+	"gdsc/baro/global/storage"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadToken_RoundTrip(t *testing.T) {
+	// Create a signed token
+	now := time.Now()
+	token := storage.UploadToken{Key: "videos/1/a.mp4", UserID: 1, ContentType: "video/mp4", MaxSize: 10, ExpiresAt: now.Add(time.Minute).Unix()}
+	signed, err := storage.SignUploadToken("secret", token)
+	assert.NoError(t, err)
+
+	// Call the method under test
+	parsed, err := storage.ParseUploadToken("secret", signed, now)
+
+	// Check the result
+	assert.NoError(t, err)
+	assert.Equal(t, token, parsed)
+}
+
+func TestUploadToken_Rejected(t *testing.T) {
+	// Create a signed token
+	now := time.Now()
+	signed, err := storage.SignUploadToken("secret", storage.UploadToken{Key: "videos/1/a.mp4", ExpiresAt: now.Add(time.Minute).Unix()})
+	assert.NoError(t, err)
+	payload, signature, _ := strings.Cut(signed, ".")
+
+	// Call the method under test with a wrong secret, a tampered token and an expired token
+	_, err = storage.ParseUploadToken("other", signed, now)
+	assert.ErrorIs(t, err, storage.ErrInvalidUploadToken)
+
+	_, err = storage.ParseUploadToken("secret", payload+"x."+signature, now)
+	assert.ErrorIs(t, err, storage.ErrInvalidUploadToken)
+
+	_, err = storage.ParseUploadToken("secret", "garbage", now)
+	assert.ErrorIs(t, err, storage.ErrInvalidUploadToken)
+
+	_, err = storage.ParseUploadToken("secret", signed, now.Add(2*time.Minute))
+	assert.ErrorIs(t, err, storage.ErrUploadTokenExpired)
+}
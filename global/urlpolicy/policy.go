@@ -0,0 +1,165 @@
+package urlpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+const DefaultMaxLength = 2048
+
+// ErrNotAllowed is wrapped by every *Error returned from Policy.Check.
+var ErrNotAllowed = errors.New("url is not allowed")
+
+// Error explains why a URL was rejected. The reason is safe to show to the client.
+type Error struct {
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return "video_url is not allowed: " + e.Reason
+}
+
+func (e *Error) Unwrap() error {
+	return ErrNotAllowed
+}
+
+// Resolver looks up the addresses of a host. *net.Resolver satisfies it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network string, host string) ([]netip.Addr, error)
+}
+
+// Policy decides which URLs may be handed to the AI server, which fetches them from inside our network.
+//
+// A URL must be at most MaxLength bytes, use one of AllowedSchemes, carry no credentials,
+// and name a host in AllowedHosts (any host when empty). Every address the host resolves to
+// must be public: loopback, private, link-local, multicast and other reserved ranges are refused.
+// URLs under TrustedPrefixes are issued by this server itself and skip the host and address checks.
+type Policy struct {
+	AllowedSchemes  []string
+	AllowedHosts    []string
+	TrustedPrefixes []string
+	MaxLength       int
+	Resolver        Resolver
+}
+
+func NewPolicy(allowedSchemes []string, allowedHosts []string) *Policy {
+	return &Policy{
+		AllowedSchemes: allowedSchemes,
+		AllowedHosts:   allowedHosts,
+		MaxLength:      DefaultMaxLength,
+		Resolver:       net.DefaultResolver,
+	}
+}
+
+func (policy *Policy) Check(ctx context.Context, rawURL string) error {
+	if policy.MaxLength > 0 && len(rawURL) > policy.MaxLength {
+		return &Error{Reason: fmt.Sprintf("longer than %d characters", policy.MaxLength)}
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || !parsed.IsAbs() || parsed.Opaque != "" {
+		return &Error{Reason: "not an absolute url"}
+	}
+
+	for _, prefix := range policy.TrustedPrefixes {
+		if prefix != "" && strings.HasPrefix(rawURL, prefix) && !strings.Contains(rawURL[len(prefix):], "..") {
+			return nil
+		}
+	}
+
+	if !contains(policy.AllowedSchemes, strings.ToLower(parsed.Scheme)) {
+		return &Error{Reason: fmt.Sprintf("scheme %q is not allowed", parsed.Scheme)}
+	}
+	if parsed.User != nil {
+		return &Error{Reason: "credentials in the url are not allowed"}
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "" {
+		return &Error{Reason: "host is required"}
+	}
+	if len(policy.AllowedHosts) > 0 && !matchHost(policy.AllowedHosts, host) {
+		return &Error{Reason: fmt.Sprintf("host %q is not allowed", host)}
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !IsPublic(addr) {
+			return &Error{Reason: fmt.Sprintf("address %s is not public", addr)}
+		}
+		return nil
+	}
+
+	addrs, err := policy.Resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return &Error{Reason: fmt.Sprintf("host %q could not be resolved", host)}
+	}
+	for _, addr := range addrs {
+		if !IsPublic(addr) {
+			return &Error{Reason: fmt.Sprintf("host %q resolves to non-public address %s", host, addr.Unmap())}
+		}
+	}
+
+	return nil
+}
+
+// reserved lists ranges that are not covered by the netip predicates but must not be reachable either.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// IsPublic reports whether addr is a globally routable unicast address.
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchHost(allowed []string, host string) bool {
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.ToLower(v) == value {
+			return true
+		}
+	}
+
+	return false
+}
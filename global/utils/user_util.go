@@ -11,6 +11,7 @@ import (
 
 type UserUtilInterface interface {
 	FindCurrentUser(c *gin.Context) (*models.User, error)
+	FindUserByID(id uint) (*models.User, error)
 }
 
 type UserUtil struct {
@@ -36,3 +37,12 @@ func (util *UserUtil) FindCurrentUser(c *gin.Context) (*models.User, error) {
 
 	return &user, nil
 }
+
+func (util *UserUtil) FindUserByID(id uint) (*models.User, error) {
+	user, err := util.UserRepository.FindByID(fmt.Sprint(id))
+	if err != nil {
+		return nil, fmt.Errorf("not found user")
+	}
+
+	return &user, nil
+}
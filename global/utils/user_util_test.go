@@ -130,3 +130,55 @@ func TestUserUtil_FindCurrentUser_UserNotFound(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, user)
 }
+
+func TestUserUtil_FindUserByID(t *testing.T) {
+	// Create a new instance of the mock repository
+	mockRepo := new(MockUserRepository)
+
+	// Create an instance of UserUtil with the mock repository
+	userUtil := utils.NewUserUtil(mockRepo)
+
+	// Create a sample user for testing
+	expectedUser := &models.User{
+		ID:       123,
+		Name:     "test",
+		Nickname: "test",
+		Email:    "test@gmail.com",
+		Age:      25,
+		Gender:   "male",
+	}
+
+	// Set up expectations for the mock repository
+	mockRepo.On("FindByID", "123").Return(expectedUser, nil)
+
+	// Call the method under test
+	user, err := userUtil.FindUserByID(123)
+
+	// Assert that the expectations were met
+	mockRepo.AssertExpectations(t)
+
+	// Validate the result
+	assert.Nil(t, err)
+	assert.Equal(t, expectedUser, user)
+}
+
+func TestUserUtil_FindUserByID_UserNotFound(t *testing.T) {
+	// Create a new instance of the mock repository
+	mockRepo := new(MockUserRepository)
+
+	// Create an instance of UserUtil with the mock repository
+	userUtil := utils.NewUserUtil(mockRepo)
+
+	// Set up expectations for the mock repository when user is not found
+	mockRepo.On("FindByID", "123").Return(&models.User{}, fmt.Errorf("user not found"))
+
+	// Call the method under test
+	user, err := userUtil.FindUserByID(123)
+
+	// Assert that the expectations were met
+	mockRepo.AssertExpectations(t)
+
+	// Validate the result
+	assert.NotNil(t, err)
+	assert.Nil(t, user)
+}
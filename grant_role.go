@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	userRepository "gdsc/baro/app/user/repositories"
+	"gdsc/baro/global/auth"
+	"gdsc/baro/global/config"
+	"log"
+)
+
+// runGrantRole implements "grant-role": it sets the role of a user, who gets it with the next token
+// they log in for.
+//
+//	baro grant-role -email someone@example.com -role admin
+func runGrantRole(args []string) {
+	flags := flag.NewFlagSet("grant-role", flag.ExitOnError)
+	email := flags.String("email", "", "email of the user")
+	role := flags.String("role", auth.RoleAdmin, "role to grant")
+	flags.Parse(args)
+
+	if *email == "" {
+		log.Fatal("-email is required")
+	}
+	if !auth.ValidRole(*role) {
+		log.Fatalf("unknown role %q", *role)
+	}
+
+	DB, connectionErr := config.ConnectDatabase()
+	if connectionErr != nil {
+		log.Fatal(connectionErr)
+	}
+
+	repository := userRepository.NewUserRepository(DB)
+	user, err := repository.FindByEmail(*email)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	user.Role = *role
+	if _, err := repository.Update(user); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Granted role %s to user %d", *role, user.ID)
+}
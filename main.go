@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
+	"gdsc/baro/app/report/analyzers"
 	reportController "gdsc/baro/app/report/controllers"
+	reportapp "gdsc/baro/app/report/pb"
 	reportRepository "gdsc/baro/app/report/repositories"
 	reportService "gdsc/baro/app/report/services"
 	userController "gdsc/baro/app/user/controllers"
@@ -13,30 +16,45 @@ import (
 	videoRepository "gdsc/baro/app/video/repositories"
 	videoService "gdsc/baro/app/video/services"
 
+	reportpb "gdsc/baro/protos/report"
 	userpb "gdsc/baro/protos/user"
 	videopb "gdsc/baro/protos/video"
 
+	"fmt"
 	"gdsc/baro/docs"
 	"gdsc/baro/global"
 	"gdsc/baro/global/auth"
 	"gdsc/baro/global/config"
+	"gdsc/baro/global/storage"
+	"gdsc/baro/global/urlpolicy"
 	"gdsc/baro/global/utils"
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/soheilhy/cmux"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"google.golang.org/grpc"
+	"gorm.io/gorm"
 )
 
 type App struct {
-	UserCtrl   *userController.UserController
-	ReportCtrl *reportController.ReportController
-	VideoCtrl  *videoController.VideoController
-	Router     *gin.Engine
+	UserCtrl      *userController.UserController
+	ReportCtrl    *reportController.ReportController
+	VideoCtrl     *videoController.VideoController
+	UploadCtrl    *reportController.UploadController
+	ScoringCtrl   *reportController.ScoringController
+	ReportService *reportService.ReportService
+	UploadService *reportService.UploadService
+	IDTokens      *auth.IDTokenVerifier
+	TokenService  *userService.TokenService
+	Keys          *auth.KeyRing
+	Router        *gin.Engine
 }
 
 func (app *App) Init() {
@@ -52,13 +70,19 @@ func (app *App) InitDocs() {
 }
 
 func (app *App) RunGrpcServer(l net.Listener, userRepository userRepository.UserRepositoryInterface, userUtil utils.UserUtilInterface, videoRepository videoRepository.VideoRepositoryInterface) {
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryAuthInterceptor))
+	authMiddleware := auth.NewAuthentication(app.Keys)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authMiddleware.UnaryAuthInterceptor, auth.UnaryPermissionInterceptor),
+		grpc.ChainStreamInterceptor(authMiddleware.StreamAuthInterceptor, auth.StreamPermissionInterceptor),
+	)
 
-	userPbApp := userapp.NewUserPbApp(userRepository, userUtil)
+	userPbApp := userapp.NewUserPbApp(userRepository, userUtil, app.IDTokens, app.TokenService)
 	videoPbApp := videoapp.NewVideoPbApp(videoRepository)
+	reportPbApp := reportapp.NewReportPbApp(app.ReportService, app.UploadService, userRepository)
 
 	userpb.RegisterUserServiceServer(grpcServer, userPbApp)
 	videopb.RegisterVideoServiceServer(grpcServer, videoPbApp)
+	reportpb.RegisterReportServiceServer(grpcServer, reportPbApp)
 
 	if err := grpcServer.Serve(l); err != nil {
 		log.Fatalf("failed to serve: %v", err)
@@ -77,8 +101,8 @@ func (app *App) InitRouter() {
 
 	app.Router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	JWT_SECRET := os.Getenv("JWT_SECRET")
-	authMiddleware := auth.NewAuthentication(JWT_SECRET)
+	app.Keys = newKeyRing()
+	authMiddleware := auth.NewAuthentication(app.Keys)
 
 	DB, connectionErr := config.ConnectDatabase()
 	if connectionErr != nil {
@@ -88,12 +112,62 @@ func (app *App) InitRouter() {
 	userRepository := userRepository.NewUserRepository(DB)
 	userUtil := utils.NewUserUtil(userRepository)
 
-	userService := userService.NewUserService(userRepository, userUtil)
+	app.IDTokens = newIDTokenVerifier()
+	app.TokenService = newTokenService(DB, userRepository, app.Keys)
+	userService := userService.NewUserService(userRepository, userUtil, app.IDTokens, app.TokenService)
 	app.UserCtrl = userController.NewUserController(userService)
 
+	analysisJobRepository := reportRepository.NewAnalysisJobRepository(DB)
+	uploadSessionRepository := reportRepository.NewUploadSessionRepository(DB)
+	scoringProfileRepository := reportRepository.NewScoringProfileRepository(DB)
 	reportRepository := reportRepository.NewReportRepository(DB)
-	reportService := reportService.NewReportService(reportRepository, userUtil)
-	app.ReportCtrl = reportController.NewReportController(reportService)
+	app.ReportService = reportService.NewReportService(reportRepository, analysisJobRepository, newAnalyzer(aiServerURLs("")), userUtil)
+	app.ReportService.AnalysisTypes = newAnalysisTypes()
+	app.ReportService.Workers = newAnalysisWorkerPool(app.ReportService)
+	app.ReportService.CallbackURL = os.Getenv("AI_CALLBACK_URL")
+	app.ReportService.CallbackSecret = os.Getenv("AI_CALLBACK_SECRET")
+	if sunset, err := time.Parse(time.DateOnly, os.Getenv("REPORT_NUMERIC_IDS_UNTIL")); err == nil {
+		app.ReportService.NumericReportIDsUntil = sunset
+	}
+	if callbackTimeout, err := time.ParseDuration(os.Getenv("AI_CALLBACK_TIMEOUT")); err == nil && callbackTimeout > 0 {
+		app.ReportService.CallbackTimeout = callbackTimeout
+	}
+	if app.ReportService.CallbackURL != "" && app.ReportService.CallbackSecret == "" {
+		log.Println("AI_CALLBACK_URL is set without AI_CALLBACK_SECRET, every callback will be rejected")
+	}
+	scoringService := reportService.NewScoringService(scoringProfileRepository)
+	if err := scoringService.EnsureDefaultProfile(); err != nil {
+		log.Fatal(err)
+	}
+	for _, analysisType := range app.ReportService.AnalysisTypes.All() {
+		if analysisType.ScoringProfile == "" {
+			continue
+		}
+		if _, err := scoringService.Rules(analysisType.ScoringProfile); err != nil {
+			log.Fatalf("scoring profile %s of analysis type %s: %v", analysisType.ScoringProfile, analysisType.Name, err)
+		}
+	}
+	app.ReportService.Scoring = scoringService
+	app.ScoringCtrl = reportController.NewScoringController(scoringService)
+	app.ReportCtrl = reportController.NewReportController(app.ReportService)
+
+	blobStore := app.newBlobStore()
+	app.UploadService = reportService.NewUploadService(uploadSessionRepository, app.ReportService, blobStore, userUtil)
+	if maxSize, err := strconv.ParseInt(os.Getenv("UPLOAD_MAX_BYTES"), 10, 64); err == nil && maxSize > 0 {
+		app.UploadService.MaxSize = maxSize
+	}
+	app.UploadService.UploadURLBase = os.Getenv("UPLOAD_URL_BASE")
+	if app.UploadService.UploadURLBase == "" {
+		app.UploadService.UploadURLBase = "http://localhost:8080/uploads"
+	}
+	app.UploadService.UploadTokenSecret = os.Getenv("UPLOAD_TOKEN_SECRET")
+	if ttl, err := time.ParseDuration(os.Getenv("UPLOAD_URL_TTL")); err == nil && ttl > 0 {
+		app.UploadService.UploadURLTTL = ttl
+	}
+	app.ReportService.VideoURLs = app.UploadService
+	app.ReportService.VideoReader = app.UploadService
+	app.ReportService.URLPolicy = newURLPolicy(blobStore.URL("videos/"))
+	app.UploadCtrl = reportController.NewUploadController(app.UploadService)
 
 	videoRepository := videoRepository.NewVideoRepository(DB)
 	videoService := videoService.NewVideoService(videoRepository)
@@ -102,9 +176,21 @@ func (app *App) InitRouter() {
 	openAPI := app.Router.Group("/")
 	{
 		openAPI.GET("/health", global.HealthCheckController{}.HealthCheck)
+		openAPI.GET("/.well-known/jwks.json", auth.JWKSController{Keys: app.Keys}.JWKS)
 		openAPI.POST("/login", func(c *gin.Context) { app.UserCtrl.LoginOrRegisterUser(c) })
+		// Authenticated by the refresh token in the body, since the access token may have expired.
+		openAPI.POST("/auth/refresh", func(c *gin.Context) { app.UserCtrl.RefreshToken(c) })
+		openAPI.POST("/auth/logout", func(c *gin.Context) { app.UserCtrl.Logout(c) })
 		openAPI.GET("/videos", func(c *gin.Context) { app.VideoCtrl.GetVideos(c) })
 		openAPI.GET("/videos/category", func(c *gin.Context) { app.VideoCtrl.GetVideosByCategory(c) })
+		// Authenticated by the signed token in the URL.
+		openAPI.PUT("/uploads/:token", func(c *gin.Context) { app.UploadCtrl.ReceiveSignedUpload(c) })
+	}
+
+	// Called by the AI server; authenticated by the HMAC signature instead of a user token.
+	internalAPI := app.Router.Group("/internal")
+	{
+		internalAPI.POST("/analysis/callback", func(c *gin.Context) { app.ReportCtrl.AnalysisCallback(c) })
 	}
 
 	secureAPI := app.Router.Group("/")
@@ -116,18 +202,399 @@ func (app *App) InitRouter() {
 		secureAPI.PUT("/users/fcm-token", func(c *gin.Context) { app.UserCtrl.UpdateFcmToken(c) })
 
 		secureAPI.POST("/analysis", func(c *gin.Context) { app.ReportCtrl.Analysis(c) })
+		secureAPI.POST("/analysis/keypoints", func(c *gin.Context) { app.ReportCtrl.AnalyzeKeypoints(c) })
 		secureAPI.GET("/analysis", func(c *gin.Context) { app.ReportCtrl.GetAnalysis(c) })
 		secureAPI.GET("/analysis/:id", func(c *gin.Context) { app.ReportCtrl.GetAnalysisById(c) })
+		secureAPI.GET("/analysis/:id/timeseries", func(c *gin.Context) { app.ReportCtrl.GetAnalysisTimeseries(c) })
+		secureAPI.GET("/analysis/types", func(c *gin.Context) { app.ReportCtrl.GetAnalysisTypes(c) })
+		secureAPI.GET("/analysis/jobs/:id", func(c *gin.Context) { app.ReportCtrl.GetAnalysisJob(c) })
+		secureAPI.POST("/analysis/upload", func(c *gin.Context) { app.UploadCtrl.UploadAnalysis(c) })
+		secureAPI.POST("/analysis/upload-url", func(c *gin.Context) { app.UploadCtrl.IssueUploadURL(c) })
+		secureAPI.POST("/analysis/uploads", func(c *gin.Context) { app.UploadCtrl.CreateUploadSession(c) })
+		secureAPI.GET("/analysis/uploads/:id", func(c *gin.Context) { app.UploadCtrl.GetUploadSession(c) })
+		secureAPI.PATCH("/analysis/uploads/:id", func(c *gin.Context) { app.UploadCtrl.UploadChunk(c) })
 		secureAPI.GET("/analysis/summary", func(c *gin.Context) { app.ReportCtrl.GetAnalysisSummary(c) })
-		secureAPI.GET("/analysis/all", func(c *gin.Context) { app.ReportCtrl.GetAnalyzes(c) })
 		secureAPI.GET("/analysis/rank", func(c *gin.Context) { app.ReportCtrl.GetAnalysisRankAtAgeAndGender(c) })
+
+		secureAPI.GET("/v2/analysis", func(c *gin.Context) { app.ReportCtrl.GetAnalysisV2(c) })
+		secureAPI.GET("/v2/analysis/:id", func(c *gin.Context) { app.ReportCtrl.GetAnalysisByIdV2(c) })
+		secureAPI.POST("/v2/analysis/:id/alerts", func(c *gin.Context) { app.ReportCtrl.RecordAlertEvents(c) })
+	}
+
+	adminAPI := app.Router.Group("/admin")
+	adminAPI.Use(authMiddleware.StripTokenMiddleware())
+	app.InitAdminRouter(adminAPI)
+}
+
+// InitAdminRouter adds the admin routes to adminAPI. Every route needs a permission of the caller's
+// role on top of a valid token. Admin operations are only served here; over gRPC only GetAllReports exists.
+func (app *App) InitAdminRouter(adminAPI *gin.RouterGroup) {
+	adminAPI.GET("/analysis/all", auth.RequirePermission(auth.PermissionReadAllReports), func(c *gin.Context) { app.ReportCtrl.GetAnalyzes(c) })
+	adminAPI.GET("/analysis/dead-letters", auth.RequirePermission(auth.PermissionManageAnalysis), func(c *gin.Context) { app.ReportCtrl.GetDeadLetters(c) })
+	adminAPI.POST("/analysis/dead-letters/:id/redrive", auth.RequirePermission(auth.PermissionManageAnalysis), func(c *gin.Context) { app.ReportCtrl.RedriveDeadLetter(c) })
+	adminAPI.GET("/analysis/endpoints", auth.RequirePermission(auth.PermissionManageAnalysis), func(c *gin.Context) { app.ReportCtrl.GetAnalyzerEndpoints(c) })
+	adminAPI.GET("/analysis/queue", auth.RequirePermission(auth.PermissionManageAnalysis), func(c *gin.Context) { app.ReportCtrl.GetAnalysisQueue(c) })
+	adminAPI.GET("/analysis/rejections", auth.RequirePermission(auth.PermissionManageAnalysis), func(c *gin.Context) { app.ReportCtrl.GetAnalysisRejections(c) })
+	adminAPI.GET("/scoring-profiles", auth.RequirePermission(auth.PermissionManageScoring), func(c *gin.Context) { app.ScoringCtrl.GetScoringProfiles(c) })
+	adminAPI.POST("/scoring-profiles", auth.RequirePermission(auth.PermissionManageScoring), func(c *gin.Context) { app.ScoringCtrl.CreateScoringProfile(c) })
+	adminAPI.POST("/scoring-profiles/:version/activate", auth.RequirePermission(auth.PermissionManageScoring), func(c *gin.Context) { app.ScoringCtrl.ActivateScoringProfile(c) })
+}
+
+// newKeyRing signs access tokens with the PEM private key in JWT_SIGNING_KEY_FILE, published as
+// JWT_SIGNING_KEY_ID. JWT_VERIFICATION_KEY_FILES lists further keys as comma-separated kid=path pairs:
+// the next key before it becomes the signing key, and the previous one until its tokens expire.
+// JWT_SECRET still verifies tokens signed before keys rotated, if they were issued before
+// JWT_SECRET_ISSUED_BEFORE and no longer than LegacyTokenMaxAge ago. Startup fails without a signing
+// key, unless JWT_EPHEMERAL_KEY=true generates one for local development: its tokens are rejected by
+// other instances and do not outlive the process.
+func newKeyRing() *auth.KeyRing {
+	var active auth.SigningKey
+	var err error
+	if file := os.Getenv("JWT_SIGNING_KEY_FILE"); file != "" {
+		active, err = readSigningKey(os.Getenv("JWT_SIGNING_KEY_ID"), file)
+	} else if os.Getenv("JWT_EPHEMERAL_KEY") == "true" {
+		log.Println("JWT_EPHEMERAL_KEY is set, signing tokens with an ephemeral key")
+		active, err = auth.GenerateSigningKey("ephemeral", auth.AlgorithmRS256)
+	} else {
+		log.Fatal("JWT_SIGNING_KEY_FILE is not set; set JWT_EPHEMERAL_KEY=true to sign with an ephemeral key in development")
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var verificationKeys []auth.SigningKey
+	for _, entry := range splitList(os.Getenv("JWT_VERIFICATION_KEY_FILES")) {
+		kid, file, ok := strings.Cut(entry, "=")
+		if !ok {
+			log.Fatalf("JWT_VERIFICATION_KEY_FILES entry %q is not kid=path", entry)
+		}
+		key, err := readSigningKey(strings.TrimSpace(kid), strings.TrimSpace(file))
+		if err != nil {
+			log.Fatal(err)
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		issuedBefore, err := time.Parse(time.RFC3339, os.Getenv("JWT_SECRET_ISSUED_BEFORE"))
+		if err != nil {
+			log.Fatal("JWT_SECRET needs JWT_SECRET_ISSUED_BEFORE, the RFC 3339 time the server stopped signing tokens with it")
+		}
+		verificationKeys = append(verificationKeys, auth.LegacySecretKey(secret, issuedBefore))
+	}
+
+	keys, err := auth.NewKeyRing(active, verificationKeys...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return keys
+}
+
+func readSigningKey(kid string, file string) (auth.SigningKey, error) {
+	if kid == "" {
+		return auth.SigningKey{}, fmt.Errorf("signing key %s has no key ID", file)
+	}
+
+	pemBytes, err := os.ReadFile(file)
+	if err != nil {
+		return auth.SigningKey{}, err
+	}
+
+	return auth.ParseSigningKeyPEM(kid, pemBytes)
+}
+
+// newIDTokenVerifier accepts Google sign-in tokens issued for GOOGLE_CLIENT_IDS and Firebase tokens of
+// FIREBASE_PROJECT_ID. Keys are fetched from Google unless ID_TOKEN_JWKS_FILE names a key set to use
+// instead, such as a fake one to log in offline.
+func newIDTokenVerifier() *auth.IDTokenVerifier {
+	var issuers, audiences, urls []string
+	if clientIDs := splitList(os.Getenv("GOOGLE_CLIENT_IDS")); len(clientIDs) > 0 {
+		issuers = append(issuers, auth.GoogleIssuers...)
+		audiences = append(audiences, clientIDs...)
+		urls = append(urls, auth.GoogleJWKSURL)
+	}
+	if projectID := os.Getenv("FIREBASE_PROJECT_ID"); projectID != "" {
+		issuers = append(issuers, auth.FirebaseIssuerBase+projectID)
+		audiences = append(audiences, projectID)
+		urls = append(urls, auth.FirebaseJWKSURL)
+	}
+	if len(audiences) == 0 {
+		log.Println("neither GOOGLE_CLIENT_IDS nor FIREBASE_PROJECT_ID is set, every login will be rejected")
+	}
+
+	var keys auth.KeySet
+	var err error
+	if file := os.Getenv("ID_TOKEN_JWKS_FILE"); file != "" {
+		jwksJSON, readErr := os.ReadFile(file)
+		if readErr != nil {
+			log.Fatal(readErr)
+		}
+		keys, err = auth.NewStaticKeySet(jwksJSON)
+	} else {
+		keys, err = auth.NewRemoteKeySet(urls, func(err error) { log.Printf("Failed to refresh ID token keys: %v", err) })
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return auth.NewIDTokenVerifier(keys, issuers, audiences)
+}
+
+// newTokenService issues access tokens valid for ACCESS_TOKEN_TTL and refresh tokens valid for
+// REFRESH_TOKEN_TTL, falling back to the defaults.
+func newTokenService(DB *gorm.DB, users userRepository.UserRepositoryInterface, keys *auth.KeyRing) *userService.TokenService {
+	service := userService.NewTokenService(userRepository.NewRefreshTokenRepository(DB), users, keys)
+	if ttl, err := time.ParseDuration(os.Getenv("ACCESS_TOKEN_TTL")); err == nil && ttl > 0 {
+		service.AccessTokenTTL = ttl
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL")); err == nil && ttl > 0 {
+		service.RefreshTokenTTL = ttl
+	}
+
+	return service
+}
+
+// newAnalyzer returns the client of the AI servers at urls, or an in-process fake when AI_SERVER_FAKE
+// is set so the server can run without the AI VM.
+func newAnalyzer(urls string) analyzers.Analyzer {
+	if os.Getenv("AI_SERVER_FAKE") == "true" {
+		log.Println("AI_SERVER_FAKE is set, using fake analyzer")
+		return analyzers.NewFakeAnalyzer()
+	}
+
+	balancer := newBalancedAnalyzer(urls)
+	balancer.StartHealthChecks(context.Background())
+
+	return analyzers.NewRetryAnalyzer(balancer, newRetryPolicy())
+}
+
+// aiServerURLs returns the comma-separated AI_SERVER_API_URLS (or the single AI_SERVER_API_URL),
+// suffixed with _<SUFFIX> when suffix is set.
+func aiServerURLs(suffix string) string {
+	if suffix != "" {
+		suffix = "_" + strings.ToUpper(suffix)
+	}
+
+	urls := os.Getenv("AI_SERVER_API_URLS" + suffix)
+	if urls == "" {
+		urls = os.Getenv("AI_SERVER_API_URL" + suffix)
 	}
+
+	return urls
+}
+
+// newAnalysisTypes returns the built-in analysis types. A type is analyzed by its own AI servers when
+// AI_SERVER_API_URLS_<TYPE> is set, and scored with the profile SCORING_PROFILE_<TYPE> when that is set.
+// ANALYSIS_TYPE_ALIASES maps more legacy types to built-in ones, e.g. "Work=side_sitting,Game=side_sitting".
+func newAnalysisTypes() *reportService.AnalysisTypeRegistry {
+	analysisTypes := reportService.DefaultAnalysisTypes()
+	for i := range analysisTypes {
+		if urls := aiServerURLs(analysisTypes[i].Name); urls != "" {
+			analysisTypes[i].Analyzer = newAnalyzer(urls)
+		}
+		analysisTypes[i].ScoringProfile = os.Getenv("SCORING_PROFILE_" + strings.ToUpper(analysisTypes[i].Name))
+	}
+
+	aliases := reportService.DefaultAnalysisTypeAliases()
+	for _, entry := range splitList(os.Getenv("ANALYSIS_TYPE_ALIASES")) {
+		alias, name, ok := strings.Cut(entry, "=")
+		if !ok {
+			log.Fatalf("analysis type alias %q is not of the form alias=type", entry)
+		}
+		aliases[alias] = strings.TrimSpace(name)
+	}
+
+	registry := reportService.NewAnalysisTypeRegistry(analysisTypes...)
+	if err := registry.AddAliases(aliases); err != nil {
+		log.Fatalf("analysis type aliases: %v", err)
+	}
+
+	return registry
+}
+
+// aiServerTimeout returns AI_SERVER_TIMEOUT, the time a single AI server request may take.
+func aiServerTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(os.Getenv("AI_SERVER_TIMEOUT")); err == nil && timeout > 0 {
+		return timeout
+	}
+
+	return analyzers.DefaultTimeout
+}
+
+// newBalancedAnalyzer spreads requests over the comma-separated urls, each endpoint guarded by its
+// own circuit breaker. Endpoints are health-checked at AI_SERVER_HEALTH_PATH when it is set.
+func newBalancedAnalyzer(urls string) *analyzers.BalancedAnalyzer {
+	timeout := aiServerTimeout()
+	failureThreshold, _ := strconv.Atoi(os.Getenv("AI_BREAKER_FAILURE_THRESHOLD"))
+	openTimeout, _ := time.ParseDuration(os.Getenv("AI_BREAKER_OPEN_TIMEOUT"))
+	healthPath := os.Getenv("AI_SERVER_HEALTH_PATH")
+
+	var endpoints []*analyzers.Endpoint
+	for _, url := range strings.Split(urls, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+
+		healthURL := ""
+		if healthPath != "" {
+			healthURL = strings.TrimRight(url, "/") + "/" + strings.TrimLeft(healthPath, "/")
+		}
+
+		endpoints = append(endpoints, analyzers.NewEndpoint(
+			url,
+			healthURL,
+			analyzers.NewHTTPAnalyzer(url, timeout),
+			analyzers.NewCircuitBreaker(failureThreshold, openTimeout),
+		))
+	}
+
+	balancer := analyzers.NewBalancedAnalyzer(endpoints, analyzers.BalanceStrategy(os.Getenv("AI_SERVER_BALANCE_STRATEGY")))
+	if healthInterval, err := time.ParseDuration(os.Getenv("AI_SERVER_HEALTH_INTERVAL")); err == nil && healthInterval > 0 {
+		balancer.HealthInterval = healthInterval
+	}
+
+	return balancer
+}
+
+// newBlobStore stores uploaded videos in the GCS bucket GCS_BUCKET when STORAGE_BACKEND is "gcs",
+// otherwise under LOCAL_STORAGE_DIR, served at /files only with URLs signed with LOCAL_STORAGE_SECRET
+// (UPLOAD_TOKEN_SECRET when unset) for the AI server to download.
+func (app *App) newBlobStore() storage.BlobStore {
+	if os.Getenv("STORAGE_BACKEND") == "gcs" {
+		credentials := os.Getenv("GCS_CREDENTIALS")
+		if credentials == "" {
+			credentials = os.Getenv("FIREBASE_SERVICE_KEY")
+		}
+
+		store, err := storage.NewGCSStore(context.Background(), os.Getenv("GCS_BUCKET"), credentials)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return store
+	}
+
+	root := os.Getenv("LOCAL_STORAGE_DIR")
+	if root == "" {
+		root = "data/blobs"
+	}
+	baseURL := os.Getenv("LOCAL_STORAGE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080/files"
+	}
+
+	store, err := storage.NewLocalStore(root, baseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	store.ReadSecret = os.Getenv("LOCAL_STORAGE_SECRET")
+	if store.ReadSecret == "" {
+		store.ReadSecret = os.Getenv("UPLOAD_TOKEN_SECRET")
+	}
+	if store.ReadSecret == "" {
+		log.Println("neither LOCAL_STORAGE_SECRET nor UPLOAD_TOKEN_SECRET is set, stored videos cannot be analyzed")
+	}
+	app.Router.GET("/files/*key", func(c *gin.Context) {
+		store.ServeSigned(c.Writer, c.Request, strings.TrimPrefix(c.Param("key"), "/"))
+	})
+
+	return store
+}
+
+// newAnalysisWorkerPool sizes the worker pool from ANALYSIS_WORKERS, ANALYSIS_MAX_QUEUED
+// and ANALYSIS_RETRY_AFTER, falling back to the defaults.
+func newAnalysisWorkerPool(service *reportService.ReportService) *reportService.AnalysisWorkerPool {
+	workers, _ := strconv.Atoi(os.Getenv("ANALYSIS_WORKERS"))
+	pool := reportService.NewAnalysisWorkerPool(service, workers)
+
+	if maxQueued, err := strconv.Atoi(os.Getenv("ANALYSIS_MAX_QUEUED")); err == nil {
+		pool.MaxQueued = maxQueued
+	}
+	if retryAfter, err := time.ParseDuration(os.Getenv("ANALYSIS_RETRY_AFTER")); err == nil && retryAfter > 0 {
+		pool.RetryAfter = retryAfter
+	}
+	// A job is only stale once it has run longer than an analysis with every retry can take.
+	pool.StaleAfter = reportService.StaleAfterFor(aiServerTimeout(), newRetryPolicy())
+	if staleAfter, err := time.ParseDuration(os.Getenv("ANALYSIS_STALE_AFTER")); err == nil && staleAfter > 0 {
+		if staleAfter < pool.StaleAfter {
+			log.Printf("ANALYSIS_STALE_AFTER %s is shorter than an analysis can run, using %s", staleAfter, pool.StaleAfter)
+		} else {
+			pool.StaleAfter = staleAfter
+		}
+	}
+	if sweepInterval, err := time.ParseDuration(os.Getenv("ANALYSIS_SWEEP_INTERVAL")); err == nil && sweepInterval > 0 {
+		pool.SweepInterval = sweepInterval
+	}
+
+	return pool
+}
+
+// newURLPolicy builds the policy for submitted video URLs from VIDEO_URL_* settings.
+// Videos stored by this server are trusted even when the blob store is on a private address.
+func newURLPolicy(trustedPrefix string) *urlpolicy.Policy {
+	schemes := splitList(os.Getenv("VIDEO_URL_SCHEMES"))
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	policy := urlpolicy.NewPolicy(schemes, splitList(os.Getenv("VIDEO_URL_HOSTS")))
+	policy.TrustedPrefixes = []string{trustedPrefix}
+	if maxLength, err := strconv.Atoi(os.Getenv("VIDEO_URL_MAX_LENGTH")); err == nil && maxLength > 0 {
+		policy.MaxLength = maxLength
+	}
+
+	return policy
+}
+
+// splitList splits a comma-separated setting, dropping empty entries.
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// newRetryPolicy reads AI_RETRY_* overrides on top of the default retry policy.
+func newRetryPolicy() analyzers.RetryPolicy {
+	policy := analyzers.DefaultRetryPolicy()
+
+	if maxAttempts, err := strconv.Atoi(os.Getenv("AI_RETRY_MAX_ATTEMPTS")); err == nil {
+		policy.MaxAttempts = maxAttempts
+	}
+	if baseDelay, err := time.ParseDuration(os.Getenv("AI_RETRY_BASE_DELAY")); err == nil {
+		policy.BaseDelay = baseDelay
+	}
+	if maxDelay, err := time.ParseDuration(os.Getenv("AI_RETRY_MAX_DELAY")); err == nil {
+		policy.MaxDelay = maxDelay
+	}
+	if jitter, err := strconv.ParseFloat(os.Getenv("AI_RETRY_JITTER"), 64); err == nil {
+		policy.Jitter = jitter
+	}
+
+	return policy
 }
 
 // @SecurityDefinitions.apikey Bearer
 // @in header
 // @name Authorization
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "rescore" {
+		runRescore(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "grant-role" {
+		runGrantRole(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		runBackfill(os.Args[2:])
+		return
+	}
+
 	app := App{}
 	app.Init()
 
@@ -156,6 +623,8 @@ func main() {
 
 	videoRepository := videoRepository.NewVideoRepository(DB)
 
+	app.ReportService.Workers.Start(context.Background())
+
 	go app.RunGrpcServer(grpcL, userRepository, userUtil, videoRepository)
 	go app.RunHttpServer(httpL)
 
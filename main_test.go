@@ -0,0 +1,38 @@
+package main
+
+import (
+	"gdsc/baro/global/auth"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitAdminRouter_RequiresPermission(t *testing.T) {
+	// Create a router where the role is set the way StripTokenMiddleware sets it. A route missing its
+	// permission check reaches the nil controllers and the panic is recovered as a 500
+	router := gin.New()
+	router.Use(gin.Recovery())
+	adminAPI := router.Group("/admin", func(c *gin.Context) {
+		c.Set(string(auth.RoleKey), auth.RoleUser)
+	})
+	(&App{}).InitAdminRouter(adminAPI)
+
+	routes := router.Routes()
+	assert.NotEmpty(t, routes)
+
+	params := regexp.MustCompile(`:[^/]+`)
+	for _, route := range routes {
+		path := params.ReplaceAllString(route.Path, "1")
+
+		// Call the route as a user
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(route.Method, path, nil))
+
+		// Check the results
+		assert.Equal(t, http.StatusForbidden, w.Code, "%s %s", route.Method, route.Path)
+	}
+}
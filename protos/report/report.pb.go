@@ -0,0 +1,3111 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.1
+// source: protos/report/report.proto
+
+package report
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RequestAnalysis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VideoUrl     string `protobuf:"bytes,1,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	AlertCount   int32  `protobuf:"varint,2,opt,name=alert_count,json=alertCount,proto3" json:"alert_count,omitempty"`
+	AnalysisTime int32  `protobuf:"varint,3,opt,name=analysis_time,json=analysisTime,proto3" json:"analysis_time,omitempty"`
+	Type         string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *RequestAnalysis) Reset() {
+	*x = RequestAnalysis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAnalysis) ProtoMessage() {}
+
+func (x *RequestAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAnalysis.ProtoReflect.Descriptor instead.
+func (*RequestAnalysis) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestAnalysis) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *RequestAnalysis) GetAlertCount() int32 {
+	if x != nil {
+		return x.AlertCount
+	}
+	return 0
+}
+
+func (x *RequestAnalysis) GetAnalysisTime() int32 {
+	if x != nil {
+		return x.AnalysisTime
+	}
+	return 0
+}
+
+func (x *RequestAnalysis) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type RequestAnalysisJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RequestAnalysisJob) Reset() {
+	*x = RequestAnalysisJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestAnalysisJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAnalysisJob) ProtoMessage() {}
+
+func (x *RequestAnalysisJob) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAnalysisJob.ProtoReflect.Descriptor instead.
+func (*RequestAnalysisJob) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RequestAnalysisJob) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ResponseAnalysisJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                 // queued, running, succeeded, failed
+	ErrorMessage   string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Set when status is failed
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ReportPublicId string                 `protobuf:"bytes,7,opt,name=report_public_id,json=reportPublicId,proto3" json:"report_public_id,omitempty"` // Set when status is succeeded
+}
+
+func (x *ResponseAnalysisJob) Reset() {
+	*x = ResponseAnalysisJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseAnalysisJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseAnalysisJob) ProtoMessage() {}
+
+func (x *ResponseAnalysisJob) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseAnalysisJob.ProtoReflect.Descriptor instead.
+func (*ResponseAnalysisJob) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResponseAnalysisJob) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ResponseAnalysisJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ResponseAnalysisJob) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ResponseAnalysisJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ResponseAnalysisJob) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ResponseAnalysisJob) GetReportPublicId() string {
+	if x != nil {
+		return x.ReportPublicId
+	}
+	return ""
+}
+
+type UploadAnalysisInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContentType  string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // e.g. video/mp4
+	AlertCount   int32  `protobuf:"varint,2,opt,name=alert_count,json=alertCount,proto3" json:"alert_count,omitempty"`
+	AnalysisTime int32  `protobuf:"varint,3,opt,name=analysis_time,json=analysisTime,proto3" json:"analysis_time,omitempty"`
+	Type         string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *UploadAnalysisInfo) Reset() {
+	*x = UploadAnalysisInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadAnalysisInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAnalysisInfo) ProtoMessage() {}
+
+func (x *UploadAnalysisInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAnalysisInfo.ProtoReflect.Descriptor instead.
+func (*UploadAnalysisInfo) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadAnalysisInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadAnalysisInfo) GetAlertCount() int32 {
+	if x != nil {
+		return x.AlertCount
+	}
+	return 0
+}
+
+func (x *UploadAnalysisInfo) GetAnalysisTime() int32 {
+	if x != nil {
+		return x.AnalysisTime
+	}
+	return 0
+}
+
+func (x *UploadAnalysisInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// The first message of an upload carries info, every following message a chunk of the video.
+type RequestUploadAnalysis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*RequestUploadAnalysis_Info
+	//	*RequestUploadAnalysis_Chunk
+	Data isRequestUploadAnalysis_Data `protobuf_oneof:"data"`
+}
+
+func (x *RequestUploadAnalysis) Reset() {
+	*x = RequestUploadAnalysis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestUploadAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestUploadAnalysis) ProtoMessage() {}
+
+func (x *RequestUploadAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestUploadAnalysis.ProtoReflect.Descriptor instead.
+func (*RequestUploadAnalysis) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{4}
+}
+
+func (m *RequestUploadAnalysis) GetData() isRequestUploadAnalysis_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *RequestUploadAnalysis) GetInfo() *UploadAnalysisInfo {
+	if x, ok := x.GetData().(*RequestUploadAnalysis_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *RequestUploadAnalysis) GetChunk() []byte {
+	if x, ok := x.GetData().(*RequestUploadAnalysis_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isRequestUploadAnalysis_Data interface {
+	isRequestUploadAnalysis_Data()
+}
+
+type RequestUploadAnalysis_Info struct {
+	Info *UploadAnalysisInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type RequestUploadAnalysis_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*RequestUploadAnalysis_Info) isRequestUploadAnalysis_Data() {}
+
+func (*RequestUploadAnalysis_Chunk) isRequestUploadAnalysis_Data() {}
+
+type KeypointAnalysisInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AlertCount   int32  `protobuf:"varint,1,opt,name=alert_count,json=alertCount,proto3" json:"alert_count,omitempty"`
+	AnalysisTime int32  `protobuf:"varint,2,opt,name=analysis_time,json=analysisTime,proto3" json:"analysis_time,omitempty"`
+	Type         string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *KeypointAnalysisInfo) Reset() {
+	*x = KeypointAnalysisInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeypointAnalysisInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeypointAnalysisInfo) ProtoMessage() {}
+
+func (x *KeypointAnalysisInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeypointAnalysisInfo.ProtoReflect.Descriptor instead.
+func (*KeypointAnalysisInfo) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *KeypointAnalysisInfo) GetAlertCount() int32 {
+	if x != nil {
+		return x.AlertCount
+	}
+	return 0
+}
+
+func (x *KeypointAnalysisInfo) GetAnalysisTime() int32 {
+	if x != nil {
+		return x.AnalysisTime
+	}
+	return 0
+}
+
+func (x *KeypointAnalysisInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Landmark struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"` // Normalized to the frame width, 0 to 1
+	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"` // Normalized to the frame height, 0 to 1, growing downwards
+}
+
+func (x *Landmark) Reset() {
+	*x = Landmark{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Landmark) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Landmark) ProtoMessage() {}
+
+func (x *Landmark) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Landmark.ProtoReflect.Descriptor instead.
+func (*Landmark) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Landmark) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Landmark) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type KeypointFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LeftShoulder *Landmark `protobuf:"bytes,1,opt,name=left_shoulder,json=leftShoulder,proto3" json:"left_shoulder,omitempty"`
+	LeftEar      *Landmark `protobuf:"bytes,2,opt,name=left_ear,json=leftEar,proto3" json:"left_ear,omitempty"`
+}
+
+func (x *KeypointFrame) Reset() {
+	*x = KeypointFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeypointFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeypointFrame) ProtoMessage() {}
+
+func (x *KeypointFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeypointFrame.ProtoReflect.Descriptor instead.
+func (*KeypointFrame) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *KeypointFrame) GetLeftShoulder() *Landmark {
+	if x != nil {
+		return x.LeftShoulder
+	}
+	return nil
+}
+
+func (x *KeypointFrame) GetLeftEar() *Landmark {
+	if x != nil {
+		return x.LeftEar
+	}
+	return nil
+}
+
+type KeypointFrames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Frames []*KeypointFrame `protobuf:"bytes,1,rep,name=frames,proto3" json:"frames,omitempty"`
+}
+
+func (x *KeypointFrames) Reset() {
+	*x = KeypointFrames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeypointFrames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeypointFrames) ProtoMessage() {}
+
+func (x *KeypointFrames) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeypointFrames.ProtoReflect.Descriptor instead.
+func (*KeypointFrames) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KeypointFrames) GetFrames() []*KeypointFrame {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+// The first message of a keypoint analysis carries info, every following message a batch of frames in order.
+type RequestKeypointAnalysis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*RequestKeypointAnalysis_Info
+	//	*RequestKeypointAnalysis_Frames
+	Data isRequestKeypointAnalysis_Data `protobuf_oneof:"data"`
+}
+
+func (x *RequestKeypointAnalysis) Reset() {
+	*x = RequestKeypointAnalysis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestKeypointAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestKeypointAnalysis) ProtoMessage() {}
+
+func (x *RequestKeypointAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestKeypointAnalysis.ProtoReflect.Descriptor instead.
+func (*RequestKeypointAnalysis) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *RequestKeypointAnalysis) GetData() isRequestKeypointAnalysis_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *RequestKeypointAnalysis) GetInfo() *KeypointAnalysisInfo {
+	if x, ok := x.GetData().(*RequestKeypointAnalysis_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *RequestKeypointAnalysis) GetFrames() *KeypointFrames {
+	if x, ok := x.GetData().(*RequestKeypointAnalysis_Frames); ok {
+		return x.Frames
+	}
+	return nil
+}
+
+type isRequestKeypointAnalysis_Data interface {
+	isRequestKeypointAnalysis_Data()
+}
+
+type RequestKeypointAnalysis_Info struct {
+	Info *KeypointAnalysisInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type RequestKeypointAnalysis_Frames struct {
+	Frames *KeypointFrames `protobuf:"bytes,2,opt,name=frames,proto3,oneof"`
+}
+
+func (*RequestKeypointAnalysis_Info) isRequestKeypointAnalysis_Data() {}
+
+func (*RequestKeypointAnalysis_Frames) isRequestKeypointAnalysis_Data() {}
+
+type PostureSessionStart struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *PostureSessionStart) Reset() {
+	*x = PostureSessionStart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostureSessionStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostureSessionStart) ProtoMessage() {}
+
+func (x *PostureSessionStart) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostureSessionStart.ProtoReflect.Descriptor instead.
+func (*PostureSessionStart) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PostureSessionStart) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type PostureFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ElapsedMs int64          `protobuf:"varint,1,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"` // Since the session started
+	Keypoints *KeypointFrame `protobuf:"bytes,2,opt,name=keypoints,proto3" json:"keypoints,omitempty"`
+}
+
+func (x *PostureFrame) Reset() {
+	*x = PostureFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostureFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostureFrame) ProtoMessage() {}
+
+func (x *PostureFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostureFrame.ProtoReflect.Descriptor instead.
+func (*PostureFrame) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PostureFrame) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *PostureFrame) GetKeypoints() *KeypointFrame {
+	if x != nil {
+		return x.Keypoints
+	}
+	return nil
+}
+
+// A posture the app classified itself.
+type PostureState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ElapsedMs  int64   `protobuf:"varint,1,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"` // Since the session started
+	Hunched    bool    `protobuf:"varint,2,opt,name=hunched,proto3" json:"hunched,omitempty"`
+	Confidence float64 `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"` // 0 to 100
+}
+
+func (x *PostureState) Reset() {
+	*x = PostureState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostureState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostureState) ProtoMessage() {}
+
+func (x *PostureState) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostureState.ProtoReflect.Descriptor instead.
+func (*PostureState) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PostureState) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *PostureState) GetHunched() bool {
+	if x != nil {
+		return x.Hunched
+	}
+	return false
+}
+
+func (x *PostureState) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+// The first message of a posture session carries start, every following message a frame or a state.
+type RequestPostureSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*RequestPostureSession_Start
+	//	*RequestPostureSession_Frame
+	//	*RequestPostureSession_State
+	Data isRequestPostureSession_Data `protobuf_oneof:"data"`
+}
+
+func (x *RequestPostureSession) Reset() {
+	*x = RequestPostureSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestPostureSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPostureSession) ProtoMessage() {}
+
+func (x *RequestPostureSession) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPostureSession.ProtoReflect.Descriptor instead.
+func (*RequestPostureSession) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{13}
+}
+
+func (m *RequestPostureSession) GetData() isRequestPostureSession_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *RequestPostureSession) GetStart() *PostureSessionStart {
+	if x, ok := x.GetData().(*RequestPostureSession_Start); ok {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *RequestPostureSession) GetFrame() *PostureFrame {
+	if x, ok := x.GetData().(*RequestPostureSession_Frame); ok {
+		return x.Frame
+	}
+	return nil
+}
+
+func (x *RequestPostureSession) GetState() *PostureState {
+	if x, ok := x.GetData().(*RequestPostureSession_State); ok {
+		return x.State
+	}
+	return nil
+}
+
+type isRequestPostureSession_Data interface {
+	isRequestPostureSession_Data()
+}
+
+type RequestPostureSession_Start struct {
+	Start *PostureSessionStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type RequestPostureSession_Frame struct {
+	Frame *PostureFrame `protobuf:"bytes,2,opt,name=frame,proto3,oneof"`
+}
+
+type RequestPostureSession_State struct {
+	State *PostureState `protobuf:"bytes,3,opt,name=state,proto3,oneof"`
+}
+
+func (*RequestPostureSession_Start) isRequestPostureSession_Data() {}
+
+func (*RequestPostureSession_Frame) isRequestPostureSession_Data() {}
+
+func (*RequestPostureSession_State) isRequestPostureSession_Data() {}
+
+type PostureEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind         string  `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // alert, score, break
+	ElapsedMs    int64   `protobuf:"varint,2,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	Score        float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"` // Running score of the session
+	NormalRatio  float64 `protobuf:"fixed64,4,opt,name=normal_ratio,json=normalRatio,proto3" json:"normal_ratio,omitempty"`
+	HunchedForMs int64   `protobuf:"varint,5,opt,name=hunched_for_ms,json=hunchedForMs,proto3" json:"hunched_for_ms,omitempty"` // Set for alerts
+	Severity     string  `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"`                                // Set for alerts: low, medium, high
+}
+
+func (x *PostureEvent) Reset() {
+	*x = PostureEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostureEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostureEvent) ProtoMessage() {}
+
+func (x *PostureEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostureEvent.ProtoReflect.Descriptor instead.
+func (*PostureEvent) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PostureEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PostureEvent) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *PostureEvent) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PostureEvent) GetNormalRatio() float64 {
+	if x != nil {
+		return x.NormalRatio
+	}
+	return 0
+}
+
+func (x *PostureEvent) GetHunchedForMs() int64 {
+	if x != nil {
+		return x.HunchedForMs
+	}
+	return 0
+}
+
+func (x *PostureEvent) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+// Events are sent while the session runs. Once the app closes its side, the saved report is the last message.
+type ResponsePostureSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*ResponsePostureSession_Event
+	//	*ResponsePostureSession_Report
+	Data isResponsePostureSession_Data `protobuf_oneof:"data"`
+}
+
+func (x *ResponsePostureSession) Reset() {
+	*x = ResponsePostureSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponsePostureSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponsePostureSession) ProtoMessage() {}
+
+func (x *ResponsePostureSession) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponsePostureSession.ProtoReflect.Descriptor instead.
+func (*ResponsePostureSession) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{15}
+}
+
+func (m *ResponsePostureSession) GetData() isResponsePostureSession_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *ResponsePostureSession) GetEvent() *PostureEvent {
+	if x, ok := x.GetData().(*ResponsePostureSession_Event); ok {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ResponsePostureSession) GetReport() *ResponseReport {
+	if x, ok := x.GetData().(*ResponsePostureSession_Report); ok {
+		return x.Report
+	}
+	return nil
+}
+
+type isResponsePostureSession_Data interface {
+	isResponsePostureSession_Data()
+}
+
+type ResponsePostureSession_Event struct {
+	Event *PostureEvent `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type ResponsePostureSession_Report struct {
+	Report *ResponseReport `protobuf:"bytes,2,opt,name=report,proto3,oneof"`
+}
+
+func (*ResponsePostureSession_Event) isResponsePostureSession_Data() {}
+
+func (*ResponsePostureSession_Report) isResponsePostureSession_Data() {}
+
+// Reports are looked up by public_id, or by id when public_id is empty.
+// Numeric ids are only accepted until their sunset date.
+type RequestReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Deprecated: Marked as deprecated in protos/report/report.proto.
+	Id       uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PublicId string `protobuf:"bytes,2,opt,name=public_id,json=publicId,proto3" json:"public_id,omitempty"`
+}
+
+func (x *RequestReport) Reset() {
+	*x = RequestReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReport) ProtoMessage() {}
+
+func (x *RequestReport) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReport.ProtoReflect.Descriptor instead.
+func (*RequestReport) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{16}
+}
+
+// Deprecated: Marked as deprecated in protos/report/report.proto.
+func (x *RequestReport) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RequestReport) GetPublicId() string {
+	if x != nil {
+		return x.PublicId
+	}
+	return ""
+}
+
+type RequestReports struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RequestReports) Reset() {
+	*x = RequestReports{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestReports) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReports) ProtoMessage() {}
+
+func (x *RequestReports) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReports.ProtoReflect.Descriptor instead.
+func (*RequestReports) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{17}
+}
+
+// Admin only: the reports of every user, without their frames.
+type RequestAllReports struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RequestAllReports) Reset() {
+	*x = RequestAllReports{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestAllReports) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAllReports) ProtoMessage() {}
+
+func (x *RequestAllReports) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAllReports.ProtoReflect.Descriptor instead.
+func (*RequestAllReports) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{18}
+}
+
+// Points defaults to 200, method to bucket for class and to lttb otherwise.
+type RequestTimeseries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Deprecated: Marked as deprecated in protos/report/report.proto.
+	ReportId       uint64 `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Metric         string `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"` // angle, distance or class
+	Points         int32  `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`
+	Method         string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`                                         // lttb or bucket
+	ReportPublicId string `protobuf:"bytes,5,opt,name=report_public_id,json=reportPublicId,proto3" json:"report_public_id,omitempty"` // Used instead of report_id when set
+}
+
+func (x *RequestTimeseries) Reset() {
+	*x = RequestTimeseries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestTimeseries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTimeseries) ProtoMessage() {}
+
+func (x *RequestTimeseries) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTimeseries.ProtoReflect.Descriptor instead.
+func (*RequestTimeseries) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{19}
+}
+
+// Deprecated: Marked as deprecated in protos/report/report.proto.
+func (x *RequestTimeseries) GetReportId() uint64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+func (x *RequestTimeseries) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *RequestTimeseries) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *RequestTimeseries) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RequestTimeseries) GetReportPublicId() string {
+	if x != nil {
+		return x.ReportPublicId
+	}
+	return ""
+}
+
+// Bucketed points average their frames, with min and max set.
+type TimeseriesPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	T     float64  `protobuf:"fixed64,1,opt,name=t,proto3" json:"t,omitempty"` // Seconds since the analysis started
+	Frame int32    `protobuf:"varint,2,opt,name=frame,proto3" json:"frame,omitempty"`
+	Value float64  `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Min   *float64 `protobuf:"fixed64,4,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max   *float64 `protobuf:"fixed64,5,opt,name=max,proto3,oneof" json:"max,omitempty"`
+}
+
+func (x *TimeseriesPoint) Reset() {
+	*x = TimeseriesPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeseriesPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeseriesPoint) ProtoMessage() {}
+
+func (x *TimeseriesPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeseriesPoint.ProtoReflect.Descriptor instead.
+func (*TimeseriesPoint) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TimeseriesPoint) GetT() float64 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *TimeseriesPoint) GetFrame() int32 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *TimeseriesPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TimeseriesPoint) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *TimeseriesPoint) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+type ResponseTimeseries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metric         string             `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	Method         string             `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	TotalFrames    int32              `protobuf:"varint,4,opt,name=total_frames,json=totalFrames,proto3" json:"total_frames,omitempty"`
+	Points         []*TimeseriesPoint `protobuf:"bytes,5,rep,name=points,proto3" json:"points,omitempty"`
+	ReportPublicId string             `protobuf:"bytes,6,opt,name=report_public_id,json=reportPublicId,proto3" json:"report_public_id,omitempty"`
+}
+
+func (x *ResponseTimeseries) Reset() {
+	*x = ResponseTimeseries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseTimeseries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseTimeseries) ProtoMessage() {}
+
+func (x *ResponseTimeseries) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseTimeseries.ProtoReflect.Descriptor instead.
+func (*ResponseTimeseries) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResponseTimeseries) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *ResponseTimeseries) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ResponseTimeseries) GetTotalFrames() int32 {
+	if x != nil {
+		return x.TotalFrames
+	}
+	return 0
+}
+
+func (x *ResponseTimeseries) GetPoints() []*TimeseriesPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *ResponseTimeseries) GetReportPublicId() string {
+	if x != nil {
+		return x.ReportPublicId
+	}
+	return ""
+}
+
+type RequestAnalysisTypes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RequestAnalysisTypes) Reset() {
+	*x = RequestAnalysisTypes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestAnalysisTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAnalysisTypes) ProtoMessage() {}
+
+func (x *RequestAnalysisTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAnalysisTypes.ProtoReflect.Descriptor instead.
+func (*RequestAnalysisTypes) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{22}
+}
+
+type AnalysisType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name            string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Sources         []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`                                           // video, keypoints, session
+	MaxAnalysisTime int32    `protobuf:"varint,4,opt,name=max_analysis_time,json=maxAnalysisTime,proto3" json:"max_analysis_time,omitempty"` // Seconds, 0 when unlimited
+	ScoringProfile  string   `protobuf:"bytes,5,opt,name=scoring_profile,json=scoringProfile,proto3" json:"scoring_profile,omitempty"`       // Empty when scored with the active profile
+}
+
+func (x *AnalysisType) Reset() {
+	*x = AnalysisType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnalysisType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisType) ProtoMessage() {}
+
+func (x *AnalysisType) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisType.ProtoReflect.Descriptor instead.
+func (*AnalysisType) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AnalysisType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AnalysisType) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AnalysisType) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *AnalysisType) GetMaxAnalysisTime() int32 {
+	if x != nil {
+		return x.MaxAnalysisTime
+	}
+	return 0
+}
+
+func (x *AnalysisType) GetScoringProfile() string {
+	if x != nil {
+		return x.ScoringProfile
+	}
+	return ""
+}
+
+type ResponseAnalysisTypes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Types []*AnalysisType `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+}
+
+func (x *ResponseAnalysisTypes) Reset() {
+	*x = ResponseAnalysisTypes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseAnalysisTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseAnalysisTypes) ProtoMessage() {}
+
+func (x *ResponseAnalysisTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseAnalysisTypes.ProtoReflect.Descriptor instead.
+func (*ResponseAnalysisTypes) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ResponseAnalysisTypes) GetTypes() []*AnalysisType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+// Reports are only identified by public_id.
+type ResponseReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId            uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AlertCount        int32                  `protobuf:"varint,3,opt,name=alert_count,json=alertCount,proto3" json:"alert_count,omitempty"`
+	AnalysisTime      int32                  `protobuf:"varint,4,opt,name=analysis_time,json=analysisTime,proto3" json:"analysis_time,omitempty"`
+	Type              string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Predict           []int32                `protobuf:"varint,6,rep,packed,name=predict,proto3" json:"predict,omitempty"`
+	Score             float64                `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"`
+	NormalRatio       float64                `protobuf:"fixed64,8,opt,name=normal_ratio,json=normalRatio,proto3" json:"normal_ratio,omitempty"`
+	NeckAngles        []float64              `protobuf:"fixed64,9,rep,packed,name=neck_angles,json=neckAngles,proto3" json:"neck_angles,omitempty"`
+	Distances         []float64              `protobuf:"fixed64,10,rep,packed,name=distances,proto3" json:"distances,omitempty"`
+	StatusFrequencies map[string]int32       `protobuf:"bytes,11,rep,name=status_frequencies,json=statusFrequencies,proto3" json:"status_frequencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // Fine, Danger, Serious, Very Serious
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ScoringProfile    string                 `protobuf:"bytes,13,opt,name=scoring_profile,json=scoringProfile,proto3" json:"scoring_profile,omitempty"` // Version of the scoring profile score was calculated with
+	Breakdown         *ScoreBreakdown        `protobuf:"bytes,14,opt,name=breakdown,proto3" json:"breakdown,omitempty"`                                 // Unset when the report has no per-frame confidences
+	AlertTimeline     []*AlertEvent          `protobuf:"bytes,15,rep,name=alert_timeline,json=alertTimeline,proto3" json:"alert_timeline,omitempty"`
+	AlertMetrics      *AlertMetrics          `protobuf:"bytes,16,opt,name=alert_metrics,json=alertMetrics,proto3" json:"alert_metrics,omitempty"`
+	PublicId          string                 `protobuf:"bytes,17,opt,name=public_id,json=publicId,proto3" json:"public_id,omitempty"`
+}
+
+func (x *ResponseReport) Reset() {
+	*x = ResponseReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseReport) ProtoMessage() {}
+
+func (x *ResponseReport) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseReport.ProtoReflect.Descriptor instead.
+func (*ResponseReport) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResponseReport) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ResponseReport) GetAlertCount() int32 {
+	if x != nil {
+		return x.AlertCount
+	}
+	return 0
+}
+
+func (x *ResponseReport) GetAnalysisTime() int32 {
+	if x != nil {
+		return x.AnalysisTime
+	}
+	return 0
+}
+
+func (x *ResponseReport) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ResponseReport) GetPredict() []int32 {
+	if x != nil {
+		return x.Predict
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ResponseReport) GetNormalRatio() float64 {
+	if x != nil {
+		return x.NormalRatio
+	}
+	return 0
+}
+
+func (x *ResponseReport) GetNeckAngles() []float64 {
+	if x != nil {
+		return x.NeckAngles
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetDistances() []float64 {
+	if x != nil {
+		return x.Distances
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetStatusFrequencies() map[string]int32 {
+	if x != nil {
+		return x.StatusFrequencies
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetScoringProfile() string {
+	if x != nil {
+		return x.ScoringProfile
+	}
+	return ""
+}
+
+func (x *ResponseReport) GetBreakdown() *ScoreBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetAlertTimeline() []*AlertEvent {
+	if x != nil {
+		return x.AlertTimeline
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetAlertMetrics() *AlertMetrics {
+	if x != nil {
+		return x.AlertMetrics
+	}
+	return nil
+}
+
+func (x *ResponseReport) GetPublicId() string {
+	if x != nil {
+		return x.PublicId
+	}
+	return ""
+}
+
+type AlertEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type             string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Severity         string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`                                                  // low, medium, high
+	OccurredAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`                            // Required when recording events
+	CorrectedAfterMs *int64                 `protobuf:"varint,5,opt,name=corrected_after_ms,json=correctedAfterMs,proto3,oneof" json:"corrected_after_ms,omitempty"` // Unset when the user never corrected their posture
+}
+
+func (x *AlertEvent) Reset() {
+	*x = AlertEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlertEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertEvent) ProtoMessage() {}
+
+func (x *AlertEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertEvent.ProtoReflect.Descriptor instead.
+func (*AlertEvent) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AlertEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlertEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetCorrectedAfterMs() int64 {
+	if x != nil && x.CorrectedAfterMs != nil {
+		return *x.CorrectedAfterMs
+	}
+	return 0
+}
+
+type AlertMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count               int32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Corrected           int32   `protobuf:"varint,2,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	MeanTimeToCorrectMs float64 `protobuf:"fixed64,3,opt,name=mean_time_to_correct_ms,json=meanTimeToCorrectMs,proto3" json:"mean_time_to_correct_ms,omitempty"`
+	AlertsPerHour       float64 `protobuf:"fixed64,4,opt,name=alerts_per_hour,json=alertsPerHour,proto3" json:"alerts_per_hour,omitempty"`
+}
+
+func (x *AlertMetrics) Reset() {
+	*x = AlertMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlertMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertMetrics) ProtoMessage() {}
+
+func (x *AlertMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertMetrics.ProtoReflect.Descriptor instead.
+func (*AlertMetrics) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AlertMetrics) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AlertMetrics) GetCorrected() int32 {
+	if x != nil {
+		return x.Corrected
+	}
+	return 0
+}
+
+func (x *AlertMetrics) GetMeanTimeToCorrectMs() float64 {
+	if x != nil {
+		return x.MeanTimeToCorrectMs
+	}
+	return 0
+}
+
+func (x *AlertMetrics) GetAlertsPerHour() float64 {
+	if x != nil {
+		return x.AlertsPerHour
+	}
+	return 0
+}
+
+type RequestAlertEvents struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Deprecated: Marked as deprecated in protos/report/report.proto.
+	ReportId       uint64        `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Events         []*AlertEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`                                         // id is ignored
+	ReportPublicId string        `protobuf:"bytes,3,opt,name=report_public_id,json=reportPublicId,proto3" json:"report_public_id,omitempty"` // Used instead of report_id when set
+}
+
+func (x *RequestAlertEvents) Reset() {
+	*x = RequestAlertEvents{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestAlertEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAlertEvents) ProtoMessage() {}
+
+func (x *RequestAlertEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAlertEvents.ProtoReflect.Descriptor instead.
+func (*RequestAlertEvents) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{28}
+}
+
+// Deprecated: Marked as deprecated in protos/report/report.proto.
+func (x *RequestAlertEvents) GetReportId() uint64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+func (x *RequestAlertEvents) GetEvents() []*AlertEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *RequestAlertEvents) GetReportPublicId() string {
+	if x != nil {
+		return x.ReportPublicId
+	}
+	return ""
+}
+
+type ScoreBand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Class         string  `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`                                        // normal, abnormal
+	MinConfidence float64 `protobuf:"fixed64,2,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"` // 0 for the default band
+	Weight        float64 `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Frames        int32   `protobuf:"varint,4,opt,name=frames,proto3" json:"frames,omitempty"`
+	Points        float64 `protobuf:"fixed64,5,opt,name=points,proto3" json:"points,omitempty"`
+	Deduction     float64 `protobuf:"fixed64,6,opt,name=deduction,proto3" json:"deduction,omitempty"`
+}
+
+func (x *ScoreBand) Reset() {
+	*x = ScoreBand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoreBand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreBand) ProtoMessage() {}
+
+func (x *ScoreBand) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreBand.ProtoReflect.Descriptor instead.
+func (*ScoreBand) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ScoreBand) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *ScoreBand) GetMinConfidence() float64 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *ScoreBand) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ScoreBand) GetFrames() int32 {
+	if x != nil {
+		return x.Frames
+	}
+	return 0
+}
+
+func (x *ScoreBand) GetPoints() float64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *ScoreBand) GetDeduction() float64 {
+	if x != nil {
+		return x.Deduction
+	}
+	return 0
+}
+
+type ScoreBreakdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalFrames   int32        `protobuf:"varint,1,opt,name=total_frames,json=totalFrames,proto3" json:"total_frames,omitempty"`
+	Bands         []*ScoreBand `protobuf:"bytes,2,rep,name=bands,proto3" json:"bands,omitempty"`
+	TopDeductions []*ScoreBand `protobuf:"bytes,3,rep,name=top_deductions,json=topDeductions,proto3" json:"top_deductions,omitempty"`
+}
+
+func (x *ScoreBreakdown) Reset() {
+	*x = ScoreBreakdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoreBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreBreakdown) ProtoMessage() {}
+
+func (x *ScoreBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreBreakdown.ProtoReflect.Descriptor instead.
+func (*ScoreBreakdown) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ScoreBreakdown) GetTotalFrames() int32 {
+	if x != nil {
+		return x.TotalFrames
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetBands() []*ScoreBand {
+	if x != nil {
+		return x.Bands
+	}
+	return nil
+}
+
+func (x *ScoreBreakdown) GetTopDeductions() []*ScoreBand {
+	if x != nil {
+		return x.TopDeductions
+	}
+	return nil
+}
+
+type ResponseReports struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reports []*ResponseReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+}
+
+func (x *ResponseReports) Reset() {
+	*x = ResponseReports{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_report_report_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseReports) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseReports) ProtoMessage() {}
+
+func (x *ResponseReports) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_report_report_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseReports.ProtoReflect.Descriptor instead.
+func (*ResponseReports) Descriptor() ([]byte, []int) {
+	return file_protos_report_report_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ResponseReports) GetReports() []*ResponseReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+var File_protos_report_report_proto protoreflect.FileDescriptor
+
+var file_protos_report_report_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2f,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x88, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x69, 0x64,
+	0x65, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x69,
+	0x64, 0x65, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x61, 0x6c, 0x65,
+	0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x22, 0x24, 0x0a, 0x12, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x69, 0x73, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x93, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x4a, 0x6f, 0x62, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x64, 0x4a, 0x04, 0x08, 0x03, 0x10,
+	0x04, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x22, 0x91, 0x01, 0x0a,
+	0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x61, 0x6c, 0x65,
+	0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x22, 0x69, 0x0a, 0x15, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x30, 0x0a, 0x04, 0x69, 0x6e, 0x66,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x49,
+	0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x05, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x70, 0x0a, 0x14, 0x4b,
+	0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x61, 0x6e, 0x61,
+	0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x26, 0x0a,
+	0x08, 0x4c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x01, 0x79, 0x22, 0x73, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x0d, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x73,
+	0x68, 0x6f, 0x75, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x52,
+	0x0c, 0x6c, 0x65, 0x66, 0x74, 0x53, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x2b, 0x0a,
+	0x08, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x65, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72,
+	0x6b, 0x52, 0x07, 0x6c, 0x65, 0x66, 0x74, 0x45, 0x61, 0x72, 0x22, 0x3f, 0x0a, 0x0e, 0x4b, 0x65,
+	0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x06,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x17,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4b, 0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41,
+	0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x32, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4b,
+	0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x49,
+	0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x30, 0x0a, 0x06, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x73, 0x48, 0x00, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x42, 0x06, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x29, 0x0a, 0x13, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x22, 0x62, 0x0a, 0x0c, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73, 0x12,
+	0x33, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x22, 0x67, 0x0a, 0x0c, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x5f,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65,
+	0x64, 0x4d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xb0, 0x01,
+	0x0a, 0x15, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x05,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x48, 0x00, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48,
+	0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0xbc, 0x01, 0x0a, 0x0c, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64,
+	0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6c, 0x61, 0x70, 0x73,
+	0x65, 0x64, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f,
+	0x72, 0x6d, 0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x24, 0x0a,
+	0x0e, 0x68, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x68, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x46, 0x6f,
+	0x72, 0x4d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x22,
+	0x80, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74,
+	0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x40, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42,
+	0x02, 0x18, 0x01, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x49, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x11,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x1f, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x49, 0x64, 0x22, 0x89, 0x01, 0x0a, 0x0f, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x01, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48,
+	0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01,
+	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61, 0x78,
+	0x22, 0xd3, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x49, 0x64, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x09, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0xb3,
+	0x01, 0x0a, 0x0c, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x41,
+	0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73,
+	0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0x43, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x2a, 0x0a,
+	0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xf0, 0x05, 0x0a, 0x0e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x61, 0x6c, 0x65, 0x72,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73,
+	0x69, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x61,
+	0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x07, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x52, 0x61, 0x74,
+	0x69, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x63, 0x6b, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x6e, 0x65, 0x63, 0x6b, 0x41, 0x6e, 0x67,
+	0x6c, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x12, 0x5c, 0x0a, 0x12, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x66, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x34, 0x0a, 0x09, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x09,
+	0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x39, 0x0a, 0x0e, 0x61, 0x6c, 0x65,
+	0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0f, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0d, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x39, 0x0a, 0x0d, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x5f, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x52, 0x0c, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x64, 0x1a, 0x44, 0x0a, 0x16,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x69, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x02, 0x69, 0x64, 0x22, 0xd3, 0x01, 0x0a,
+	0x0a, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x6f,
+	0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x31, 0x0a, 0x12, 0x63, 0x6f, 0x72, 0x72,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x10, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x88, 0x01, 0x01, 0x42, 0x15, 0x0a, 0x13, 0x5f,
+	0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f,
+	0x6d, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x0c, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f,
+	0x72, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x17, 0x6d, 0x65, 0x61, 0x6e, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f,
+	0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x6d, 0x65, 0x61, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x54, 0x6f, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x4d, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x50, 0x65,
+	0x72, 0x48, 0x6f, 0x75, 0x72, 0x22, 0x8b, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x09,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42,
+	0x02, 0x18, 0x01, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x2a, 0x0a,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x49, 0x64, 0x22, 0xae, 0x01, 0x0a, 0x09, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x6e,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x64, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x65, 0x64, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x96, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72,
+	0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x62, 0x61,
+	0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x52, 0x05, 0x62, 0x61,
+	0x6e, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x0e, 0x74, 0x6f, 0x70, 0x5f, 0x64, 0x65, 0x64, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x52, 0x0d,
+	0x74, 0x6f, 0x70, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x43, 0x0a,
+	0x0f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x12, 0x30, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x32, 0xc8, 0x06, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x69, 0x73, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69,
+	0x73, 0x54, 0x79, 0x70, 0x65, 0x73, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x08, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x69, 0x73, 0x12, 0x17, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x1a, 0x1b, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x4a, 0x6f, 0x62, 0x12, 0x1a, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x4a, 0x6f, 0x62, 0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x69, 0x73, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x73, 0x69, 0x73, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x28, 0x01, 0x12, 0x4f, 0x0a, 0x10, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x7a, 0x65, 0x4b, 0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1f,
+	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4b,
+	0x65, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x1a,
+	0x16, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x28, 0x01, 0x12, 0x55, 0x0a, 0x0e, 0x50,
+	0x6f, 0x73, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x6f,
+	0x73, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x1e, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x6f,
+	0x73, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x49, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x41, 0x6c, 0x65, 0x72,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x1a, 0x16, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x12, 0x3f, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x00, 0x12, 0x45,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12,
+	0x19, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x16, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x1a,
+	0x1a, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x00, 0x42, 0x14, 0x5a,
+	0x12, 0x62, 0x61, 0x72, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protos_report_report_proto_rawDescOnce sync.Once
+	file_protos_report_report_proto_rawDescData = file_protos_report_report_proto_rawDesc
+)
+
+func file_protos_report_report_proto_rawDescGZIP() []byte {
+	file_protos_report_report_proto_rawDescOnce.Do(func() {
+		file_protos_report_report_proto_rawDescData = protoimpl.X.CompressGZIP(file_protos_report_report_proto_rawDescData)
+	})
+	return file_protos_report_report_proto_rawDescData
+}
+
+var file_protos_report_report_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_protos_report_report_proto_goTypes = []interface{}{
+	(*RequestAnalysis)(nil),         // 0: report.RequestAnalysis
+	(*RequestAnalysisJob)(nil),      // 1: report.RequestAnalysisJob
+	(*ResponseAnalysisJob)(nil),     // 2: report.ResponseAnalysisJob
+	(*UploadAnalysisInfo)(nil),      // 3: report.UploadAnalysisInfo
+	(*RequestUploadAnalysis)(nil),   // 4: report.RequestUploadAnalysis
+	(*KeypointAnalysisInfo)(nil),    // 5: report.KeypointAnalysisInfo
+	(*Landmark)(nil),                // 6: report.Landmark
+	(*KeypointFrame)(nil),           // 7: report.KeypointFrame
+	(*KeypointFrames)(nil),          // 8: report.KeypointFrames
+	(*RequestKeypointAnalysis)(nil), // 9: report.RequestKeypointAnalysis
+	(*PostureSessionStart)(nil),     // 10: report.PostureSessionStart
+	(*PostureFrame)(nil),            // 11: report.PostureFrame
+	(*PostureState)(nil),            // 12: report.PostureState
+	(*RequestPostureSession)(nil),   // 13: report.RequestPostureSession
+	(*PostureEvent)(nil),            // 14: report.PostureEvent
+	(*ResponsePostureSession)(nil),  // 15: report.ResponsePostureSession
+	(*RequestReport)(nil),           // 16: report.RequestReport
+	(*RequestReports)(nil),          // 17: report.RequestReports
+	(*RequestAllReports)(nil),       // 18: report.RequestAllReports
+	(*RequestTimeseries)(nil),       // 19: report.RequestTimeseries
+	(*TimeseriesPoint)(nil),         // 20: report.TimeseriesPoint
+	(*ResponseTimeseries)(nil),      // 21: report.ResponseTimeseries
+	(*RequestAnalysisTypes)(nil),    // 22: report.RequestAnalysisTypes
+	(*AnalysisType)(nil),            // 23: report.AnalysisType
+	(*ResponseAnalysisTypes)(nil),   // 24: report.ResponseAnalysisTypes
+	(*ResponseReport)(nil),          // 25: report.ResponseReport
+	(*AlertEvent)(nil),              // 26: report.AlertEvent
+	(*AlertMetrics)(nil),            // 27: report.AlertMetrics
+	(*RequestAlertEvents)(nil),      // 28: report.RequestAlertEvents
+	(*ScoreBand)(nil),               // 29: report.ScoreBand
+	(*ScoreBreakdown)(nil),          // 30: report.ScoreBreakdown
+	(*ResponseReports)(nil),         // 31: report.ResponseReports
+	nil,                             // 32: report.ResponseReport.StatusFrequenciesEntry
+	(*timestamppb.Timestamp)(nil),   // 33: google.protobuf.Timestamp
+}
+var file_protos_report_report_proto_depIdxs = []int32{
+	33, // 0: report.ResponseAnalysisJob.created_at:type_name -> google.protobuf.Timestamp
+	33, // 1: report.ResponseAnalysisJob.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: report.RequestUploadAnalysis.info:type_name -> report.UploadAnalysisInfo
+	6,  // 3: report.KeypointFrame.left_shoulder:type_name -> report.Landmark
+	6,  // 4: report.KeypointFrame.left_ear:type_name -> report.Landmark
+	7,  // 5: report.KeypointFrames.frames:type_name -> report.KeypointFrame
+	5,  // 6: report.RequestKeypointAnalysis.info:type_name -> report.KeypointAnalysisInfo
+	8,  // 7: report.RequestKeypointAnalysis.frames:type_name -> report.KeypointFrames
+	7,  // 8: report.PostureFrame.keypoints:type_name -> report.KeypointFrame
+	10, // 9: report.RequestPostureSession.start:type_name -> report.PostureSessionStart
+	11, // 10: report.RequestPostureSession.frame:type_name -> report.PostureFrame
+	12, // 11: report.RequestPostureSession.state:type_name -> report.PostureState
+	14, // 12: report.ResponsePostureSession.event:type_name -> report.PostureEvent
+	25, // 13: report.ResponsePostureSession.report:type_name -> report.ResponseReport
+	20, // 14: report.ResponseTimeseries.points:type_name -> report.TimeseriesPoint
+	23, // 15: report.ResponseAnalysisTypes.types:type_name -> report.AnalysisType
+	32, // 16: report.ResponseReport.status_frequencies:type_name -> report.ResponseReport.StatusFrequenciesEntry
+	33, // 17: report.ResponseReport.created_at:type_name -> google.protobuf.Timestamp
+	30, // 18: report.ResponseReport.breakdown:type_name -> report.ScoreBreakdown
+	26, // 19: report.ResponseReport.alert_timeline:type_name -> report.AlertEvent
+	27, // 20: report.ResponseReport.alert_metrics:type_name -> report.AlertMetrics
+	33, // 21: report.AlertEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	26, // 22: report.RequestAlertEvents.events:type_name -> report.AlertEvent
+	29, // 23: report.ScoreBreakdown.bands:type_name -> report.ScoreBand
+	29, // 24: report.ScoreBreakdown.top_deductions:type_name -> report.ScoreBand
+	25, // 25: report.ResponseReports.reports:type_name -> report.ResponseReport
+	22, // 26: report.ReportService.GetAnalysisTypes:input_type -> report.RequestAnalysisTypes
+	0,  // 27: report.ReportService.Analysis:input_type -> report.RequestAnalysis
+	1,  // 28: report.ReportService.GetAnalysisJob:input_type -> report.RequestAnalysisJob
+	4,  // 29: report.ReportService.UploadAnalysis:input_type -> report.RequestUploadAnalysis
+	9,  // 30: report.ReportService.AnalyzeKeypoints:input_type -> report.RequestKeypointAnalysis
+	13, // 31: report.ReportService.PostureSession:input_type -> report.RequestPostureSession
+	28, // 32: report.ReportService.RecordAlertEvents:input_type -> report.RequestAlertEvents
+	17, // 33: report.ReportService.GetReports:input_type -> report.RequestReports
+	18, // 34: report.ReportService.GetAllReports:input_type -> report.RequestAllReports
+	16, // 35: report.ReportService.GetReport:input_type -> report.RequestReport
+	19, // 36: report.ReportService.GetTimeseries:input_type -> report.RequestTimeseries
+	24, // 37: report.ReportService.GetAnalysisTypes:output_type -> report.ResponseAnalysisTypes
+	2,  // 38: report.ReportService.Analysis:output_type -> report.ResponseAnalysisJob
+	2,  // 39: report.ReportService.GetAnalysisJob:output_type -> report.ResponseAnalysisJob
+	2,  // 40: report.ReportService.UploadAnalysis:output_type -> report.ResponseAnalysisJob
+	25, // 41: report.ReportService.AnalyzeKeypoints:output_type -> report.ResponseReport
+	15, // 42: report.ReportService.PostureSession:output_type -> report.ResponsePostureSession
+	25, // 43: report.ReportService.RecordAlertEvents:output_type -> report.ResponseReport
+	31, // 44: report.ReportService.GetReports:output_type -> report.ResponseReports
+	31, // 45: report.ReportService.GetAllReports:output_type -> report.ResponseReports
+	25, // 46: report.ReportService.GetReport:output_type -> report.ResponseReport
+	21, // 47: report.ReportService.GetTimeseries:output_type -> report.ResponseTimeseries
+	37, // [37:48] is the sub-list for method output_type
+	26, // [26:37] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_protos_report_report_proto_init() }
+func file_protos_report_report_proto_init() {
+	if File_protos_report_report_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protos_report_report_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestAnalysis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestAnalysisJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseAnalysisJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAnalysisInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestUploadAnalysis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeypointAnalysisInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Landmark); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeypointFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeypointFrames); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestKeypointAnalysis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostureSessionStart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostureFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostureState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestPostureSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostureEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponsePostureSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestReports); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestAllReports); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestTimeseries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeseriesPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseTimeseries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestAnalysisTypes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnalysisType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseAnalysisTypes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlertEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlertMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestAlertEvents); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScoreBand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScoreBreakdown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_report_report_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseReports); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_protos_report_report_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*RequestUploadAnalysis_Info)(nil),
+		(*RequestUploadAnalysis_Chunk)(nil),
+	}
+	file_protos_report_report_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*RequestKeypointAnalysis_Info)(nil),
+		(*RequestKeypointAnalysis_Frames)(nil),
+	}
+	file_protos_report_report_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*RequestPostureSession_Start)(nil),
+		(*RequestPostureSession_Frame)(nil),
+		(*RequestPostureSession_State)(nil),
+	}
+	file_protos_report_report_proto_msgTypes[15].OneofWrappers = []interface{}{
+		(*ResponsePostureSession_Event)(nil),
+		(*ResponsePostureSession_Report)(nil),
+	}
+	file_protos_report_report_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_protos_report_report_proto_msgTypes[26].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protos_report_report_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   33,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_protos_report_report_proto_goTypes,
+		DependencyIndexes: file_protos_report_report_proto_depIdxs,
+		MessageInfos:      file_protos_report_report_proto_msgTypes,
+	}.Build()
+	File_protos_report_report_proto = out.File
+	file_protos_report_report_proto_rawDesc = nil
+	file_protos_report_report_proto_goTypes = nil
+	file_protos_report_report_proto_depIdxs = nil
+}
@@ -0,0 +1,566 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.25.1
+// source: protos/report/report.proto
+
+package report
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ReportServiceClient is the client API for ReportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReportServiceClient interface {
+	GetAnalysisTypes(ctx context.Context, in *RequestAnalysisTypes, opts ...grpc.CallOption) (*ResponseAnalysisTypes, error)
+	Analysis(ctx context.Context, in *RequestAnalysis, opts ...grpc.CallOption) (*ResponseAnalysisJob, error)
+	GetAnalysisJob(ctx context.Context, in *RequestAnalysisJob, opts ...grpc.CallOption) (*ResponseAnalysisJob, error)
+	UploadAnalysis(ctx context.Context, opts ...grpc.CallOption) (ReportService_UploadAnalysisClient, error)
+	AnalyzeKeypoints(ctx context.Context, opts ...grpc.CallOption) (ReportService_AnalyzeKeypointsClient, error)
+	PostureSession(ctx context.Context, opts ...grpc.CallOption) (ReportService_PostureSessionClient, error)
+	RecordAlertEvents(ctx context.Context, in *RequestAlertEvents, opts ...grpc.CallOption) (*ResponseReport, error)
+	GetReports(ctx context.Context, in *RequestReports, opts ...grpc.CallOption) (*ResponseReports, error)
+	GetAllReports(ctx context.Context, in *RequestAllReports, opts ...grpc.CallOption) (*ResponseReports, error)
+	GetReport(ctx context.Context, in *RequestReport, opts ...grpc.CallOption) (*ResponseReport, error)
+	GetTimeseries(ctx context.Context, in *RequestTimeseries, opts ...grpc.CallOption) (*ResponseTimeseries, error)
+}
+
+type reportServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReportServiceClient(cc grpc.ClientConnInterface) ReportServiceClient {
+	return &reportServiceClient{cc}
+}
+
+func (c *reportServiceClient) GetAnalysisTypes(ctx context.Context, in *RequestAnalysisTypes, opts ...grpc.CallOption) (*ResponseAnalysisTypes, error) {
+	out := new(ResponseAnalysisTypes)
+	err := c.cc.Invoke(ctx, "/report.ReportService/GetAnalysisTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) Analysis(ctx context.Context, in *RequestAnalysis, opts ...grpc.CallOption) (*ResponseAnalysisJob, error) {
+	out := new(ResponseAnalysisJob)
+	err := c.cc.Invoke(ctx, "/report.ReportService/Analysis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetAnalysisJob(ctx context.Context, in *RequestAnalysisJob, opts ...grpc.CallOption) (*ResponseAnalysisJob, error) {
+	out := new(ResponseAnalysisJob)
+	err := c.cc.Invoke(ctx, "/report.ReportService/GetAnalysisJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) UploadAnalysis(ctx context.Context, opts ...grpc.CallOption) (ReportService_UploadAnalysisClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReportService_ServiceDesc.Streams[0], "/report.ReportService/UploadAnalysis", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reportServiceUploadAnalysisClient{stream}
+	return x, nil
+}
+
+type ReportService_UploadAnalysisClient interface {
+	Send(*RequestUploadAnalysis) error
+	CloseAndRecv() (*ResponseAnalysisJob, error)
+	grpc.ClientStream
+}
+
+type reportServiceUploadAnalysisClient struct {
+	grpc.ClientStream
+}
+
+func (x *reportServiceUploadAnalysisClient) Send(m *RequestUploadAnalysis) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *reportServiceUploadAnalysisClient) CloseAndRecv() (*ResponseAnalysisJob, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ResponseAnalysisJob)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *reportServiceClient) AnalyzeKeypoints(ctx context.Context, opts ...grpc.CallOption) (ReportService_AnalyzeKeypointsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReportService_ServiceDesc.Streams[1], "/report.ReportService/AnalyzeKeypoints", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reportServiceAnalyzeKeypointsClient{stream}
+	return x, nil
+}
+
+type ReportService_AnalyzeKeypointsClient interface {
+	Send(*RequestKeypointAnalysis) error
+	CloseAndRecv() (*ResponseReport, error)
+	grpc.ClientStream
+}
+
+type reportServiceAnalyzeKeypointsClient struct {
+	grpc.ClientStream
+}
+
+func (x *reportServiceAnalyzeKeypointsClient) Send(m *RequestKeypointAnalysis) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *reportServiceAnalyzeKeypointsClient) CloseAndRecv() (*ResponseReport, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ResponseReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *reportServiceClient) PostureSession(ctx context.Context, opts ...grpc.CallOption) (ReportService_PostureSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReportService_ServiceDesc.Streams[2], "/report.ReportService/PostureSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reportServicePostureSessionClient{stream}
+	return x, nil
+}
+
+type ReportService_PostureSessionClient interface {
+	Send(*RequestPostureSession) error
+	Recv() (*ResponsePostureSession, error)
+	grpc.ClientStream
+}
+
+type reportServicePostureSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *reportServicePostureSessionClient) Send(m *RequestPostureSession) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *reportServicePostureSessionClient) Recv() (*ResponsePostureSession, error) {
+	m := new(ResponsePostureSession)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *reportServiceClient) RecordAlertEvents(ctx context.Context, in *RequestAlertEvents, opts ...grpc.CallOption) (*ResponseReport, error) {
+	out := new(ResponseReport)
+	err := c.cc.Invoke(ctx, "/report.ReportService/RecordAlertEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetReports(ctx context.Context, in *RequestReports, opts ...grpc.CallOption) (*ResponseReports, error) {
+	out := new(ResponseReports)
+	err := c.cc.Invoke(ctx, "/report.ReportService/GetReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetAllReports(ctx context.Context, in *RequestAllReports, opts ...grpc.CallOption) (*ResponseReports, error) {
+	out := new(ResponseReports)
+	err := c.cc.Invoke(ctx, "/report.ReportService/GetAllReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetReport(ctx context.Context, in *RequestReport, opts ...grpc.CallOption) (*ResponseReport, error) {
+	out := new(ResponseReport)
+	err := c.cc.Invoke(ctx, "/report.ReportService/GetReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetTimeseries(ctx context.Context, in *RequestTimeseries, opts ...grpc.CallOption) (*ResponseTimeseries, error) {
+	out := new(ResponseTimeseries)
+	err := c.cc.Invoke(ctx, "/report.ReportService/GetTimeseries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportServiceServer is the server API for ReportService service.
+// All implementations must embed UnimplementedReportServiceServer
+// for forward compatibility
+type ReportServiceServer interface {
+	GetAnalysisTypes(context.Context, *RequestAnalysisTypes) (*ResponseAnalysisTypes, error)
+	Analysis(context.Context, *RequestAnalysis) (*ResponseAnalysisJob, error)
+	GetAnalysisJob(context.Context, *RequestAnalysisJob) (*ResponseAnalysisJob, error)
+	UploadAnalysis(ReportService_UploadAnalysisServer) error
+	AnalyzeKeypoints(ReportService_AnalyzeKeypointsServer) error
+	PostureSession(ReportService_PostureSessionServer) error
+	RecordAlertEvents(context.Context, *RequestAlertEvents) (*ResponseReport, error)
+	GetReports(context.Context, *RequestReports) (*ResponseReports, error)
+	GetAllReports(context.Context, *RequestAllReports) (*ResponseReports, error)
+	GetReport(context.Context, *RequestReport) (*ResponseReport, error)
+	GetTimeseries(context.Context, *RequestTimeseries) (*ResponseTimeseries, error)
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+// UnimplementedReportServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReportServiceServer struct {
+}
+
+func (UnimplementedReportServiceServer) GetAnalysisTypes(context.Context, *RequestAnalysisTypes) (*ResponseAnalysisTypes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnalysisTypes not implemented")
+}
+func (UnimplementedReportServiceServer) Analysis(context.Context, *RequestAnalysis) (*ResponseAnalysisJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Analysis not implemented")
+}
+func (UnimplementedReportServiceServer) GetAnalysisJob(context.Context, *RequestAnalysisJob) (*ResponseAnalysisJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnalysisJob not implemented")
+}
+func (UnimplementedReportServiceServer) UploadAnalysis(ReportService_UploadAnalysisServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAnalysis not implemented")
+}
+func (UnimplementedReportServiceServer) AnalyzeKeypoints(ReportService_AnalyzeKeypointsServer) error {
+	return status.Errorf(codes.Unimplemented, "method AnalyzeKeypoints not implemented")
+}
+func (UnimplementedReportServiceServer) PostureSession(ReportService_PostureSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method PostureSession not implemented")
+}
+func (UnimplementedReportServiceServer) RecordAlertEvents(context.Context, *RequestAlertEvents) (*ResponseReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordAlertEvents not implemented")
+}
+func (UnimplementedReportServiceServer) GetReports(context.Context, *RequestReports) (*ResponseReports, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReports not implemented")
+}
+func (UnimplementedReportServiceServer) GetAllReports(context.Context, *RequestAllReports) (*ResponseReports, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllReports not implemented")
+}
+func (UnimplementedReportServiceServer) GetReport(context.Context, *RequestReport) (*ResponseReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedReportServiceServer) GetTimeseries(context.Context, *RequestTimeseries) (*ResponseTimeseries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeseries not implemented")
+}
+func (UnimplementedReportServiceServer) mustEmbedUnimplementedReportServiceServer() {}
+
+// UnsafeReportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReportServiceServer will
+// result in compilation errors.
+type UnsafeReportServiceServer interface {
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+func RegisterReportServiceServer(s grpc.ServiceRegistrar, srv ReportServiceServer) {
+	s.RegisterService(&ReportService_ServiceDesc, srv)
+}
+
+func _ReportService_GetAnalysisTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAnalysisTypes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetAnalysisTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/GetAnalysisTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetAnalysisTypes(ctx, req.(*RequestAnalysisTypes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_Analysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAnalysis)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).Analysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/Analysis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).Analysis(ctx, req.(*RequestAnalysis))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetAnalysisJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAnalysisJob)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetAnalysisJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/GetAnalysisJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetAnalysisJob(ctx, req.(*RequestAnalysisJob))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_UploadAnalysis_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReportServiceServer).UploadAnalysis(&reportServiceUploadAnalysisServer{stream})
+}
+
+type ReportService_UploadAnalysisServer interface {
+	SendAndClose(*ResponseAnalysisJob) error
+	Recv() (*RequestUploadAnalysis, error)
+	grpc.ServerStream
+}
+
+type reportServiceUploadAnalysisServer struct {
+	grpc.ServerStream
+}
+
+func (x *reportServiceUploadAnalysisServer) SendAndClose(m *ResponseAnalysisJob) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *reportServiceUploadAnalysisServer) Recv() (*RequestUploadAnalysis, error) {
+	m := new(RequestUploadAnalysis)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ReportService_AnalyzeKeypoints_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReportServiceServer).AnalyzeKeypoints(&reportServiceAnalyzeKeypointsServer{stream})
+}
+
+type ReportService_AnalyzeKeypointsServer interface {
+	SendAndClose(*ResponseReport) error
+	Recv() (*RequestKeypointAnalysis, error)
+	grpc.ServerStream
+}
+
+type reportServiceAnalyzeKeypointsServer struct {
+	grpc.ServerStream
+}
+
+func (x *reportServiceAnalyzeKeypointsServer) SendAndClose(m *ResponseReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *reportServiceAnalyzeKeypointsServer) Recv() (*RequestKeypointAnalysis, error) {
+	m := new(RequestKeypointAnalysis)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ReportService_PostureSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReportServiceServer).PostureSession(&reportServicePostureSessionServer{stream})
+}
+
+type ReportService_PostureSessionServer interface {
+	Send(*ResponsePostureSession) error
+	Recv() (*RequestPostureSession, error)
+	grpc.ServerStream
+}
+
+type reportServicePostureSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *reportServicePostureSessionServer) Send(m *ResponsePostureSession) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *reportServicePostureSessionServer) Recv() (*RequestPostureSession, error) {
+	m := new(RequestPostureSession)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ReportService_RecordAlertEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAlertEvents)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).RecordAlertEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/RecordAlertEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).RecordAlertEvents(ctx, req.(*RequestAlertEvents))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReports)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/GetReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetReports(ctx, req.(*RequestReports))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetAllReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAllReports)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetAllReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/GetAllReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetAllReports(ctx, req.(*RequestAllReports))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/GetReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetReport(ctx, req.(*RequestReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetTimeseries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestTimeseries)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetTimeseries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/report.ReportService/GetTimeseries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetTimeseries(ctx, req.(*RequestTimeseries))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReportService_ServiceDesc is the grpc.ServiceDesc for ReportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "report.ReportService",
+	HandlerType: (*ReportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAnalysisTypes",
+			Handler:    _ReportService_GetAnalysisTypes_Handler,
+		},
+		{
+			MethodName: "Analysis",
+			Handler:    _ReportService_Analysis_Handler,
+		},
+		{
+			MethodName: "GetAnalysisJob",
+			Handler:    _ReportService_GetAnalysisJob_Handler,
+		},
+		{
+			MethodName: "RecordAlertEvents",
+			Handler:    _ReportService_RecordAlertEvents_Handler,
+		},
+		{
+			MethodName: "GetReports",
+			Handler:    _ReportService_GetReports_Handler,
+		},
+		{
+			MethodName: "GetAllReports",
+			Handler:    _ReportService_GetAllReports_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _ReportService_GetReport_Handler,
+		},
+		{
+			MethodName: "GetTimeseries",
+			Handler:    _ReportService_GetTimeseries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAnalysis",
+			Handler:       _ReportService_UploadAnalysis_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "AnalyzeKeypoints",
+			Handler:       _ReportService_AnalyzeKeypoints_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PostureSession",
+			Handler:       _ReportService_PostureSession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "protos/report/report.proto",
+}
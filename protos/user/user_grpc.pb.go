@@ -23,6 +23,8 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserServiceClient interface {
 	Login(ctx context.Context, in *RequestCreateUser, opts ...grpc.CallOption) (*ResponseToken, error)
+	Refresh(ctx context.Context, in *RequestRefreshToken, opts ...grpc.CallOption) (*ResponseToken, error)
+	Logout(ctx context.Context, in *RequestRefreshToken, opts ...grpc.CallOption) (*Empty, error)
 	GetUserInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ResponseUser, error)
 	UpdateUserInfo(ctx context.Context, in *RequestUpdateUser, opts ...grpc.CallOption) (*ResponseUser, error)
 	DeleteUser(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
@@ -46,6 +48,24 @@ func (c *userServiceClient) Login(ctx context.Context, in *RequestCreateUser, op
 	return out, nil
 }
 
+func (c *userServiceClient) Refresh(ctx context.Context, in *RequestRefreshToken, opts ...grpc.CallOption) (*ResponseToken, error) {
+	out := new(ResponseToken)
+	err := c.cc.Invoke(ctx, "/user.UserService/Refresh", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Logout(ctx context.Context, in *RequestRefreshToken, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/user.UserService/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetUserInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ResponseUser, error) {
 	out := new(ResponseUser)
 	err := c.cc.Invoke(ctx, "/user.UserService/GetUserInfo", in, out, opts...)
@@ -87,6 +107,8 @@ func (c *userServiceClient) UpdateFcmToken(ctx context.Context, in *RequestUpdat
 // for forward compatibility
 type UserServiceServer interface {
 	Login(context.Context, *RequestCreateUser) (*ResponseToken, error)
+	Refresh(context.Context, *RequestRefreshToken) (*ResponseToken, error)
+	Logout(context.Context, *RequestRefreshToken) (*Empty, error)
 	GetUserInfo(context.Context, *Empty) (*ResponseUser, error)
 	UpdateUserInfo(context.Context, *RequestUpdateUser) (*ResponseUser, error)
 	DeleteUser(context.Context, *Empty) (*Empty, error)
@@ -101,6 +123,12 @@ type UnimplementedUserServiceServer struct {
 func (UnimplementedUserServiceServer) Login(context.Context, *RequestCreateUser) (*ResponseToken, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedUserServiceServer) Refresh(context.Context, *RequestRefreshToken) (*ResponseToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
+func (UnimplementedUserServiceServer) Logout(context.Context, *RequestRefreshToken) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
 func (UnimplementedUserServiceServer) GetUserInfo(context.Context, *Empty) (*ResponseUser, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserInfo not implemented")
 }
@@ -144,6 +172,42 @@ func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRefreshToken)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/Refresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Refresh(ctx, req.(*RequestRefreshToken))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRefreshToken)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Logout(ctx, req.(*RequestRefreshToken))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -227,6 +291,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _UserService_Login_Handler,
 		},
+		{
+			MethodName: "Refresh",
+			Handler:    _UserService_Refresh_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _UserService_Logout_Handler,
+		},
 		{
 			MethodName: "GetUserInfo",
 			Handler:    _UserService_GetUserInfo_Handler,
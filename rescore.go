@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	reportRepository "gdsc/baro/app/report/repositories"
+	reportService "gdsc/baro/app/report/services"
+	"gdsc/baro/global/config"
+	"log"
+	"os"
+)
+
+// runRescore implements "rescore": it recalculates the scores of stored reports with a scoring profile.
+//
+//	baro rescore [-profile v2] [-batch 200] [-dry-run]
+func runRescore(args []string) {
+	flags := flag.NewFlagSet("rescore", flag.ExitOnError)
+	profile := flags.String("profile", "", "scoring profile version to rescore with (default: the active profile)")
+	batchSize := flags.Int("batch", reportService.DefaultRescoreBatchSize, "reports to load per batch")
+	dryRun := flags.Bool("dry-run", false, "print score changes without saving them")
+	flags.Parse(args)
+
+	DB, connectionErr := config.ConnectDatabase()
+	if connectionErr != nil {
+		log.Fatal(connectionErr)
+	}
+
+	scoringService := reportService.NewScoringService(reportRepository.NewScoringProfileRepository(DB))
+	rescoreService := reportService.NewRescoreService(reportRepository.NewReportRepository(DB), scoringService)
+	rescoreService.OnResult = func(result reportService.RescoreResult) {
+		fmt.Printf("report %d: %.2f (%s) -> %.2f (%+.2f)\n", result.ReportID, result.FromScore, result.FromVersion, result.ToScore, result.Delta)
+	}
+	rescoreService.OnProgress = func(progress reportService.RescoreProgress) {
+		log.Printf("Processed %d reports up to id %d: %d rescored, %d skipped", progress.Processed, progress.LastID, progress.Rescored, progress.Skipped)
+	}
+
+	progress, version, err := rescoreService.Rescore(reportService.RescoreOptions{
+		Version:   *profile,
+		BatchSize: *batchSize,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mode := "Rescored"
+	if *dryRun {
+		mode = "Dry run, would rescore"
+	}
+	fmt.Fprintf(os.Stderr, "%s %d of %d reports with scoring profile %s (%d skipped)\n", mode, progress.Rescored, progress.Processed, version, progress.Skipped)
+}